@@ -1,846 +1,22 @@
-// Code generated by swaggo/swag. DO NOT EDIT.
-
+// Package api публикует OpenAPI спецификацию, сгенерированную из
+// internal/grpc/pvz_v1/pvz.proto (make proto-gen), для Swagger UI
 package api
 
-import "github.com/swaggo/swag"
+import (
+	_ "embed"
+
+	"github.com/swaggo/swag"
+)
+
+//go:embed swagger.json
+var doc string
 
-const docTemplate = `{
-    "schemes": {{ marshal .Schemes }},
-    "swagger": "2.0",
-    "info": {
-        "description": "{{escape .Description}}",
-        "title": "{{.Title}}",
-        "contact": {},
-        "version": "{{.Version}}"
-    },
-    "host": "{{.Host}}",
-    "basePath": "{{.BasePath}}",
-    "paths": {
-        "/dummyLogin": {
-            "post": {
-                "description": "Создает JWT токен с указанной ролью без проверки учетных данных (для тестирования)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Упрощенная авторизация",
-                "operationId": "user-dummy-login",
-                "parameters": [
-                    {
-                        "description": "Роль для токена",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.DummyLoginRequestDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Успешная авторизация",
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserLoginResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректные данные / Неверный запрос",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Авторизует пользователя по email и паролю и возвращает JWT токен",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Авторизация пользователя",
-                "operationId": "user-login",
-                "parameters": [
-                    {
-                        "description": "Данные для авторизации",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserLoginRequestDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Успешная авторизация",
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserLoginResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректные данные",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "401": {
-                        "description": "Неверные учетные данные",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/products": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Добавляет новый товар в активную приемку для указанного ПВЗ",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "products"
-                ],
-                "summary": "Добавить товар в приемку",
-                "operationId": "add-product-to-reception",
-                "parameters": [
-                    {
-                        "description": "Информация о товаре",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.AddProductRequestDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Товар успешно добавлен",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AddProductResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректные данные / ПВЗ не найден / Нет активной приемки",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "403": {
-                        "description": "Доступ запрещен",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/pvz": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Возвращает список ПВЗ с их приемками и товарами с возможностью фильтрации по дате и пагинацией",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "pvz"
-                ],
-                "summary": "Получить список ПВЗ с пагинацией",
-                "operationId": "get-pvz-with-pagination",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Начальная дата (RFC3339)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Конечная дата (RFC3339)",
-                        "name": "endDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы (по умолчанию 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Количество элементов на странице (по умолчанию 10, максимум 30)",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Список ПВЗ с приемками и товарами",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.PVZWithReceptionsDto"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Невалидные параметры запроса",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Создает новый пункт выдачи заказов в указанном городе",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "pvz"
-                ],
-                "summary": "Завести ПВЗ",
-                "operationId": "create-pvz",
-                "parameters": [
-                    {
-                        "description": "Информация о создаваемом ПВЗ",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreatePVZRequestDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "ПВЗ успешно создан",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreatePVZResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректные данные / Неверный запрос",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "403": {
-                        "description": "Доступ запрещен",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/pvz/{pvzId}/close_last_reception": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Закрывает последнюю активную приемку для указанного ПВЗ",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "pvz"
-                ],
-                "summary": "Закрыть последнюю приемку",
-                "operationId": "close-last-reception",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Идентификатор ПВЗ",
-                        "name": "pvzId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Приемка успешно закрыта",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CloseReceptionResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректные данные / ПВЗ не найден / Приемка уже закрыта",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "403": {
-                        "description": "Доступ запрещен",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/pvz/{pvzId}/delete_last_product": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Удаляет последний добавленный товар из последней активной приемки для указанного ПВЗ",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "pvz"
-                ],
-                "summary": "Удалить последний товар",
-                "operationId": "delete-last-product",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Идентификатор ПВЗ",
-                        "name": "pvzId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Товар успешно удален"
-                    },
-                    "400": {
-                        "description": "Некорректные данные / ПВЗ не найден / Нет активной приемки / Нет товаров для удаления",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "403": {
-                        "description": "Доступ запрещен",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/receptions": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Создает новую приемку товаров для указанного ПВЗ",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "receptions"
-                ],
-                "summary": "Создать приемку",
-                "operationId": "create-reception",
-                "parameters": [
-                    {
-                        "description": "Информация о создаваемой приемке",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateReceptionRequestDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Приемка успешно создана",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateReceptionResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректные данные / ПВЗ не найден / ПВЗ уже имеет незакрытую приемку",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "403": {
-                        "description": "Доступ запрещен",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/register": {
-            "post": {
-                "description": "Регистрирует нового пользователя с указанными email, паролем и ролью",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Регистрация пользователя",
-                "operationId": "user-register",
-                "parameters": [
-                    {
-                        "description": "Данные для регистрации",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserRegisterRequestDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Пользователь успешно зарегистрирован",
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserRegisterResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректные данные / Неверный запрос",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorDto"
-                        }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "dto.AddProductRequestDto": {
-            "description": "Информация запроса о товаре при его добавлении",
-            "type": "object",
-            "properties": {
-                "pvzId": {
-                    "description": "Идентификатор ПВЗ, на который добавляется товар",
-                    "type": "string"
-                },
-                "type": {
-                    "description": "Тип товара",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.AddProductResponseDto": {
-            "description": "Информация о товаре при его добавлении",
-            "type": "object",
-            "properties": {
-                "dateTime": {
-                    "description": "Дата и время",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Идентификатор",
-                    "type": "string"
-                },
-                "receptionId": {
-                    "description": "Идентификатор приемки",
-                    "type": "string"
-                },
-                "type": {
-                    "description": "Тип товара",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CloseReceptionResponseDto": {
-            "description": "Информация о приемке при ее закрытии",
-            "type": "object",
-            "properties": {
-                "dateTime": {
-                    "description": "Дата и время приемки",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Идентификатор приемки",
-                    "type": "string"
-                },
-                "pvzId": {
-                    "description": "Идентификатор ПВЗ",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "Статус приемки",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CreatePVZRequestDto": {
-            "description": "Информация о ПВЗ при его создании",
-            "type": "object",
-            "properties": {
-                "city": {
-                    "description": "Город",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CreatePVZResponseDto": {
-            "description": "Информация о ПВЗ при его создании",
-            "type": "object",
-            "properties": {
-                "city": {
-                    "description": "Город",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Идентификатор",
-                    "type": "string"
-                },
-                "registrationDate": {
-                    "description": "Дата регистрации",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CreateReceptionRequestDto": {
-            "description": "Информация о приемке при ее создании",
-            "type": "object",
-            "properties": {
-                "pvzId": {
-                    "description": "Идентификатор ПВЗ",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CreateReceptionResponseDto": {
-            "description": "Информация о приемке при ее создании",
-            "type": "object",
-            "properties": {
-                "dateTime": {
-                    "description": "Дата и время приемки",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Идентификатор приемки",
-                    "type": "string"
-                },
-                "pvzId": {
-                    "description": "Идентификатор ПВЗ",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "Статус приемки",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.DummyLoginRequestDto": {
-            "description": "Информация о пользователе при упрощенном входе",
-            "type": "object",
-            "properties": {
-                "role": {
-                    "description": "Желаемая роль (employee || moderator)",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.ErrorDto": {
-            "description": "Информация об ошибке (DTO)",
-            "type": "object",
-            "properties": {
-                "message": {
-                    "description": "Текст ошибки",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.PVZDto": {
-            "description": "Информация о ПВЗ",
-            "type": "object",
-            "properties": {
-                "city": {
-                    "description": "Город",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Идентификатор",
-                    "type": "string"
-                },
-                "registrationDate": {
-                    "description": "Дата регистрации",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.PVZWithReceptionsDto": {
-            "description": "Информация о ПВЗ и приемках, связанных с ним",
-            "type": "object",
-            "properties": {
-                "pvz": {
-                    "description": "Информация о ПВЗ",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.PVZDto"
-                        }
-                    ]
-                },
-                "receptions": {
-                    "description": "Информация о всех приемках на ПВЗ",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ReceptionWithProductsDto"
-                    }
-                }
-            }
-        },
-        "dto.ProductDto": {
-            "description": "Информация о товаре",
-            "type": "object",
-            "properties": {
-                "dateTime": {
-                    "description": "Дата и время",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Идентификатор",
-                    "type": "string"
-                },
-                "receptionId": {
-                    "description": "Идентификатор приемки",
-                    "type": "string"
-                },
-                "type": {
-                    "description": "Тип товара",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.ReceptionDto": {
-            "description": "Информация о приемке",
-            "type": "object",
-            "properties": {
-                "dateTime": {
-                    "description": "Дата и время создания приемки",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Идентификатор приемки",
-                    "type": "string"
-                },
-                "pvzId": {
-                    "description": "Идентификатор ПВЗ",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "Статус приемки",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.ReceptionWithProductsDto": {
-            "description": "Информация о приемке и товарах в ней",
-            "type": "object",
-            "properties": {
-                "products": {
-                    "description": "Информация о всех товарах в приемке",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ProductDto"
-                    }
-                },
-                "reception": {
-                    "description": "Информация о приемке",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.ReceptionDto"
-                        }
-                    ]
-                }
-            }
-        },
-        "dto.UserLoginRequestDto": {
-            "description": "Информация о пользователе при входе в систему",
-            "type": "object",
-            "properties": {
-                "email": {
-                    "description": "Почта",
-                    "type": "string"
-                },
-                "password": {
-                    "description": "Пароль",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.UserLoginResponseDto": {
-            "description": "Информация о пользователе при входе в систему",
-            "type": "object",
-            "properties": {
-                "token": {
-                    "description": "JWT токен",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.UserRegisterRequestDto": {
-            "description": "Информация о пользователе при регистрации",
-            "type": "object",
-            "properties": {
-                "email": {
-                    "description": "Почта",
-                    "type": "string"
-                },
-                "password": {
-                    "description": "Пароль",
-                    "type": "string"
-                },
-                "role": {
-                    "description": "Роль пользователя (employee || moderator)",
-                    "type": "string"
-                }
-            }
-        },
-        "dto.UserRegisterResponseDto": {
-            "description": "Информация о пользователе при регистрации",
-            "type": "object",
-            "properties": {
-                "email": {
-                    "description": "Почта",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Идентификатор",
-                    "type": "string"
-                },
-                "role": {
-                    "description": "Роль пользователя (employee || moderator)",
-                    "type": "string"
-                }
-            }
-        }
-    },
-    "securityDefinitions": {
-        "ApiKeyAuth": {
-            "description": "Authorization check",
-            "type": "apiKey",
-            "name": "auth-x",
-            "in": "header"
-        }
-    }
-}`
+type spec struct{}
 
-// SwaggerInfo holds exported Swagger Info so clients can modify it
-var SwaggerInfo = &swag.Spec{
-	Version:          "1.0",
-	Host:             "",
-	BasePath:         "",
-	Schemes:          []string{},
-	Title:            "PVZ Service",
-	Description:      "Avito PVZ Service 2025",
-	InfoInstanceName: "swagger",
-	SwaggerTemplate:  docTemplate,
+func (spec) ReadDoc() string {
+	return doc
 }
 
 func init() {
-	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+	swag.Register(swag.Name, spec{})
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	_ "time/tzdata" // в alpine-образе нет базы часовых поясов, а она нужна для проверки timezone ПВЗ
+
+	"github.com/hamillka/avitoTechSpring25/internal/app"
+	"github.com/hamillka/avitoTechSpring25/internal/config"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"go.uber.org/zap"
+)
+
+const usage = `Usage: pvz-service [http|grpc|all]
+
+Commands:
+  http  run HTTP API on HTTP_PORT
+  grpc  run gRPC API on GRPC_PORT
+  all   run both servers in one process (default)
+
+Metrics are served on :9000 in every mode.
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+
+	mode, err := app.ParseMode(flag.Args())
+	if err != nil {
+		fmt.Fprintln(flag.CommandLine.Output(), err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Something went wrong with config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger, err := logger.CreateLogger(cfg.Log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+	// usecases пишут в глобальный логгер, если в контексте нет логгера запроса
+	zap.ReplaceGlobals(logger.Desugar())
+
+	// Ошибка запуска не завершает процесс сразу через Fatalf: сначала run
+	// закрывает базу и кеш, и только потом процесс выходит
+	err = run(cfg, logger, mode)
+	if err != nil {
+		logger.Errorf("Service stopped with error: %v", err)
+	}
+
+	if closeErr := logger.Close(); closeErr != nil {
+		fmt.Fprintf(os.Stderr, "Error while closing logger: %v\n", closeErr)
+	}
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(cfg *config.Config, logger *logger.Logger, mode string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	application, err := app.New(cfg, logger.SugaredLogger, logger.Level)
+	if err != nil {
+		return err
+	}
+	defer application.Close()
+
+	logger.Infof("Starting pvz-service in %s mode", mode)
+
+	return application.Run(ctx, mode)
+}
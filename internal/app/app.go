@@ -0,0 +1,316 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/cache"
+	"github.com/hamillka/avitoTechSpring25/internal/config"
+	"github.com/hamillka/avitoTechSpring25/internal/db"
+	"github.com/hamillka/avitoTechSpring25/internal/gateway"
+	mygrpc "github.com/hamillka/avitoTechSpring25/internal/grpc"
+	"github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers"
+	"github.com/hamillka/avitoTechSpring25/internal/health"
+	"github.com/hamillka/avitoTechSpring25/internal/lifecycle"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+	"github.com/hamillka/avitoTechSpring25/internal/notifier"
+	"github.com/hamillka/avitoTechSpring25/internal/password"
+	"github.com/hamillka/avitoTechSpring25/internal/ratelimit"
+	"github.com/hamillka/avitoTechSpring25/internal/repositories"
+	"github.com/hamillka/avitoTechSpring25/internal/tracing"
+	"github.com/hamillka/avitoTechSpring25/internal/usecases"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const metricsPort = "9000"
+
+// tracingShutdownTimeout ограничивает отправку последних спанов при остановке
+const tracingShutdownTimeout = 5 * time.Second
+
+// Режимы запуска: только HTTP, только gRPC или оба сервера в одном процессе
+const (
+	ModeHTTP = "http"
+	ModeGRPC = "grpc"
+	ModeAll  = "all"
+)
+
+var ErrUnknownMode = errors.New("unknown mode")
+
+// ParseMode определяет режим запуска по аргументам командной строки.
+// Без аргументов поднимаются оба сервера
+func ParseMode(args []string) (string, error) {
+	if len(args) == 0 {
+		return ModeAll, nil
+	}
+
+	if len(args) > 1 {
+		return "", fmt.Errorf("%w: unexpected arguments %v", ErrUnknownMode, args[1:])
+	}
+
+	switch args[0] {
+	case ModeHTTP, ModeGRPC, ModeAll:
+		return args[0], nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownMode, args[0])
+	}
+}
+
+// App собирает все зависимости сервиса один раз, чтобы HTTP и gRPC серверы
+// работали поверх одних и тех же сервисов, кеша и пулов соединений
+type App struct {
+	cfg      *config.Config
+	logger   *zap.SugaredLogger
+	logLevel zap.AtomicLevel
+
+	cluster *db.Cluster
+	cache   cache.Cache
+	limiter *ratelimit.Limiter
+	checker *health.Checker
+
+	shutdownTracing func(context.Context) error
+
+	recRepo  *repositories.ReceptionRepository
+	prodRepo *repositories.ProductRepository
+
+	productService   *usecases.ProductService
+	pvzService       *usecases.PVZService
+	receptionService *usecases.ReceptionService
+	userService      *usecases.UserService
+
+	pvzServer       *mygrpc.PVZServer
+	receptionServer *mygrpc.ReceptionServer
+	productServer   *mygrpc.ProductServer
+	userServer      *mygrpc.UserServer
+}
+
+func New(cfg *config.Config, logger *zap.SugaredLogger, logLevel zap.AtomicLevel) (*App, error) {
+	passwordPolicy, err := password.New(cfg.PasswordPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	userNotifier, err := notifier.New(cfg.Notifier, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := db.CreateCluster(&cfg.DB)
+	if err != nil {
+		_ = shutdownTracing(context.Background())
+		return nil, err
+	}
+
+	pvzCache, err := cache.New(cfg.Cache)
+	if err != nil {
+		_ = cluster.Close()
+		_ = shutdownTracing(context.Background())
+		return nil, err
+	}
+
+	limiter, err := ratelimit.New(cfg.RateLimit)
+	if err != nil {
+		_ = pvzCache.Close()
+		_ = cluster.Close()
+		_ = shutdownTracing(context.Background())
+		return nil, err
+	}
+
+	pr := repositories.NewProductRepository(cluster)
+	pvzr := repositories.NewPVZRepository(cluster)
+	rr := repositories.NewReceptionRepository(cluster)
+	ur := repositories.NewUserRepository(cluster.Primary())
+	lfr := repositories.NewLoginFailureRepository(cluster.Primary())
+	prr := repositories.NewPasswordResetRepository(cluster.Primary())
+
+	checker := health.NewChecker(cfg.Health.Timeout)
+	checker.Add("database", cluster.Ping)
+	checker.Add("schema", cluster.CheckSchema)
+
+	productService := usecases.NewProductService(pr, rr, pvzr, pvzCache)
+	pvzService := usecases.NewPVZService(pvzr, rr, pr, pvzCache)
+	receptionService := usecases.NewReceptionService(pvzr, rr, pr, pvzCache)
+	userService := usecases.NewUserService(ur, lfr, prr, passwordPolicy, userNotifier, cfg.LoginProtection, cfg.PasswordReset, cfg.Registration)
+
+	return &App{
+		cfg:      cfg,
+		logger:   logger,
+		logLevel: logLevel,
+		cluster:  cluster,
+		cache:    pvzCache,
+		limiter:  limiter,
+		checker:  checker,
+		recRepo:  rr,
+		prodRepo: pr,
+
+		shutdownTracing: shutdownTracing,
+
+		productService:   productService,
+		pvzService:       pvzService,
+		receptionService: receptionService,
+		userService:      userService,
+
+		pvzServer:       mygrpc.NewPVZServer(pvzService, logger),
+		receptionServer: mygrpc.NewReceptionServer(receptionService, logger),
+		productServer:   mygrpc.NewProductServer(productService, logger),
+		userServer:      mygrpc.NewUserServer(userService, logger),
+	}, nil
+}
+
+func (a *App) Close() {
+	if err := a.cache.Close(); err != nil {
+		a.logger.Errorf("Error while closing cache: %v", err)
+	}
+
+	if err := a.limiter.Close(); err != nil {
+		a.logger.Errorf("Error while closing rate limiter: %v", err)
+	}
+
+	if err := a.cluster.Close(); err != nil {
+		a.logger.Errorf("Error while closing connection to db: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := a.shutdownTracing(ctx); err != nil {
+		a.logger.Errorf("Error while flushing traces: %v", err)
+	}
+}
+
+// Run запускает серверы выбранного режима вместе с сервером метрик и фоновыми
+// воркерами и блокируется до отмены ctx, после чего останавливает их все
+func (a *App) Run(ctx context.Context, mode string) error {
+	runner := lifecycle.New(a.logger, a.cfg.ShutdownTimeout)
+	runner.OnShutdown(a.checker.Shutdown)
+
+	if mode == ModeHTTP || mode == ModeAll {
+		component, err := a.httpServer()
+		if err != nil {
+			return err
+		}
+		runner.Add(component)
+	}
+
+	if mode == ModeGRPC || mode == ModeAll {
+		components, err := a.grpcServer(runner)
+		if err != nil {
+			return err
+		}
+		runner.Add(components...)
+	}
+
+	runner.Add(a.metricsServer(), a.adminServer())
+	runner.Add(a.workers()...)
+
+	return runner.Run(ctx)
+}
+
+func (a *App) httpServer() (lifecycle.Component, error) {
+	gw, err := gateway.New(a.pvzServer, a.receptionServer, a.productServer, a.userServer)
+	if err != nil {
+		return lifecycle.Component{}, fmt.Errorf("failed to create gateway: %w", err)
+	}
+
+	r := handlers.Router(a.pvzService, a.receptionService, gw, gateway.Routes(), a.checker, a.userService, a.limiter, a.logger)
+
+	return lifecycle.HTTPServer(
+		"http server on port "+a.cfg.HttpPort,
+		&http.Server{Addr: ":" + a.cfg.HttpPort, Handler: r},
+	), nil
+}
+
+func (a *App) grpcServer(runner *lifecycle.Runner) ([]lifecycle.Component, error) {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", a.cfg.GRPCPort))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	healthServer := grpchealth.NewServer()
+	runner.OnShutdown(healthServer.Shutdown)
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			mygrpc.UnaryMetricsInterceptor(),
+			mygrpc.UnaryTracingInterceptor(),
+			mygrpc.UnaryLoggingInterceptor(a.logger),
+			mygrpc.UnaryClientIPInterceptor(a.limiter.ClientIP),
+			mygrpc.UnaryAuthInterceptor(gateway.RequiresAuth, a.userService),
+			mygrpc.UnaryRateLimitInterceptor(a.limiter),
+		),
+		grpc.ChainStreamInterceptor(
+			mygrpc.StreamMetricsInterceptor(),
+			mygrpc.StreamTracingInterceptor(),
+			mygrpc.StreamLoggingInterceptor(a.logger),
+			mygrpc.StreamClientIPInterceptor(a.limiter.ClientIP),
+			mygrpc.StreamAuthInterceptor(gateway.RequiresAuth, a.userService),
+			mygrpc.StreamRateLimitInterceptor(a.limiter),
+		),
+	)
+	pvz_v1.RegisterPVZServiceServer(srv, a.pvzServer)
+	pvz_v1.RegisterReceptionServiceServer(srv, a.receptionServer)
+	pvz_v1.RegisterProductServiceServer(srv, a.productServer)
+	pvz_v1.RegisterUserServiceServer(srv, a.userServer)
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	return []lifecycle.Component{
+		lifecycle.GRPCServer("grpc server on port "+a.cfg.GRPCPort, srv, lis),
+		lifecycle.Worker("grpc health watcher", func(ctx context.Context) {
+			mygrpc.WatchHealth(ctx, a.checker, healthServer, a.cfg.Health.Interval)
+		}),
+	}, nil
+}
+
+func (a *App) metricsServer() lifecycle.Component {
+	metrics.Register()
+	for name, conn := range a.cluster.Databases() {
+		metrics.RegisterDBStats(name, conn.DB)
+	}
+	metrics.RegisterOpenReceptions(a.receptionService.OpenReceptionStats, a.cfg.Health.Timeout)
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
+
+	return lifecycle.HTTPServer("metrics server on port "+metricsPort, &http.Server{Addr: ":" + metricsPort, Handler: metricsMux})
+}
+
+// adminServer отдает служебные ручки без авторизации на отдельном адресе, а
+// не рядом с метриками: доступ к метрикам не должен давать права менять
+// уровень логов
+func (a *App) adminServer() lifecycle.Component {
+	adminMux := http.NewServeMux()
+	// GET отдает текущий уровень логов, PUT {"level":"info"} меняет его на лету
+	adminMux.Handle("/log/level", a.logLevel)
+
+	return lifecycle.HTTPServer("admin server on "+a.cfg.AdminAddr, &http.Server{Addr: a.cfg.AdminAddr, Handler: adminMux})
+}
+
+func (a *App) workers() []lifecycle.Component {
+	workers := []lifecycle.Component{
+		lifecycle.Worker("replica health checks", func(ctx context.Context) {
+			a.cluster.RunHealthChecks(ctx, a.cfg.DB.ReplicaCheckInterval, a.logger)
+		}),
+		lifecycle.Worker("login failures cleanup", a.userService.RunLoginFailuresCleanup),
+	}
+
+	if a.cfg.AutoClose.Enabled {
+		elector := db.NewLeaderElector(a.cluster.Primary(), a.cfg.AutoClose.LockKey)
+		closer := usecases.NewReceptionCloser(a.recRepo, a.prodRepo, elector, a.cache, a.cfg.AutoClose, a.logger)
+		workers = append(workers, lifecycle.Worker("reception auto closer", closer.Run))
+	}
+
+	return workers
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", args: nil, want: ModeAll},
+		{name: "http", args: []string{"http"}, want: ModeHTTP},
+		{name: "grpc", args: []string{"grpc"}, want: ModeGRPC},
+		{name: "all", args: []string{"all"}, want: ModeAll},
+		{name: "unknown", args: []string{"rest"}, wantErr: true},
+		{name: "extra args", args: []string{"http", "grpc"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := ParseMode(tt.args)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnknownMode)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mode)
+		})
+	}
+}
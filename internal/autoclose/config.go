@@ -0,0 +1,36 @@
+package autoclose
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config описывает автоматическое закрытие зависших приемок. MaxIdle = 0
+// отключает закрытие по простою, тогда остается только ограничение MaxAge
+type Config struct {
+	Enabled  bool          `default:"false" envconfig:"ENABLED"`
+	Interval time.Duration `default:"1m"    envconfig:"INTERVAL"`
+	MaxAge   time.Duration `default:"24h"   envconfig:"MAX_AGE"`
+	MaxIdle  time.Duration `default:"0"     envconfig:"MAX_IDLE"`
+	LockKey  int64         `default:"2025"  envconfig:"LOCK_KEY"`
+}
+
+// Validate проверяет настройки включенного автозакрытия при старте:
+// нулевой интервал уронил бы фоновый воркер уже после запуска
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", c.Interval)
+	}
+	if c.MaxAge <= 0 {
+		return fmt.Errorf("max age must be positive, got %s", c.MaxAge)
+	}
+	if c.MaxIdle < 0 {
+		return fmt.Errorf("max idle must not be negative, got %s", c.MaxIdle)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package autoclose
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{Enabled: true, Interval: time.Minute, MaxAge: 24 * time.Hour}
+
+	cases := map[string]struct {
+		cfg   func(c Config) Config
+		valid bool
+	}{
+		"valid":             {cfg: func(c Config) Config { return c }, valid: true},
+		"disabled":          {cfg: func(Config) Config { return Config{} }, valid: true},
+		"zero interval":     {cfg: func(c Config) Config { c.Interval = 0; return c }},
+		"negative interval": {cfg: func(c Config) Config { c.Interval = -time.Second; return c }},
+		"zero max age":      {cfg: func(c Config) Config { c.MaxAge = 0; return c }},
+		"negative max idle": {cfg: func(c Config) Config { c.MaxIdle = -time.Hour; return c }},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg(valid).Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+)
+
+const (
+	BackendNone   = "none"
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Config описывает кеш чтения списков ПВЗ. In-memory кеш живет внутри процесса,
+// поэтому при нескольких инстансах (или отдельных HTTP и gRPC серверах) запись
+// в одном из них не сбросит кеш в другом: для такой схемы нужен redis.
+// ReplicaLag — сколько после сброса не кешировать прочитанное: реплика за это
+// время может еще не получить запись, из-за которой кеш сброшен
+type Config struct {
+	Backend       string        `default:"memory"         envconfig:"BACKEND"`
+	TTL           time.Duration `default:"30s"            envconfig:"TTL"`
+	ReplicaLag    time.Duration `default:"1s"             envconfig:"REPLICA_LAG"`
+	Size          int           `default:"1024"           envconfig:"SIZE"`
+	RedisAddr     string        `default:"localhost:6379" envconfig:"REDIS_ADDR"`
+	RedisPassword string        `default:""               envconfig:"REDIS_PASSWORD"`
+	RedisDB       int           `default:"0"              envconfig:"REDIS_DB"`
+	Prefix        string        `default:"pvz:"           envconfig:"PREFIX"`
+}
+
+// Generation — поколение кеша. Сброс не удаляет записи, а начинает новое
+// поколение: ключи старого больше не читаются и истекают по TTL. Чтение,
+// начатое до сброса, пишет результат в старое поколение, где его уже никто
+// не увидит
+type Generation struct {
+	Value int64
+	// Cacheable ложно сразу после сброса, пока реплики могут отставать
+	Cacheable bool
+}
+
+// Key добавляет поколение к ключу записи
+func (g Generation) Key(key string) string {
+	return strconv.FormatInt(g.Value, 10) + ":" + key
+}
+
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Generation(ctx context.Context) (Generation, error)
+	Invalidate(ctx context.Context) error
+	Close() error
+}
+
+func New(cfg Config) (Cache, error) {
+	switch cfg.Backend {
+	case BackendNone:
+		return NewNoop(), nil
+	case BackendMemory:
+		return NewLRU(cfg.Size, cfg.TTL, cfg.ReplicaLag), nil
+	case BackendRedis:
+		return NewRedis(cfg)
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", cfg.Backend)
+	}
+}
+
+func observeGet(backend string, hit bool) {
+	if hit {
+		metrics.CacheHits.WithLabelValues(backend).Inc()
+		return
+	}
+	metrics.CacheMisses.WithLabelValues(backend).Inc()
+}
+
+func observeError(backend, op string) {
+	metrics.CacheErrors.WithLabelValues(backend, op).Inc()
+}
+
+// Noop ничего не хранит: каждое чтение идет в базу
+type Noop struct{}
+
+func NewNoop() *Noop {
+	return &Noop{}
+}
+
+func (*Noop) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (*Noop) Set(context.Context, string, []byte) error {
+	return nil
+}
+
+func (*Noop) Generation(context.Context) (Generation, error) {
+	return Generation{}, nil
+}
+
+func (*Noop) Invalidate(context.Context) error {
+	return nil
+}
+
+func (*Noop) Close() error {
+	return nil
+}
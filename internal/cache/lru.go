@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// LRU хранит не больше size записей и вытесняет давно не читавшиеся.
+// Записи старше ttl считаются промахом и удаляются при чтении
+type LRU struct {
+	mu           sync.Mutex
+	size         int
+	ttl          time.Duration
+	replicaLag   time.Duration
+	order        *list.List
+	entries      map[string]*list.Element
+	generation   int64
+	invalidateAt time.Time
+	now          func() time.Time
+}
+
+func NewLRU(size int, ttl, replicaLag time.Duration) *LRU {
+	return &LRU{
+		size:       size,
+		ttl:        ttl,
+		replicaLag: replicaLag,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element, size),
+		now:        time.Now,
+	}
+}
+
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if ok && c.ttl > 0 && c.now().After(elem.Value.(*lruEntry).expiresAt) {
+		c.remove(elem)
+		ok = false
+	}
+
+	observeGet(BackendMemory, ok)
+	if !ok {
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true, nil
+}
+
+func (c *LRU) Set(_ context.Context, key string, value []byte) error {
+	if c.size <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+
+	return nil
+}
+
+func (c *LRU) Generation(context.Context) (Generation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Generation{
+		Value:     c.generation,
+		Cacheable: !c.now().Before(c.invalidateAt.Add(c.replicaLag)),
+	}, nil
+}
+
+// Invalidate начинает новое поколение. Записи старого уже не прочитать,
+// поэтому они сразу удаляются, чтобы не занимать место
+func (c *LRU) Invalidate(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.invalidateAt = c.now()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element, c.size)
+
+	return nil
+}
+
+func (c *LRU) Close() error {
+	return nil
+}
+
+func (c *LRU) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry).key)
+}
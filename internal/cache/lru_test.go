@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	c := NewLRU(2, time.Minute, 0)
+	ctx := context.Background()
+
+	_, ok, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, c.Set(ctx, "a", []byte("1")))
+
+	value, ok, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU(2, time.Minute, 0)
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", []byte("1"))
+	_ = c.Set(ctx, "b", []byte("2"))
+	_, _, _ = c.Get(ctx, "a")
+	_ = c.Set(ctx, "c", []byte("3"))
+
+	_, ok, _ := c.Get(ctx, "b")
+	assert.False(t, ok)
+
+	_, ok, _ = c.Get(ctx, "a")
+	assert.True(t, ok)
+
+	_, ok, _ = c.Get(ctx, "c")
+	assert.True(t, ok)
+}
+
+func TestLRU_Expires(t *testing.T) {
+	c := NewLRU(2, time.Minute, 0)
+	ctx := context.Background()
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	_ = c.Set(ctx, "a", []byte("1"))
+
+	c.now = func() time.Time { return now.Add(2 * time.Minute) }
+	_, ok, _ := c.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.order.Len())
+}
+
+func TestLRU_Invalidate(t *testing.T) {
+	c := NewLRU(2, time.Minute, time.Second)
+	ctx := context.Background()
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	_ = c.Set(ctx, "a", []byte("1"))
+	assert.NoError(t, c.Invalidate(ctx))
+
+	_, ok, _ := c.Get(ctx, "a")
+	assert.False(t, ok)
+
+	gen, _ := c.Generation(ctx)
+	assert.Equal(t, int64(1), gen.Value)
+	assert.False(t, gen.Cacheable)
+
+	c.now = func() time.Time { return now.Add(time.Second) }
+	gen, _ = c.Generation(ctx)
+	assert.True(t, gen.Cacheable)
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	generationKey = "generation"
+	settlingKey   = "settling"
+)
+
+// Redis хранит записи с общим префиксом, что позволяет нескольким инстансам
+// сервиса делить кеш и сбрасывать его друг у друга
+type Redis struct {
+	client *redis.Client
+	cfg    Config
+}
+
+func NewRedis(cfg Config) (*Redis, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisAddr,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+
+	err := client.Ping(context.Background()).Err()
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &Redis{client: client, cfg: cfg}, nil
+}
+
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.cfg.Prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		observeGet(BackendRedis, false)
+		return nil, false, nil
+	}
+	if err != nil {
+		observeError(BackendRedis, "get")
+		return nil, false, err
+	}
+
+	observeGet(BackendRedis, true)
+	return value, true, nil
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value []byte) error {
+	err := c.client.Set(ctx, c.cfg.Prefix+key, value, c.cfg.TTL).Err()
+	if err != nil {
+		observeError(BackendRedis, "set")
+	}
+
+	return err
+}
+
+// Generation читает поколение и признак недавнего сброса одним запросом
+func (c *Redis) Generation(ctx context.Context) (Generation, error) {
+	values, err := c.client.MGet(ctx, c.cfg.Prefix+generationKey, c.cfg.Prefix+settlingKey).Result()
+	if err != nil {
+		observeError(BackendRedis, "generation")
+		return Generation{}, err
+	}
+
+	var gen Generation
+	if raw, ok := values[0].(string); ok {
+		gen.Value, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			observeError(BackendRedis, "generation")
+			return Generation{}, err
+		}
+	}
+	gen.Cacheable = values[1] == nil
+
+	return gen, nil
+}
+
+// Invalidate начинает новое поколение одним INCR вместо обхода ключей, а
+// записи старого поколения истекают по TTL. Ключ settling живет ReplicaLag и
+// запрещает все это время кешировать прочитанное с реплик
+func (c *Redis) Invalidate(ctx context.Context) error {
+	pipe := c.client.TxPipeline()
+	pipe.Incr(ctx, c.cfg.Prefix+generationKey)
+	if c.cfg.ReplicaLag > 0 {
+		pipe.Set(ctx, c.cfg.Prefix+settlingKey, 1, c.cfg.ReplicaLag)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		observeError(BackendRedis, "invalidate")
+		return err
+	}
+
+	return nil
+}
+
+func (c *Redis) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedis(t *testing.T) (*Redis, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+
+	c, err := NewRedis(Config{RedisAddr: server.Addr(), TTL: time.Minute, ReplicaLag: time.Second, Prefix: "pvz:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c, server
+}
+
+func TestRedis_GetSet(t *testing.T) {
+	c, server := newTestRedis(t)
+	ctx := context.Background()
+
+	_, ok, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, c.Set(ctx, "a", []byte("1")))
+	assert.Equal(t, time.Minute, server.TTL("pvz:a"))
+
+	value, ok, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestRedis_InvalidateStartsNewGeneration(t *testing.T) {
+	c, server := newTestRedis(t)
+	ctx := context.Background()
+
+	gen, err := c.Generation(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Generation{Value: 0, Cacheable: true}, gen)
+
+	_ = c.Set(ctx, gen.Key("a"), []byte("1"))
+	require.NoError(t, server.Set("other", "x"))
+
+	assert.NoError(t, c.Invalidate(ctx))
+
+	gen, err = c.Generation(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), gen.Value)
+	assert.False(t, gen.Cacheable)
+
+	_, ok, _ := c.Get(ctx, gen.Key("a"))
+	assert.False(t, ok)
+	assert.True(t, server.Exists("other"))
+
+	server.FastForward(time.Second)
+	gen, err = c.Generation(ctx)
+	require.NoError(t, err)
+	assert.True(t, gen.Cacheable)
+}
+
+func TestRedis_Unavailable(t *testing.T) {
+	c, server := newTestRedis(t)
+	server.Close()
+
+	_, ok, err := c.Get(context.Background(), "a")
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
+
+func TestNew_UnknownBackend(t *testing.T) {
+	_, err := New(Config{Backend: "memcached"})
+	assert.Error(t, err)
+}
@@ -1,17 +1,40 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/autoclose"
+	"github.com/hamillka/avitoTechSpring25/internal/cache"
 	"github.com/hamillka/avitoTechSpring25/internal/db"
+	"github.com/hamillka/avitoTechSpring25/internal/health"
 	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/notifier"
+	"github.com/hamillka/avitoTechSpring25/internal/password"
+	"github.com/hamillka/avitoTechSpring25/internal/ratelimit"
+	"github.com/hamillka/avitoTechSpring25/internal/tracing"
+	"github.com/hamillka/avitoTechSpring25/internal/usecases"
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Config struct {
-	DB       db.DatabaseConfig `envconfig:"DB"`
-	HttpPort string            `envconfig:"HTTP_PORT"`
-	GRPCPort string            `envconfig:"GRPC_PORT"`
-	Timeout  int64             `envconfig:"TIMEOUT"`
-	Log      logger.LogConfig  `envconfig:"LOG"`
+	DB              db.DatabaseConfig              `envconfig:"DB"`
+	HttpPort        string                         `envconfig:"HTTP_PORT"`
+	GRPCPort        string                         `envconfig:"GRPC_PORT"`
+	AdminAddr       string                         `default:"127.0.0.1:9001" envconfig:"ADMIN_ADDR"`
+	Timeout         int64                          `envconfig:"TIMEOUT"`
+	ShutdownTimeout time.Duration                  `default:"15s" envconfig:"SHUTDOWN_TIMEOUT"`
+	Log             logger.LogConfig               `envconfig:"LOG"`
+	AutoClose       autoclose.Config               `envconfig:"AUTO_CLOSE"`
+	LoginProtection usecases.LoginProtectionConfig `envconfig:"LOGIN_PROTECTION"`
+	PasswordPolicy  password.Config                `envconfig:"PASSWORD_POLICY"`
+	PasswordReset   usecases.PasswordResetConfig   `envconfig:"PASSWORD_RESET"`
+	Registration    usecases.RegistrationConfig    `envconfig:"REGISTRATION"`
+	Notifier        notifier.Config                `envconfig:"NOTIFIER"`
+	Cache           cache.Config                   `envconfig:"CACHE"`
+	Health          health.Config                  `envconfig:"HEALTH"`
+	Tracing         tracing.Config                 `envconfig:"TRACING"`
+	RateLimit       ratelimit.Config               `envconfig:"RATE_LIMIT"`
 }
 
 func New() (*Config, error) {
@@ -22,5 +45,9 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
+	if err = config.AutoClose.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid AUTO_CLOSE config: %w", err)
+	}
+
 	return &config, nil
 }
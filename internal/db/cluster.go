@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const replicaPingTimeout = 2 * time.Second
+
+type replica struct {
+	db      *sqlx.DB
+	healthy atomic.Bool
+}
+
+// Cluster раздает соединения с primary и репликами. Записи и чтения, которым
+// нужны только что записанные данные, идут в primary, тяжелые чтения списков
+// распределяются по живым репликам по кругу. Если живых реплик нет, чтения
+// тоже уходят в primary
+type Cluster struct {
+	primary  *sqlx.DB
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+func NewCluster(primary *sqlx.DB, replicas ...*sqlx.DB) *Cluster {
+	c := &Cluster{primary: primary}
+
+	for _, db := range replicas {
+		r := &replica{db: db}
+		r.healthy.Store(true)
+		c.replicas = append(c.replicas, r)
+	}
+
+	return c
+}
+
+func (c *Cluster) Primary() *sqlx.DB {
+	return c.primary
+}
+
+// Databases возвращает все пулы кластера по именам для экспорта их статистики
+func (c *Cluster) Databases() map[string]*sqlx.DB {
+	dbs := map[string]*sqlx.DB{"primary": c.primary}
+	for i, r := range c.replicas {
+		dbs[fmt.Sprintf("replica_%d", i)] = r.db
+	}
+
+	return dbs
+}
+
+func (c *Cluster) Replica() *sqlx.DB {
+	n := len(c.replicas)
+	if n == 0 {
+		return c.primary
+	}
+
+	start := c.next.Add(1)
+	for i := range n {
+		r := c.replicas[(start+uint64(i))%uint64(n)]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+
+	return c.primary
+}
+
+// Ping проверяет доступность primary: без него сервис не может писать
+func (c *Cluster) Ping(ctx context.Context) error {
+	return c.primary.PingContext(ctx)
+}
+
+func (c *Cluster) CheckSchema(ctx context.Context) error {
+	return CheckSchemaVersion(ctx, c.primary)
+}
+
+// CheckReplicas пингует реплики и исключает из ротации те, что не ответили
+func (c *Cluster) CheckReplicas(ctx context.Context, logger *zap.SugaredLogger) {
+	for i, r := range c.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := r.db.PingContext(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if r.healthy.Swap(healthy) != healthy {
+			if healthy {
+				logger.Infof("replica %d is back in rotation", i)
+			} else {
+				logger.Errorf("replica %d is removed from rotation: %v", i, err)
+			}
+		}
+	}
+}
+
+func (c *Cluster) RunHealthChecks(ctx context.Context, interval time.Duration, logger *zap.SugaredLogger) {
+	if len(c.replicas) == 0 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CheckReplicas(ctx, logger)
+		}
+	}
+}
+
+func (c *Cluster) Close() error {
+	err := c.primary.Close()
+
+	for _, r := range c.replicas {
+		if closeErr := r.db.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+)
+
+func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	return sqlx.NewDb(conn, "postgres"), mock
+}
+
+func TestCluster_NoReplicasUsesPrimary(t *testing.T) {
+	primary, _ := newMockDB(t)
+	c := NewCluster(primary)
+
+	assert.Same(t, primary, c.Replica())
+}
+
+func TestCluster_RoundRobin(t *testing.T) {
+	primary, _ := newMockDB(t)
+	r1, _ := newMockDB(t)
+	r2, _ := newMockDB(t)
+	c := NewCluster(primary, r1, r2)
+
+	first := c.Replica()
+	second := c.Replica()
+
+	assert.NotSame(t, first, second)
+	assert.NotSame(t, primary, first)
+	assert.NotSame(t, primary, second)
+}
+
+func TestCluster_FallbackToPrimary(t *testing.T) {
+	primary, _ := newMockDB(t)
+	r1, mock1 := newMockDB(t)
+	r2, mock2 := newMockDB(t)
+	c := NewCluster(primary, r1, r2)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	mock1.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock2.ExpectPing()
+	c.CheckReplicas(context.Background(), logger)
+
+	assert.Same(t, r2, c.Replica())
+	assert.Same(t, r2, c.Replica())
+
+	mock1.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock2.ExpectPing().WillReturnError(errors.New("connection refused"))
+	c.CheckReplicas(context.Background(), logger)
+
+	assert.Same(t, primary, c.Replica())
+
+	mock1.ExpectPing()
+	mock2.ExpectPing().WillReturnError(errors.New("connection refused"))
+	c.CheckReplicas(context.Background(), logger)
+
+	assert.Same(t, r1, c.Replica())
+}
@@ -1,7 +1,9 @@
 package db
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -13,20 +15,113 @@ type DatabaseConfig struct {
 	DBName string `envconfig:"NAME"`
 	DBUser string `envconfig:"USER"`
 	DBPass string `envconfig:"PASS"`
+
+	// SSLMode передается в драйвер как есть: disable, require, verify-ca или verify-full
+	SSLMode string `default:"disable" envconfig:"SSL_MODE"`
+
+	MaxOpenConns    int           `default:"25"  envconfig:"MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `default:"10"  envconfig:"MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `default:"30m" envconfig:"CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `default:"5m"  envconfig:"CONN_MAX_IDLE_TIME"`
+
+	// При старте база может подниматься дольше сервиса, поэтому подключение
+	// повторяется ConnectRetries раз с экспоненциально растущей паузой
+	ConnectRetries    int           `default:"5"   envconfig:"CONNECT_RETRIES"`
+	ConnectBackoff    time.Duration `default:"1s"  envconfig:"CONNECT_BACKOFF"`
+	ConnectMaxBackoff time.Duration `default:"30s" envconfig:"CONNECT_MAX_BACKOFF"`
+
+	// Replicas — DSN реплик только для чтения через запятую, например
+	// "host=replica1 user=postgres password=postgres dbname=pvz_service sslmode=disable"
+	Replicas             []string      `envconfig:"REPLICAS"`
+	ReplicaCheckInterval time.Duration `default:"5s" envconfig:"REPLICA_CHECK_INTERVAL"`
 }
 
+// sleep подменяется в тестах, чтобы не ждать реальные паузы между попытками
+var sleep = time.Sleep
+
 func CreateConnection(config *DatabaseConfig) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		config.DBHost, config.DBUser, config.DBPass, config.DBName, config.DBPort)
+	sslMode := config.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		config.DBHost, config.DBUser, config.DBPass, config.DBName, config.DBPort, sslMode)
 	db, err := sqlx.Open("postgres", dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.Ping()
+	configurePool(db, config)
+
+	err = pingWithRetry(db, config)
 	if err != nil {
+		_ = db.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
+
+func configurePool(db *sqlx.DB, config *DatabaseConfig) {
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+}
+
+func pingWithRetry(db *sqlx.DB, config *DatabaseConfig) error {
+	backoff := config.ConnectBackoff
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = db.Ping()
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= config.ConnectRetries {
+			return fmt.Errorf("database is unavailable after %d attempts: %w", attempt+1, err)
+		}
+
+		sleep(backoff)
+
+		backoff *= 2
+		if config.ConnectMaxBackoff > 0 && backoff > config.ConnectMaxBackoff {
+			backoff = config.ConnectMaxBackoff
+		}
+	}
+}
+
+// CreateCluster подключается к primary и открывает соединения с репликами.
+// Недоступная при старте реплика не мешает запуску: она остается вне ротации,
+// пока ее не вернет проверка здоровья
+func CreateCluster(config *DatabaseConfig) (*Cluster, error) {
+	primary, err := CreateConnection(config)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := make([]*sqlx.DB, 0, len(config.Replicas))
+	for _, dsn := range config.Replicas {
+		replica, err := sqlx.Open("postgres", dsn)
+		if err != nil {
+			for _, opened := range replicas {
+				_ = opened.Close()
+			}
+			_ = primary.Close()
+			return nil, err
+		}
+		configurePool(replica, config)
+		replicas = append(replicas, replica)
+	}
+
+	cluster := NewCluster(primary, replicas...)
+	for _, r := range cluster.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), replicaPingTimeout)
+		r.healthy.Store(r.db.PingContext(ctx) == nil)
+		cancel()
+	}
+
+	return cluster, nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stubSleep(t *testing.T) *[]time.Duration {
+	var pauses []time.Duration
+	sleep = func(d time.Duration) { pauses = append(pauses, d) }
+	t.Cleanup(func() { sleep = time.Sleep })
+	return &pauses
+}
+
+func TestPingWithRetry_SucceedsAfterFailures(t *testing.T) {
+	pauses := stubSleep(t)
+	conn, mock := newMockDB(t)
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock.ExpectPing()
+
+	err := pingWithRetry(conn, &DatabaseConfig{
+		ConnectRetries:    5,
+		ConnectBackoff:    time.Second,
+		ConnectMaxBackoff: 3 * time.Second,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, *pauses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPingWithRetry_GivesUp(t *testing.T) {
+	pauses := stubSleep(t)
+	conn, mock := newMockDB(t)
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	err := pingWithRetry(conn, &DatabaseConfig{ConnectRetries: 1, ConnectBackoff: time.Second})
+
+	assert.ErrorContains(t, err, "after 2 attempts")
+	assert.Len(t, *pauses, 1)
+}
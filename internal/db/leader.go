@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	tryAdvisoryLock = "SELECT pg_try_advisory_lock($1)"
+	advisoryUnlock  = "SELECT pg_advisory_unlock($1)"
+)
+
+// LeaderElector выбирает одну реплику сервиса лидером с помощью сессионной
+// advisory-блокировки PostgreSQL. Блокировка держится на выделенном соединении
+// и освобождается автоматически, если реплика падает и соединение рвется
+type LeaderElector struct {
+	db   *sqlx.DB
+	key  int64
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+func NewLeaderElector(db *sqlx.DB, key int64) *LeaderElector {
+	return &LeaderElector{
+		db:  db,
+		key: key,
+	}
+}
+
+func (le *LeaderElector) IsLeader(ctx context.Context) (bool, error) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if le.conn != nil {
+		if err := le.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		_ = le.conn.Close()
+		le.conn = nil
+	}
+
+	conn, err := le.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired bool
+	err = conn.QueryRowContext(ctx, tryAdvisoryLock, le.key).Scan(&acquired)
+	if err != nil || !acquired {
+		_ = conn.Close()
+		return false, err
+	}
+
+	le.conn = conn
+	return true, nil
+}
+
+func (le *LeaderElector) Resign(ctx context.Context) error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if le.conn == nil {
+		return nil
+	}
+
+	_, err := le.conn.ExecContext(ctx, advisoryUnlock, le.key)
+	closeErr := le.conn.Close()
+	le.conn = nil
+
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SchemaVersion — версия схемы из sql-scripts, под которую собран сервис.
+// Ее нужно увеличивать вместе с изменением схемы в обоих init-скриптах
+const SchemaVersion = 5
+
+const getSchemaVersion = "SELECT MAX(version) FROM schema_version"
+
+// CheckSchemaVersion проверяет, что схема базы не старше той, что ждет сервис.
+// Более новая схема допустима: так при выкатке старые инстансы остаются готовыми
+func CheckSchemaVersion(ctx context.Context, db *sqlx.DB) error {
+	var version sql.NullInt64
+
+	err := db.QueryRowContext(ctx, getSchemaVersion).Scan(&version)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if !version.Valid || version.Int64 < SchemaVersion {
+		return fmt.Errorf("schema version %d is older than required %d", version.Int64, SchemaVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSchemaVersion_UpToDate(t *testing.T) {
+	conn, mock := newMockDB(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getSchemaVersion)).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(SchemaVersion))
+
+	assert.NoError(t, CheckSchemaVersion(context.Background(), conn))
+}
+
+func TestCheckSchemaVersion_Outdated(t *testing.T) {
+	conn, mock := newMockDB(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getSchemaVersion)).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+
+	assert.ErrorContains(t, CheckSchemaVersion(context.Background(), conn), "older than required")
+}
@@ -0,0 +1,29 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{
+		w: csv.NewWriter(w),
+	}
+}
+
+func (cw *CSVWriter) Write(record []string) error {
+	return cw.w.Write(record)
+}
+
+func (cw *CSVWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *CSVWriter) Close() error {
+	return cw.Flush()
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"errors"
+	"io"
+
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+type Writer interface {
+	Write(record []string) error
+	Flush() error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Header — заголовок выгрузки приемок, общий для REST и gRPC
+var Header = []string{
+	"pvzId",
+	"city",
+	"receptionId",
+	"receptionDateTime",
+	"receptionStatus",
+	"productId",
+	"productDateTime",
+	"productType",
+}
+
+// Record раскладывает строку выгрузки по колонкам в порядке Header
+func Record(row models.ReceptionExportRow) []string {
+	return []string{
+		row.PVZId,
+		row.City,
+		row.ReceptionId,
+		row.ReceptionDateTime,
+		row.ReceptionStatus,
+		row.ProductId,
+		row.ProductDateTime,
+		row.ProductType,
+	}
+}
@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// New собирает REST шлюз поверх gRPC серверов. Серверы вызываются в том же
+// процессе, поэтому значения контекста запроса (claims из AuthMiddleware)
+// доходят до них без изменений. Ответы и ошибки сериализуются так же,
+// как в прежнем REST API: поля в camelCase и ошибки в виде dto.ErrorDto
+func New(
+	pvz pvz_v1.PVZServiceServer,
+	reception pvz_v1.ReceptionServiceServer,
+	product pvz_v1.ProductServiceServer,
+	user pvz_v1.UserServiceServer,
+) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &marshaler{
+			JSONPb: &runtime.JSONPb{
+				MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+				UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+			},
+		}),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithForwardResponseOption(forwardSuccessCode),
+		runtime.SetQueryParameterParser(&queryParser{}),
+	)
+
+	ctx := context.Background()
+	if err := pvz_v1.RegisterUserServiceHandlerServer(ctx, mux, user); err != nil {
+		return nil, err
+	}
+	if err := pvz_v1.RegisterPVZServiceHandlerServer(ctx, mux, pvz); err != nil {
+		return nil, err
+	}
+	if err := pvz_v1.RegisterReceptionServiceHandlerServer(ctx, mux, reception); err != nil {
+		return nil, err
+	}
+	if err := pvz_v1.RegisterProductServiceHandlerServer(ctx, mux, product); err != nil {
+		return nil, err
+	}
+
+	return mux, nil
+}
+
+const retryAfterMetadataKey = "retry-after"
+
+var errInvalidBody = errors.New("Некорректные данные")
+
+// marshaler отдает пустое тело для google.protobuf.Empty и заменяет ошибки
+// разбора тела запроса на сообщение для клиента
+type marshaler struct {
+	*runtime.JSONPb
+}
+
+func (m *marshaler) Marshal(v any) ([]byte, error) {
+	if _, ok := v.(*emptypb.Empty); ok {
+		return nil, nil
+	}
+	return m.JSONPb.Marshal(v)
+}
+
+func (m *marshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	decoder := m.JSONPb.NewDecoder(r)
+	return runtime.DecoderFunc(func(v any) error {
+		err := decoder.Decode(v)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return errInvalidBody
+		}
+		return err
+	})
+}
+
+func errorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	var httpErr *runtime.HTTPStatusError
+	if errors.As(err, &httpErr) {
+		err = httpErr.Err
+	}
+
+	// заблокированный вход отдает время ожидания в метаданных retry-after
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		if values := md.HeaderMD.Get(retryAfterMetadataKey); len(values) > 0 {
+			w.Header().Set("Retry-After", values[0])
+		}
+	}
+
+	st := status.Convert(err)
+	message := st.Message()
+	if st.Code() == codes.Unknown {
+		message = "Внутренняя ошибка сервера"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	errorDto := &dto.ErrorDto{
+		Message: message,
+	}
+	err = json.NewEncoder(w).Encode(errorDto)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// forwardSuccessCode выставляет код успешного ответа, объявленный в proto
+// (например, 201 для создания ресурсов)
+func forwardSuccessCode(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	method, ok := runtime.RPCMethod(ctx)
+	if !ok {
+		return nil
+	}
+
+	if info, ok := methods[method]; ok && info.successCode != http.StatusOK {
+		w.WriteHeader(info.successCode)
+	}
+
+	return nil
+}
+
+// queryParser разбирает параметры запроса по одному, чтобы в ошибке назвать
+// невалидный параметр так же, как это делал REST API. Сгенерированный код сам
+// оборачивает ошибку в codes.InvalidArgument
+type queryParser struct {
+	runtime.DefaultQueryParser
+}
+
+func (p *queryParser) Parse(target proto.Message, values url.Values, filter *utilities.DoubleArray) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		err := p.DefaultQueryParser.Parse(target, url.Values{key: values[key]}, filter)
+		if err != nil {
+			return errors.New("Невалидный параметр " + key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	mygrpc "github.com/hamillka/avitoTechSpring25/internal/grpc"
+	"github.com/hamillka/avitoTechSpring25/internal/grpc/mocks"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type testServices struct {
+	pvz       *mocks.MockPVZService
+	reception *mocks.MockReceptionService
+	product   *mocks.MockProductService
+	user      *mocks.MockUserService
+}
+
+func newTestGateway(t *testing.T) (http.Handler, testServices) {
+	ctrl := gomock.NewController(t)
+	logger := zaptest.NewLogger(t).Sugar()
+	services := testServices{
+		pvz:       mocks.NewMockPVZService(ctrl),
+		reception: mocks.NewMockReceptionService(ctrl),
+		product:   mocks.NewMockProductService(ctrl),
+		user:      mocks.NewMockUserService(ctrl),
+	}
+
+	gw, err := New(
+		mygrpc.NewPVZServer(services.pvz, logger),
+		mygrpc.NewReceptionServer(services.reception, logger),
+		mygrpc.NewProductServer(services.product, logger),
+		mygrpc.NewUserServer(services.user, logger),
+	)
+	require.NoError(t, err)
+
+	return gw, services
+}
+
+func serve(gw http.Handler, role, method, target, body string) *httptest.ResponseRecorder {
+	var claims jwt.MapClaims
+	if role != "" {
+		claims = jwt.MapClaims{"role": role}
+	}
+
+	return serveWithClaims(gw, claims, method, target, body)
+}
+
+func serveWithClaims(gw http.Handler, claims jwt.MapClaims, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if claims != nil {
+		ctx := context.WithValue(req.Context(), middlewares.Key("props"), claims)
+		req = req.WithContext(ctx)
+	}
+
+	w := httptest.NewRecorder()
+	gw.ServeHTTP(w, req)
+	return w
+}
+
+func TestGateway_CreatePVZ(t *testing.T) {
+	gw, services := newTestGateway(t)
+
+	services.pvz.EXPECT().CreatePVZ(gomock.Any(), dto.Kazan).
+		Return(models.PVZ{Id: "pvz1", City: dto.Kazan, RegistrationDate: "2025-04-11T10:00:00Z"}, nil)
+
+	w := serve(gw, dto.RoleModerator, http.MethodPost, "/pvz", `{"city":"Казань"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp dto.CreatePVZResponseDto
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "pvz1", resp.Id)
+	assert.Equal(t, dto.Kazan, resp.City)
+	assert.Equal(t, "2025-04-11T10:00:00Z", resp.RegistrationDate)
+}
+
+func TestGateway_ErrorFormat(t *testing.T) {
+	gw, _ := newTestGateway(t)
+
+	cases := map[string]struct {
+		role    string
+		body    string
+		code    int
+		message string
+	}{
+		"forbidden":    {dto.RoleEmployee, `{"city":"Казань"}`, http.StatusForbidden, "Доступ запрещен"},
+		"invalid body": {dto.RoleModerator, `{bad json`, http.StatusBadRequest, "Некорректные данные"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := serve(gw, tc.role, http.MethodPost, "/pvz", tc.body)
+			assert.Equal(t, tc.code, w.Code)
+
+			var resp dto.ErrorDto
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tc.message, resp.Message)
+		})
+	}
+}
+
+func TestGateway_LoginBlocked(t *testing.T) {
+	gw, services := newTestGateway(t)
+
+	services.user.EXPECT().UserLogin(gomock.Any(), "test@mail.com", "pass", gomock.Any()).
+		Return(models.User{}, &dto.LoginBlockedError{Err: dto.ErrLoginThrottled, RetryAfter: 1500 * time.Millisecond})
+
+	w := serve(gw, "", http.MethodPost, "/login", `{"email":"test@mail.com","password":"pass"}`)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+}
+
+func TestGateway_RequestPasswordReset(t *testing.T) {
+	gw, services := newTestGateway(t)
+
+	services.user.EXPECT().RequestPasswordReset(gomock.Any(), "test@mail.com").Return(nil)
+
+	w := serve(gw, "", http.MethodPost, "/password/reset", `{"email":"test@mail.com"}`)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestGateway_CreateUser(t *testing.T) {
+	gw, services := newTestGateway(t)
+
+	const actorId = "11111111-1111-1111-1111-111111111111"
+	claims := jwt.MapClaims{"user_id": actorId, "role": dto.RoleModerator}
+
+	createdAt := time.Date(2025, 4, 11, 10, 0, 0, 0, time.UTC)
+	services.user.EXPECT().ActiveUserRole(gomock.Any(), actorId).Return(dto.RoleModerator, nil)
+	services.user.EXPECT().CreateUser(gomock.Any(), actorId, "mod@mail.com", "Password1", dto.RoleModerator).
+		Return(models.User{Id: "u1", Email: "mod@mail.com", Role: dto.RoleModerator, CreatedAt: createdAt}, nil)
+
+	w := serveWithClaims(gw, claims, http.MethodPost, "/users",
+		`{"email":"mod@mail.com","password":"Password1","role":"moderator"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "u1", resp["id"])
+	assert.Equal(t, "2025-04-11T10:00:00Z", resp["createdAt"])
+}
+
+func TestGateway_InvalidQueryParam(t *testing.T) {
+	gw, _ := newTestGateway(t)
+
+	w := serve(gw, dto.RoleEmployee, http.MethodGet, "/pvz/nearby?lat=55.75&lon=37.62&limit=abc", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorDto
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "Невалидный параметр limit", resp.Message)
+}
+
+func TestGateway_GetNearbyPVZs(t *testing.T) {
+	gw, services := newTestGateway(t)
+	lat, lon := 55.751, 37.621
+
+	services.pvz.EXPECT().GetNearbyPVZs(gomock.Any(), 55.75, 37.62, 2000.0, 10).
+		Return([]models.PVZWithDistance{
+			{PVZ: models.PVZ{Id: "pvz1", Latitude: &lat, Longitude: &lon}, Distance: 130.5},
+		}, nil)
+
+	w := serve(gw, dto.RoleEmployee, http.MethodGet, "/pvz/nearby?lat=55.75&lon=37.62&radius=2000", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp []dto.NearbyPVZDto
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, "pvz1", resp[0].PVZ.Id)
+	assert.Equal(t, 130.5, resp[0].Distance)
+}
+
+func TestGateway_DeleteLastProduct(t *testing.T) {
+	gw, services := newTestGateway(t)
+
+	services.pvz.EXPECT().DeleteLastProduct(gomock.Any(), "pvz1").Return(nil)
+
+	w := serve(gw, dto.RoleEmployee, http.MethodPost, "/pvz/pvz1/delete_last_product", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestRoutes(t *testing.T) {
+	routes := Routes()
+
+	index := make(map[string]int, len(routes))
+	for i, route := range routes {
+		index[route.Method+" "+route.Path] = i
+	}
+
+	assert.Contains(t, index, "POST /pvz/{pvzId}/close_last_reception")
+	assert.Contains(t, index, "GET /receptions/{receptionId}/products")
+	assert.Contains(t, index, "PUT /users/{userId}/role")
+	assert.Less(t, index["GET /pvz/nearby"], index["GET /pvz/{pvzId}"])
+
+	for _, route := range routes {
+		switch route.Path {
+		case "/dummyLogin", "/register", "/login", "/password/reset", "/password/reset/confirm":
+			assert.True(t, route.Public, route.Path)
+		default:
+			assert.False(t, route.Public, route.Path)
+		}
+	}
+}
+
+func TestRequiresAuth(t *testing.T) {
+	assert.False(t, RequiresAuth(pvz_v1.UserService_Login_FullMethodName))
+	assert.True(t, RequiresAuth(pvz_v1.PVZService_CreatePVZ_FullMethodName))
+	assert.False(t, RequiresAuth(pvz_v1.PVZService_GetPVZList_FullMethodName))
+	assert.False(t, RequiresAuth("/grpc.health.v1.Health/Check"))
+}
@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Route — HTTP маршрут метода API в формате шаблонов gorilla/mux
+type Route struct {
+	Method     string
+	Path       string
+	FullMethod string
+	Public     bool
+}
+
+type methodInfo struct {
+	route       *Route
+	public      bool
+	successCode int
+}
+
+// methods описывает все методы API по опциям pvz.proto
+var methods = describeMethods(pvz_v1.File_pvz_proto)
+
+// Routes возвращает HTTP маршруты всех методов с аннотацией google.api.http.
+// Маршруты без переменных идут первыми, чтобы /pvz/nearby проверялся раньше /pvz/{pvzId}
+func Routes() []Route {
+	routes := make([]Route, 0, len(methods))
+	for _, info := range methods {
+		if info.route != nil {
+			routes = append(routes, *info.route)
+		}
+	}
+
+	slices.SortFunc(routes, func(a, b Route) int {
+		if c := strings.Count(a.Path, "{") - strings.Count(b.Path, "{"); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+
+	return routes
+}
+
+// RequiresAuth сообщает, нужен ли токен для вызова метода. Публичными считаются
+// методы API с пустым требованием security в openapiv2_operation, а также методы
+// вне pvz.proto (например, grpc.health.v1)
+func RequiresAuth(fullMethod string) bool {
+	info, ok := methods[fullMethod]
+	return ok && !info.public
+}
+
+func describeMethods(file protoreflect.FileDescriptor) map[string]methodInfo {
+	result := make(map[string]methodInfo)
+
+	services := file.Services()
+	for i := range services.Len() {
+		service := services.Get(i)
+		serviceMethods := service.Methods()
+		for j := range serviceMethods.Len() {
+			method := serviceMethods.Get(j)
+			fullMethod := "/" + string(service.FullName()) + "/" + string(method.Name())
+
+			operation, _ := proto.GetExtension(method.Options(), options.E_Openapiv2Operation).(*options.Operation)
+			info := methodInfo{
+				public:      isPublic(operation),
+				successCode: successCode(operation),
+			}
+
+			if rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule); ok && rule != nil {
+				httpMethod, path := httpPattern(rule)
+				info.route = &Route{
+					Method:     httpMethod,
+					Path:       muxPath(path),
+					FullMethod: fullMethod,
+					Public:     info.public,
+				}
+			}
+
+			result[fullMethod] = info
+		}
+	}
+
+	return result
+}
+
+func isPublic(operation *options.Operation) bool {
+	if len(operation.GetSecurity()) == 0 {
+		return false
+	}
+
+	for _, requirement := range operation.GetSecurity() {
+		if len(requirement.GetSecurityRequirement()) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// successCode возвращает наименьший код 2xx из ответов, объявленных для метода
+func successCode(operation *options.Operation) int {
+	code := 0
+	for key := range operation.GetResponses() {
+		value, err := strconv.Atoi(key)
+		if err != nil || value < 200 || value > 299 {
+			continue
+		}
+		if code == 0 || value < code {
+			code = value
+		}
+	}
+
+	if code == 0 {
+		return http.StatusOK
+	}
+	return code
+}
+
+func httpPattern(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return "", ""
+	}
+}
+
+var pathVariable = regexp.MustCompile(`\{([a-z0-9_]+)\}`)
+
+// muxPath переводит переменные пути из snake_case proto в camelCase,
+// как они назывались в REST API: {pvz_id} -> {pvzId}
+func muxPath(path string) string {
+	return pathVariable.ReplaceAllStringFunc(path, func(variable string) string {
+		parts := strings.Split(strings.Trim(variable, "{}"), "_")
+		for i := 1; i < len(parts); i++ {
+			if parts[i] != "" {
+				parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+			}
+		}
+		return "{" + strings.Join(parts, "") + "}"
+	})
+}
@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authMetadataKey = "auth-x"
+
+var (
+	errForbidden   = status.Error(codes.PermissionDenied, "Доступ запрещен")
+	errInternal    = status.Error(codes.Internal, "Внутренняя ошибка сервера")
+	errInvalidData = status.Error(codes.InvalidArgument, "Некорректные данные")
+)
+
+// authenticate проверяет токен из метаданных auth-x и кладет claims в контекст
+// под тем же ключом, что и HTTP AuthMiddleware, поэтому серверы одинаково
+// работают и за REST шлюзом, и при прямых gRPC вызовах. Владелец токена
+// проверяется через users так же, как в AuthMiddleware
+func authenticate(ctx context.Context, users middlewares.UserChecker) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authMetadataKey)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "Токен сформирован неверно")
+	}
+
+	claims, err := middlewares.ParseToken(values[0])
+	if err != nil {
+		if errors.Is(err, middlewares.ErrMalformedToken) {
+			return nil, status.Error(codes.Unauthenticated, "Токен сформирован неверно")
+		}
+		return nil, status.Error(codes.Unauthenticated, "Неверный токен")
+	}
+
+	err = middlewares.CheckUser(ctx, users, claims)
+	switch {
+	case errors.Is(err, dto.ErrUserDisabled):
+		return nil, status.Error(codes.PermissionDenied, "Учетная запись отключена")
+	case errors.Is(err, dto.ErrUserNotFound):
+		return nil, status.Error(codes.Unauthenticated, "Неверный токен")
+	case err != nil:
+		return nil, errInternal
+	}
+
+	middlewares.SetRequestUser(ctx, claims)
+
+	return context.WithValue(ctx, middlewares.Key("props"), claims), nil
+}
+
+// UnaryAuthInterceptor требует токен для методов, для которых requiresAuth возвращает true
+func UnaryAuthInterceptor(
+	requiresAuth func(fullMethod string) bool,
+	users middlewares.UserChecker,
+) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !requiresAuth(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, users)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// contextStream подменяет контекст потока, чтобы интерсепторы могли
+// передать обработчику claims или спан
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamAuthInterceptor требует токен для потоковых методов, для которых requiresAuth возвращает true
+func StreamAuthInterceptor(
+	requiresAuth func(fullMethod string) bool,
+	users middlewares.UserChecker,
+) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !requiresAuth(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), users)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// roleFromContext возвращает роль из claims, положенных в контекст
+// AuthMiddleware или интерсептором
+func roleFromContext(ctx context.Context) string {
+	claims, ok := ctx.Value(middlewares.Key("props")).(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+
+	role, _ := claims["role"].(string)
+	return role
+}
+
+// userIdFromContext возвращает пользователя из токена. У токенов dummyLogin
+// пользователя нет, для них возвращается пустая строка
+func userIdFromContext(ctx context.Context) string {
+	claims, ok := ctx.Value(middlewares.Key("props")).(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+
+	userId, _ := claims["user_id"].(string)
+	return userId
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// stubUsers отдает роль пользователя по идентификатору; ошибки задаются
+// значением errs
+type stubUsers struct {
+	roles map[string]string
+	errs  map[string]error
+}
+
+func (s stubUsers) ActiveUserRole(_ context.Context, userId string) (string, error) {
+	if err, ok := s.errs[userId]; ok {
+		return "", err
+	}
+	return s.roles[userId], nil
+}
+
+func TestUnaryAuthInterceptor(t *testing.T) {
+	users := stubUsers{
+		roles: map[string]string{"promoted": dto.RoleModerator},
+		errs: map[string]error{
+			"disabled": dto.ErrUserDisabled,
+			"deleted":  dto.ErrUserNotFound,
+			"broken":   dto.ErrDBRead,
+		},
+	}
+	interceptor := UnaryAuthInterceptor(func(fullMethod string) bool {
+		return fullMethod != "/public"
+	}, users)
+	handler := func(ctx context.Context, _ any) (any, error) {
+		return roleFromContext(ctx), nil
+	}
+
+	userToken := func(userId string) string {
+		token, err := middlewares.CreateToken(userId, dto.RoleEmployee)
+		assert.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	token, err := middlewares.CreateToken("", dto.RoleModerator)
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		method string
+		token  string
+		role   any
+		code   codes.Code
+	}{
+		"public without token":  {method: "/public", role: "", code: codes.OK},
+		"private without token": {method: "/private", code: codes.Unauthenticated},
+		"invalid token":         {method: "/private", token: "Bearer invalid", code: codes.Unauthenticated},
+		"valid token":           {method: "/private", token: "Bearer " + token, role: dto.RoleModerator, code: codes.OK},
+		"role from database":    {method: "/private", token: userToken("promoted"), role: dto.RoleModerator, code: codes.OK},
+		"disabled user":         {method: "/private", token: userToken("disabled"), code: codes.PermissionDenied},
+		"deleted user":          {method: "/private", token: userToken("deleted"), code: codes.Unauthenticated},
+		"database error":        {method: "/private", token: userToken("broken"), code: codes.Internal},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authMetadataKey, tc.token))
+			}
+
+			role, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			assert.Equal(t, tc.code, status.Code(err))
+			assert.Equal(t, tc.role, role)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const forwardedForMetadataKey = "x-forwarded-for"
+
+// withClientIP кладет в контекст адрес клиента из peer и x-forwarded-for так же,
+// как HTTP ClientIPMiddleware
+func withClientIP(ctx context.Context, resolve func(remoteAddr, forwardedFor string) string) context.Context {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	forwardedFor := strings.Join(md.Get(forwardedForMetadataKey), ",")
+
+	return middlewares.WithClientIP(ctx, resolve(remoteAddr, forwardedFor))
+}
+
+// UnaryClientIPInterceptor определяет адрес клиента для унарных вызовов
+func UnaryClientIPInterceptor(resolve func(remoteAddr, forwardedFor string) string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withClientIP(ctx, resolve), req)
+	}
+}
+
+// StreamClientIPInterceptor определяет адрес клиента для потоковых вызовов
+func StreamClientIPInterceptor(resolve func(remoteAddr, forwardedFor string) string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: withClientIP(ss.Context(), resolve)})
+	}
+}
@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"time"
+
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Даты в моделях хранятся строками в формате RFC3339, как их отдает драйвер БД
+func toTimestamp(value string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// optionalString переводит пустую строку модели в незаполненное поле,
+// чтобы оно не попадало в JSON ответа
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func userToProto(u models.User) *pvz_v1.User {
+	user := &pvz_v1.User{
+		Id:       u.Id,
+		Email:    u.Email,
+		Role:     u.Role,
+		Disabled: u.Disabled(),
+	}
+	if !u.CreatedAt.IsZero() {
+		user.CreatedAt = timestamppb.New(u.CreatedAt)
+	}
+	return user
+}
+
+func usersToProto(users []models.User) []*pvz_v1.User {
+	result := make([]*pvz_v1.User, 0, len(users))
+	for _, user := range users {
+		result = append(result, userToProto(user))
+	}
+	return result
+}
+
+func pvzToProto(p models.PVZ) *pvz_v1.PVZ {
+	return &pvz_v1.PVZ{
+		Id:               p.Id,
+		RegistrationDate: toTimestamp(p.RegistrationDate),
+		City:             p.City,
+		Name:             optionalString(p.Name),
+		Address:          optionalString(p.Address),
+		WorkingHours:     optionalString(p.WorkingHours),
+		Status:           optionalString(p.Status),
+		Latitude:         p.Latitude,
+		Longitude:        p.Longitude,
+		Timezone:         optionalString(p.Timezone),
+	}
+}
+
+func receptionToProto(r models.Reception) *pvz_v1.Reception {
+	return &pvz_v1.Reception{
+		Id:       r.Id,
+		DateTime: toTimestamp(r.DateTime),
+		PvzId:    r.PVZId,
+		Status:   r.Status,
+	}
+}
+
+func receptionsToProto(receptions []models.Reception) []*pvz_v1.Reception {
+	result := make([]*pvz_v1.Reception, 0, len(receptions))
+	for _, reception := range receptions {
+		result = append(result, receptionToProto(reception))
+	}
+	return result
+}
+
+func productToProto(p models.Product) *pvz_v1.Product {
+	return &pvz_v1.Product{
+		Id:          p.Id,
+		DateTime:    toTimestamp(p.DateTime),
+		Type:        p.Type,
+		ReceptionId: p.ReceptionId,
+	}
+}
+
+func productsToProto(products []models.Product) []*pvz_v1.Product {
+	result := make([]*pvz_v1.Product, 0, len(products))
+	for _, product := range products {
+		result = append(result, productToProto(product))
+	}
+	return result
+}
+
+func statusChangeToProto(change models.ReceptionStatusChange) *pvz_v1.ReceptionStatusChange {
+	return &pvz_v1.ReceptionStatusChange{
+		ReceptionId:   change.ReceptionId,
+		FromStatus:    optionalString(change.FromStatus),
+		ToStatus:      change.ToStatus,
+		ChangedAt:     toTimestamp(change.ChangedAt),
+		ChangedByRole: change.ChangedByRole,
+		Reason:        optionalString(change.Reason),
+	}
+}
+
+// pvzListToProto повторяет форму REST ответа для уровня детализации expand:
+// при pvz приемки не заполняются, при receptions вместо товаров передается их количество
+func pvzListToProto(pvzs []models.PVZWithReceptions, expand string) []*pvz_v1.PVZWithReceptions {
+	result := make([]*pvz_v1.PVZWithReceptions, 0, len(pvzs))
+
+	for _, pvz := range pvzs {
+		item := &pvz_v1.PVZWithReceptions{Pvz: pvzToProto(pvz.PVZ)}
+
+		if expand != models.ExpandPVZ {
+			for _, reception := range pvz.Receptions {
+				receptionWithProducts := &pvz_v1.ReceptionWithProducts{
+					Reception: receptionToProto(reception.Reception),
+				}
+				if expand == models.ExpandReceptions {
+					count := int32(reception.ProductCount)
+					receptionWithProducts.ProductCount = &count
+				} else {
+					receptionWithProducts.Products = productsToProto(reception.Products)
+				}
+				item.Receptions = append(item.Receptions, receptionWithProducts)
+			}
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
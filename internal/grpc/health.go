@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/health"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// WatchHealth периодически переносит результат проверок зависимостей в
+// стандартный сервис grpc.health.v1: статус общий для сервера ("") и всех сервисов API.
+// После начала остановки статус выставляет grpchealth.Server.Shutdown
+func WatchHealth(ctx context.Context, checker *health.Checker, server *grpchealth.Server, interval time.Duration) {
+	update := func() {
+		if checker.ShuttingDown() {
+			return
+		}
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if !checker.Healthy(ctx) {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		server.SetServingStatus("", status)
+		for _, service := range []string{
+			pvz_v1.UserService_ServiceDesc.ServiceName,
+			pvz_v1.PVZService_ServiceDesc.ServiceName,
+			pvz_v1.ReceptionService_ServiceDesc.ServiceName,
+			pvz_v1.ProductService_ServiceDesc.ServiceName,
+		} {
+			server.SetServingStatus(service, status)
+		}
+	}
+
+	update()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
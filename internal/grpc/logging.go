@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDMetadataKey = "x-request-id"
+
+// startRequest берет x-request-id из метаданных или выдает новый, отправляет
+// его клиенту в заголовках ответа и кладет в контекст логгер запроса
+func startRequest(ctx context.Context, base *zap.SugaredLogger) (context.Context, *middlewares.RequestUser) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var incoming string
+	if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+		incoming = values[0]
+	}
+	requestID := middlewares.RequestID(incoming)
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+
+	ctx, user := middlewares.WithRequestUser(ctx)
+	return logger.WithContext(ctx, base.With("request_id", requestID)), user
+}
+
+func logRequest(ctx context.Context, base *zap.SugaredLogger, fullMethod string, start time.Time, user *middlewares.RequestUser, err error) {
+	logger.FromContext(ctx, base).Infow("request",
+		"method", fullMethod,
+		"code", status.Code(err).String(),
+		"latency", time.Since(start),
+		"user_id", user.Id,
+		"role", user.Role,
+	)
+}
+
+// UnaryLoggingInterceptor пишет access log унарных вызовов с тем же набором
+// полей, что и HTTP LoggingMiddleware
+func UnaryLoggingInterceptor(base *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, user := startRequest(ctx, base)
+
+		resp, err := handler(ctx, req)
+		logRequest(ctx, base, info.FullMethod, start, user, err)
+
+		return resp, err
+	}
+}
+
+// StreamLoggingInterceptor пишет access log потоковых вызовов
+func StreamLoggingInterceptor(base *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, user := startRequest(ss.Context(), base)
+
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logRequest(ctx, base, info.FullMethod, start, user, err)
+
+		return err
+	}
+}
@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryLoggingInterceptor(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	base := zap.New(core).Sugar()
+
+	token, err := middlewares.CreateToken("user1", dto.RoleEmployee)
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		requestIDMetadataKey, "req-1",
+		authMetadataKey, "Bearer "+token,
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/pvz.v1.PVZService/GetPVZ"}
+	handler := func(ctx context.Context, _ any) (any, error) {
+		logger.FromContext(ctx, base).Info("inside handler")
+		return nil, nil
+	}
+
+	interceptors := []grpc.UnaryServerInterceptor{
+		UnaryLoggingInterceptor(base),
+		UnaryAuthInterceptor(func(string) bool { return true }, nil),
+	}
+	_, err = interceptors[0](ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return interceptors[1](ctx, req, info, handler)
+	})
+	require.NoError(t, err)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "inside handler", entries[0].Message)
+	assert.Equal(t, "req-1", entries[0].ContextMap()["request_id"])
+
+	access := entries[1].ContextMap()
+	assert.Equal(t, "request", entries[1].Message)
+	assert.Equal(t, "req-1", access["request_id"])
+	assert.Equal(t, info.FullMethod, access["method"])
+	assert.Equal(t, "OK", access["code"])
+	assert.Equal(t, "user1", access["user_id"])
+	assert.Equal(t, dto.RoleEmployee, access["role"])
+}
+
+func TestRequestIDGeneratedWhenMissing(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/pvz.v1.UserService/DummyLogin"}
+	_, err := UnaryLoggingInterceptor(zap.New(core).Sugar())(context.Background(), nil, info,
+		func(context.Context, any) (any, error) { return nil, nil })
+	require.NoError(t, err)
+
+	require.Len(t, logs.AllUntimed(), 1)
+	assert.NotEmpty(t, logs.AllUntimed()[0].ContextMap()["request_id"])
+}
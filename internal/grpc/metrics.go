@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// observe учитывает завершенный вызов. Метка method — полное имя метода:
+// вызовы неизвестных методов отклоняются до интерсепторов, поэтому
+// число значений ограничено методами pvz.proto и health
+func observe(fullMethod string, start time.Time, err error) {
+	metrics.GRPCRequestCount.WithLabelValues(fullMethod, status.Code(err).String()).Inc()
+	metrics.GRPCResponseDuration.WithLabelValues(fullMethod).Observe(time.Since(start).Seconds())
+}
+
+// UnaryMetricsInterceptor считает унарные вызовы по методу и коду ответа
+func UnaryMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		metrics.GRPCRequestsInFlight.Inc()
+		defer metrics.GRPCRequestsInFlight.Dec()
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor считает потоковые вызовы по методу и коду ответа
+func StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		metrics.GRPCRequestsInFlight.Inc()
+		defer metrics.GRPCRequestsInFlight.Dec()
+
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
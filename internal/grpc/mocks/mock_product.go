@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,16 +36,32 @@ func (m *MockProductService) EXPECT() *MockProductServiceMockRecorder {
 }
 
 // AddProductToReception mocks base method.
-func (m *MockProductService) AddProductToReception(productType, pvzId string) (models.Product, error) {
+func (m *MockProductService) AddProductToReception(ctx context.Context, productType, pvzId string) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProductToReception", productType, pvzId)
+	ret := m.ctrl.Call(m, "AddProductToReception", ctx, productType, pvzId)
 	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddProductToReception indicates an expected call of AddProductToReception.
-func (mr *MockProductServiceMockRecorder) AddProductToReception(productType, pvzId interface{}) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) AddProductToReception(ctx, productType, pvzId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductToReception", reflect.TypeOf((*MockProductService)(nil).AddProductToReception), productType, pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductToReception", reflect.TypeOf((*MockProductService)(nil).AddProductToReception), ctx, productType, pvzId)
+}
+
+// GetReceptionProducts mocks base method.
+func (m *MockProductService) GetReceptionProducts(ctx context.Context, recId string, filter models.ProductListFilter, limit int) ([]models.Product, *models.ProductCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionProducts", ctx, recId, filter, limit)
+	ret0, _ := ret[0].([]models.Product)
+	ret1, _ := ret[1].(*models.ProductCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReceptionProducts indicates an expected call of GetReceptionProducts.
+func (mr *MockProductServiceMockRecorder) GetReceptionProducts(ctx, recId, filter, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionProducts", reflect.TypeOf((*MockProductService)(nil).GetReceptionProducts), ctx, recId, filter, limit)
 }
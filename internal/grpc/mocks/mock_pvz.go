@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pvz.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/hamillka/avitoTechSpring25/internal/models"
+)
+
+// MockPVZService is a mock of PVZService interface.
+type MockPVZService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPVZServiceMockRecorder
+}
+
+// MockPVZServiceMockRecorder is the mock recorder for MockPVZService.
+type MockPVZServiceMockRecorder struct {
+	mock *MockPVZService
+}
+
+// NewMockPVZService creates a new mock instance.
+func NewMockPVZService(ctrl *gomock.Controller) *MockPVZService {
+	mock := &MockPVZService{ctrl: ctrl}
+	mock.recorder = &MockPVZServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPVZService) EXPECT() *MockPVZServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangePVZStatus mocks base method.
+func (m *MockPVZService) ChangePVZStatus(ctx context.Context, pvzId, status string) (models.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePVZStatus", ctx, pvzId, status)
+	ret0, _ := ret[0].(models.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePVZStatus indicates an expected call of ChangePVZStatus.
+func (mr *MockPVZServiceMockRecorder) ChangePVZStatus(ctx, pvzId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePVZStatus", reflect.TypeOf((*MockPVZService)(nil).ChangePVZStatus), ctx, pvzId, status)
+}
+
+// CloseLastReception mocks base method.
+func (m *MockPVZService) CloseLastReception(ctx context.Context, pvzId string) (models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseLastReception", ctx, pvzId)
+	ret0, _ := ret[0].(models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseLastReception indicates an expected call of CloseLastReception.
+func (mr *MockPVZServiceMockRecorder) CloseLastReception(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseLastReception", reflect.TypeOf((*MockPVZService)(nil).CloseLastReception), ctx, pvzId)
+}
+
+// CountPVZs mocks base method.
+func (m *MockPVZService) CountPVZs(ctx context.Context, filter models.PVZFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPVZs", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPVZs indicates an expected call of CountPVZs.
+func (mr *MockPVZServiceMockRecorder) CountPVZs(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPVZs", reflect.TypeOf((*MockPVZService)(nil).CountPVZs), ctx, filter)
+}
+
+// CreatePVZ mocks base method.
+func (m *MockPVZService) CreatePVZ(ctx context.Context, city string) (models.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePVZ", ctx, city)
+	ret0, _ := ret[0].(models.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePVZ indicates an expected call of CreatePVZ.
+func (mr *MockPVZServiceMockRecorder) CreatePVZ(ctx, city interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePVZ", reflect.TypeOf((*MockPVZService)(nil).CreatePVZ), ctx, city)
+}
+
+// DeleteLastProduct mocks base method.
+func (m *MockPVZService) DeleteLastProduct(ctx context.Context, pvzId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLastProduct", ctx, pvzId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLastProduct indicates an expected call of DeleteLastProduct.
+func (mr *MockPVZServiceMockRecorder) DeleteLastProduct(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastProduct", reflect.TypeOf((*MockPVZService)(nil).DeleteLastProduct), ctx, pvzId)
+}
+
+// GetAllPVZs mocks base method.
+func (m *MockPVZService) GetAllPVZs(ctx context.Context) ([]models.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPVZs", ctx)
+	ret0, _ := ret[0].([]models.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPVZs indicates an expected call of GetAllPVZs.
+func (mr *MockPVZServiceMockRecorder) GetAllPVZs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPVZs", reflect.TypeOf((*MockPVZService)(nil).GetAllPVZs), ctx)
+}
+
+// GetNearbyPVZs mocks base method.
+func (m *MockPVZService) GetNearbyPVZs(ctx context.Context, lat, lon, radius float64, limit int) ([]models.PVZWithDistance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyPVZs", ctx, lat, lon, radius, limit)
+	ret0, _ := ret[0].([]models.PVZWithDistance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyPVZs indicates an expected call of GetNearbyPVZs.
+func (mr *MockPVZServiceMockRecorder) GetNearbyPVZs(ctx, lat, lon, radius, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyPVZs", reflect.TypeOf((*MockPVZService)(nil).GetNearbyPVZs), ctx, lat, lon, radius, limit)
+}
+
+// GetPVZ mocks base method.
+func (m *MockPVZService) GetPVZ(ctx context.Context, pvzId string) (models.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZ", ctx, pvzId)
+	ret0, _ := ret[0].(models.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZ indicates an expected call of GetPVZ.
+func (mr *MockPVZServiceMockRecorder) GetPVZ(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZ", reflect.TypeOf((*MockPVZService)(nil).GetPVZ), ctx, pvzId)
+}
+
+// GetPVZWithPagination mocks base method.
+func (m *MockPVZService) GetPVZWithPagination(ctx context.Context, filter models.PVZFilter, expand string, page, limit int) ([]models.PVZWithReceptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZWithPagination", ctx, filter, expand, page, limit)
+	ret0, _ := ret[0].([]models.PVZWithReceptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZWithPagination indicates an expected call of GetPVZWithPagination.
+func (mr *MockPVZServiceMockRecorder) GetPVZWithPagination(ctx, filter, expand, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZWithPagination", reflect.TypeOf((*MockPVZService)(nil).GetPVZWithPagination), ctx, filter, expand, page, limit)
+}
+
+// UpdatePVZ mocks base method.
+func (m *MockPVZService) UpdatePVZ(ctx context.Context, pvzId string, upd models.PVZUpdate) (models.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePVZ", ctx, pvzId, upd)
+	ret0, _ := ret[0].(models.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePVZ indicates an expected call of UpdatePVZ.
+func (mr *MockPVZServiceMockRecorder) UpdatePVZ(ctx, pvzId, upd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePVZ", reflect.TypeOf((*MockPVZService)(nil).UpdatePVZ), ctx, pvzId, upd)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reception.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/hamillka/avitoTechSpring25/internal/models"
+)
+
+// MockReceptionService is a mock of ReceptionService interface.
+type MockReceptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceptionServiceMockRecorder
+}
+
+// MockReceptionServiceMockRecorder is the mock recorder for MockReceptionService.
+type MockReceptionServiceMockRecorder struct {
+	mock *MockReceptionService
+}
+
+// NewMockReceptionService creates a new mock instance.
+func NewMockReceptionService(ctrl *gomock.Controller) *MockReceptionService {
+	mock := &MockReceptionService{ctrl: ctrl}
+	mock.recorder = &MockReceptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceptionService) EXPECT() *MockReceptionServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangeReceptionStatus mocks base method.
+func (m *MockReceptionService) ChangeReceptionStatus(ctx context.Context, recId, status, role, reason string) (models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReceptionStatus", ctx, recId, status, role, reason)
+	ret0, _ := ret[0].(models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeReceptionStatus indicates an expected call of ChangeReceptionStatus.
+func (mr *MockReceptionServiceMockRecorder) ChangeReceptionStatus(ctx, recId, status, role, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReceptionStatus", reflect.TypeOf((*MockReceptionService)(nil).ChangeReceptionStatus), ctx, recId, status, role, reason)
+}
+
+// CreateReception mocks base method.
+func (m *MockReceptionService) CreateReception(ctx context.Context, pvzId string) (models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReception", ctx, pvzId)
+	ret0, _ := ret[0].(models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReception indicates an expected call of CreateReception.
+func (mr *MockReceptionServiceMockRecorder) CreateReception(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockReceptionService)(nil).CreateReception), ctx, pvzId)
+}
+
+// ExportReceptions mocks base method.
+func (m *MockReceptionService) ExportReceptions(ctx context.Context, filter models.PVZFilter, fn func(models.ReceptionExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReceptions", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportReceptions indicates an expected call of ExportReceptions.
+func (mr *MockReceptionServiceMockRecorder) ExportReceptions(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReceptions", reflect.TypeOf((*MockReceptionService)(nil).ExportReceptions), ctx, filter, fn)
+}
+
+// GetPVZReceptions mocks base method.
+func (m *MockReceptionService) GetPVZReceptions(ctx context.Context, pvzId string, filter models.ReceptionListFilter, page, limit int) ([]models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZReceptions", ctx, pvzId, filter, page, limit)
+	ret0, _ := ret[0].([]models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZReceptions indicates an expected call of GetPVZReceptions.
+func (mr *MockReceptionServiceMockRecorder) GetPVZReceptions(ctx, pvzId, filter, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZReceptions", reflect.TypeOf((*MockReceptionService)(nil).GetPVZReceptions), ctx, pvzId, filter, page, limit)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockReceptionService) GetStatusHistory(ctx context.Context, recId string) ([]models.ReceptionStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, recId)
+	ret0, _ := ret[0].([]models.ReceptionStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockReceptionServiceMockRecorder) GetStatusHistory(ctx, recId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockReceptionService)(nil).GetStatusHistory), ctx, recId)
+}
@@ -0,0 +1,157 @@
+//go:generate mockgen -source=product.go -destination=./mocks/mock_product.go -package=mocks
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultProductLimit = 20
+	maxProductLimit     = 100
+)
+
+type ProductService interface {
+	AddProductToReception(ctx context.Context, productType, pvzId string) (models.Product, error)
+	GetReceptionProducts(ctx context.Context, recId string, filter models.ProductListFilter, limit int) ([]models.Product, *models.ProductCursor, error)
+}
+
+type ProductServer struct {
+	pvz_v1.UnimplementedProductServiceServer
+	service ProductService
+	logger  *zap.SugaredLogger
+}
+
+func NewProductServer(s ProductService, logger *zap.SugaredLogger) *ProductServer {
+	return &ProductServer{
+		service: s,
+		logger:  logger,
+	}
+}
+
+func (s *ProductServer) AddProduct(ctx context.Context, req *pvz_v1.AddProductRequest) (*pvz_v1.Product, error) {
+	if role := roleFromContext(ctx); role != dto.RoleEmployee {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return nil, errForbidden
+	}
+
+	if !slices.Contains(dto.ProductTypes, req.GetType()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid product type: %v", req.GetType())
+		return nil, errInvalidData
+	}
+
+	product, err := s.service.AddProductToReception(ctx, req.GetType(), req.GetPvzId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to add product to reception: %v", err)
+		switch {
+		case errors.Is(err, dto.ErrPVZNotFound):
+			return nil, status.Error(codes.InvalidArgument, "ПВЗ не найден")
+		case errors.Is(err, dto.ErrNoActiveReception):
+			return nil, status.Error(codes.FailedPrecondition, "Нет активной приемки")
+		default:
+			return nil, errInternal
+		}
+	}
+
+	return productToProto(product), nil
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Курсор непрозрачен для клиента: это дата и идентификатор последнего
+// отданного товара, закодированные в base64
+func encodeProductCursor(cursor *models.ProductCursor) *string {
+	if cursor == nil {
+		return nil
+	}
+
+	raw := cursor.DateTime.Format(time.RFC3339Nano) + "|" + cursor.Id
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(raw))
+	return &encoded
+}
+
+func decodeProductCursor(value string) (*models.ProductCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	dateTimeStr, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, errInvalidCursor
+	}
+
+	dateTime, err := time.Parse(time.RFC3339Nano, dateTimeStr)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return &models.ProductCursor{DateTime: dateTime, Id: id}, nil
+}
+
+func (s *ProductServer) ListReceptionProducts(
+	ctx context.Context,
+	req *pvz_v1.ListReceptionProductsRequest,
+) (*pvz_v1.ListReceptionProductsResponse, error) {
+	if !allAllowed(req.GetType(), dto.ProductTypes) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid product type: %v", req.GetType())
+		return nil, invalidParam("type")
+	}
+
+	order := models.SortAsc
+	if req.GetOrder() != "" {
+		order = req.GetOrder()
+	}
+	if !slices.Contains(dto.SortOrders, order) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid order: %v", order)
+		return nil, invalidParam("order")
+	}
+
+	_, limit, err := pageParams(nil, req.Limit, defaultProductLimit, maxProductLimit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid limit: %v", req.GetLimit())
+		return nil, err
+	}
+
+	cursor, err := decodeProductCursor(req.GetCursor())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("error in decoding cursor: %v", err)
+		return nil, invalidParam("cursor")
+	}
+
+	filter := models.ProductListFilter{
+		Types:     req.GetType(),
+		SortOrder: order,
+		After:     cursor,
+	}
+
+	products, next, err := s.service.GetReceptionProducts(ctx, req.GetReceptionId(), filter, limit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to get reception products: %v", err)
+		if errors.Is(err, dto.ErrReceptionNotFound) {
+			return nil, status.Error(codes.NotFound, "Приемка не найдена")
+		}
+		return nil, errInternal
+	}
+
+	return &pvz_v1.ListReceptionProductsResponse{
+		Items:      productsToProto(products),
+		NextCursor: encodeProductCursor(next),
+	}, nil
+}
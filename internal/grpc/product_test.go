@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/grpc/mocks"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAddProduct_Forbidden(t *testing.T) {
+	server := NewProductServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.AddProduct(withRole(dto.RoleModerator), &pvz_v1.AddProductRequest{Type: dto.ProductTypeShoes, PvzId: "pvz1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAddProduct_InvalidType(t *testing.T) {
+	server := NewProductServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.AddProduct(withRole(dto.RoleEmployee), &pvz_v1.AddProductRequest{Type: "food", PvzId: "pvz1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAddProduct_ServiceErrors(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		code codes.Code
+	}{
+		"pvz not found":       {dto.ErrPVZNotFound, codes.InvalidArgument},
+		"no active reception": {dto.ErrNoActiveReception, codes.FailedPrecondition},
+		"unknown":             {errors.New("db error"), codes.Internal},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			service := mocks.NewMockProductService(ctrl)
+			server := NewProductServer(service, zaptest.NewLogger(t).Sugar())
+
+			service.EXPECT().AddProductToReception(gomock.Any(), dto.ProductTypeShoes, "pvz1").Return(models.Product{}, tc.err)
+
+			_, err := server.AddProduct(withRole(dto.RoleEmployee), &pvz_v1.AddProductRequest{Type: dto.ProductTypeShoes, PvzId: "pvz1"})
+			assert.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func TestAddProduct_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockProductService(ctrl)
+	server := NewProductServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().AddProductToReception(gomock.Any(), dto.ProductTypeShoes, "pvz1").
+		Return(models.Product{Id: "p1", Type: dto.ProductTypeShoes, ReceptionId: "rec1"}, nil)
+
+	resp, err := server.AddProduct(withRole(dto.RoleEmployee), &pvz_v1.AddProductRequest{Type: dto.ProductTypeShoes, PvzId: "pvz1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", resp.GetId())
+	assert.Equal(t, "rec1", resp.GetReceptionId())
+}
+
+func TestListReceptionProducts_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockProductService(ctrl)
+	server := NewProductServer(service, zaptest.NewLogger(t).Sugar())
+
+	after := &models.ProductCursor{DateTime: time.Date(2025, 4, 11, 10, 0, 0, 500, time.UTC), Id: "p1"}
+	next := &models.ProductCursor{DateTime: time.Date(2025, 4, 11, 11, 0, 0, 0, time.UTC), Id: "p3"}
+
+	filter := models.ProductListFilter{
+		Types:     []string{dto.ProductTypeShoes},
+		SortOrder: models.SortDesc,
+		After:     after,
+	}
+	service.EXPECT().GetReceptionProducts(gomock.Any(), "rec1", filter, 2).
+		Return([]models.Product{{Id: "p2"}, {Id: "p3"}}, next, nil)
+
+	resp, err := server.ListReceptionProducts(context.Background(), &pvz_v1.ListReceptionProductsRequest{
+		ReceptionId: "rec1",
+		Type:        []string{dto.ProductTypeShoes},
+		Order:       models.SortDesc,
+		Limit:       proto.Int32(2),
+		Cursor:      *encodeProductCursor(after),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetItems(), 2)
+
+	decoded, err := decodeProductCursor(resp.GetNextCursor())
+	assert.NoError(t, err)
+	assert.Equal(t, next.Id, decoded.Id)
+	assert.True(t, next.DateTime.Equal(decoded.DateTime))
+}
+
+func TestListReceptionProducts_InvalidParams(t *testing.T) {
+	server := NewProductServer(nil, zaptest.NewLogger(t).Sugar())
+	requests := map[string]*pvz_v1.ListReceptionProductsRequest{
+		"cursor": {ReceptionId: "rec1", Cursor: "not-a-cursor"},
+		"type":   {ReceptionId: "rec1", Type: []string{"food"}},
+		"limit":  {ReceptionId: "rec1", Limit: proto.Int32(0)},
+	}
+
+	for name, req := range requests {
+		t.Run(name, func(t *testing.T) {
+			_, err := server.ListReceptionProducts(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, "Невалидный параметр "+name, status.Convert(err).Message())
+		})
+	}
+}
+
+func TestListReceptionProducts_ReceptionNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockProductService(ctrl)
+	server := NewProductServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().GetReceptionProducts(gomock.Any(), "rec404", models.ProductListFilter{SortOrder: models.SortAsc}, 20).
+		Return(nil, nil, dto.ErrReceptionNotFound)
+
+	_, err := server.ListReceptionProducts(context.Background(), &pvz_v1.ListReceptionProductsRequest{ReceptionId: "rec404"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,349 @@
+//go:generate mockgen -source=pvz.go -destination=./mocks/mock_pvz.go -package=mocks
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	defaultPVZLimit     = 10
+	maxPVZLimit         = 30
+	defaultNearbyRadius = 5000
+	maxNearbyRadius     = 100000
+	defaultNearbyLimit  = 10
+	maxNearbyLimit      = 30
+)
+
+type PVZService interface {
+	CreatePVZ(ctx context.Context, city string) (models.PVZ, error)
+	GetPVZWithPagination(ctx context.Context, filter models.PVZFilter, expand string, page, limit int) ([]models.PVZWithReceptions, error)
+	CountPVZs(ctx context.Context, filter models.PVZFilter) (int, error)
+	GetPVZ(ctx context.Context, pvzId string) (models.PVZ, error)
+	UpdatePVZ(ctx context.Context, pvzId string, upd models.PVZUpdate) (models.PVZ, error)
+	ChangePVZStatus(ctx context.Context, pvzId, status string) (models.PVZ, error)
+	CloseLastReception(ctx context.Context, pvzId string) (models.Reception, error)
+	DeleteLastProduct(ctx context.Context, pvzId string) error
+	GetAllPVZs(ctx context.Context) ([]models.PVZ, error)
+	GetNearbyPVZs(ctx context.Context, lat, lon, radius float64, limit int) ([]models.PVZWithDistance, error)
+}
+
+type PVZServer struct {
+	pvz_v1.UnimplementedPVZServiceServer
+	service PVZService
+	logger  *zap.SugaredLogger
+}
+
+func NewPVZServer(s PVZService, logger *zap.SugaredLogger) *PVZServer {
+	return &PVZServer{
+		service: s,
+		logger:  logger,
+	}
+}
+
+var errPVZNotFound = status.Error(codes.NotFound, "ПВЗ не найден")
+
+func (s *PVZServer) GetPVZList(ctx context.Context, req *pvz_v1.GetPVZListRequest) (*pvz_v1.GetPVZListResponse, error) {
+	pvzs, err := s.service.GetAllPVZs(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to get pvzs: %v", err)
+		return nil, errInternal
+	}
+
+	response := &pvz_v1.GetPVZListResponse{}
+	for _, p := range pvzs {
+		response.Pvzs = append(response.Pvzs, pvzToProto(p))
+	}
+	return response, nil
+}
+
+func (s *PVZServer) CreatePVZ(ctx context.Context, req *pvz_v1.CreatePVZRequest) (*pvz_v1.CreatePVZResponse, error) {
+	if role := roleFromContext(ctx); role != dto.RoleModerator {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return nil, errForbidden
+	}
+
+	if !slices.Contains(dto.Cities, req.GetCity()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid city: %v", req.GetCity())
+		return nil, errInvalidRequest
+	}
+
+	pvz, err := s.service.CreatePVZ(ctx, req.GetCity())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to create pvz: %v", err)
+		return nil, errInternal
+	}
+
+	return &pvz_v1.CreatePVZResponse{
+		Id:               pvz.Id,
+		RegistrationDate: toTimestamp(pvz.RegistrationDate),
+		City:             pvz.City,
+	}, nil
+}
+
+// pvzListFilter проверяет параметры списка ПВЗ так же, как REST обработчик
+func pvzListFilter(req *pvz_v1.ListPVZRequest) (models.PVZFilter, error) {
+	startDate, endDate, err := parseDateRange(req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return models.PVZFilter{}, dateRangeError(err)
+	}
+
+	lists := []struct {
+		name    string
+		values  []string
+		allowed []string
+	}{
+		{"city", req.GetCity(), dto.Cities},
+		{"receptionStatus", req.GetReceptionStatus(), dto.ReceptionStatuses},
+		{"productType", req.GetProductType(), dto.ProductTypes},
+	}
+	for _, list := range lists {
+		if !allAllowed(list.values, list.allowed) {
+			return models.PVZFilter{}, invalidParam(list.name)
+		}
+	}
+
+	filter := models.PVZFilter{
+		StartDate:         startDate,
+		EndDate:           endDate,
+		Cities:            req.GetCity(),
+		PVZIds:            req.GetPvzId(),
+		ReceptionStatuses: req.GetReceptionStatus(),
+		ProductTypes:      req.GetProductType(),
+		HasOpenReception:  req.HasOpenReception,
+		IncludeArchived:   req.GetIncludeArchived(),
+		SortBy:            models.PVZSortRegistrationDate,
+		SortOrder:         models.SortDesc,
+	}
+
+	if req.GetSortBy() != "" {
+		filter.SortBy = req.GetSortBy()
+	}
+	if !slices.Contains(dto.PVZSortFields, filter.SortBy) {
+		return models.PVZFilter{}, invalidParam("sortBy")
+	}
+
+	if req.GetSortOrder() != "" {
+		filter.SortOrder = req.GetSortOrder()
+	}
+	if !slices.Contains(dto.SortOrders, filter.SortOrder) {
+		return models.PVZFilter{}, invalidParam("sortOrder")
+	}
+
+	return filter, nil
+}
+
+func (s *PVZServer) ListPVZ(ctx context.Context, req *pvz_v1.ListPVZRequest) (*pvz_v1.ListPVZResponse, error) {
+	page, limit, err := pageParams(req.Page, req.Limit, defaultPVZLimit, maxPVZLimit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid pagination params: %v", err)
+		return nil, err
+	}
+
+	expand := models.ExpandProducts
+	if req.GetExpand() != "" {
+		expand = req.GetExpand()
+	}
+	if !slices.Contains(dto.ExpandLevels, expand) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid expand: %v", expand)
+		return nil, invalidParam("expand")
+	}
+
+	filter, err := pvzListFilter(req)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid search params: %v", err)
+		return nil, err
+	}
+
+	pvzs, err := s.service.GetPVZWithPagination(ctx, filter, expand, page, limit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to get pvzs: %v", err)
+		return nil, errInternal
+	}
+
+	total, err := s.service.CountPVZs(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to count pvzs: %v", err)
+		return nil, errInternal
+	}
+
+	return &pvz_v1.ListPVZResponse{
+		Items:   pvzListToProto(pvzs, expand),
+		Page:    int32(page),
+		Limit:   int32(limit),
+		Total:   int32(total),
+		HasNext: page*limit < total,
+	}, nil
+}
+
+func (s *PVZServer) GetPVZ(ctx context.Context, req *pvz_v1.PVZIdRequest) (*pvz_v1.PVZ, error) {
+	pvz, err := s.service.GetPVZ(ctx, req.GetPvzId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to get pvz: %v", err)
+		if errors.Is(err, dto.ErrPVZNotFound) {
+			return nil, errPVZNotFound
+		}
+		return nil, errInternal
+	}
+
+	return pvzToProto(pvz), nil
+}
+
+func (s *PVZServer) GetNearbyPVZs(ctx context.Context, req *pvz_v1.GetNearbyPVZsRequest) (*pvz_v1.GetNearbyPVZsResponse, error) {
+	if req.Latitude == nil || req.Longitude == nil || !validCoordinates(req.GetLatitude(), req.GetLongitude()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid coordinates: lat=%v lon=%v", req.Latitude, req.Longitude)
+		return nil, status.Error(codes.InvalidArgument, "Невалидные координаты")
+	}
+
+	radius := float64(defaultNearbyRadius)
+	if req.RadiusMeters != nil {
+		radius = req.GetRadiusMeters()
+	}
+	if !(radius > 0 && radius <= maxNearbyRadius) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid radius: %v", radius)
+		return nil, invalidParam("radius")
+	}
+
+	_, limit, err := pageParams(nil, req.Limit, defaultNearbyLimit, maxNearbyLimit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid limit: %v", req.GetLimit())
+		return nil, err
+	}
+
+	pvzs, err := s.service.GetNearbyPVZs(ctx, req.GetLatitude(), req.GetLongitude(), radius, limit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to get nearby pvzs: %v", err)
+		return nil, errInternal
+	}
+
+	response := &pvz_v1.GetNearbyPVZsResponse{}
+	for _, p := range pvzs {
+		response.Pvzs = append(response.Pvzs, &pvz_v1.NearbyPVZ{
+			Pvz:            pvzToProto(p.PVZ),
+			DistanceMeters: p.Distance,
+		})
+	}
+	return response, nil
+}
+
+func (s *PVZServer) UpdatePVZ(ctx context.Context, req *pvz_v1.UpdatePVZRequest) (*pvz_v1.PVZ, error) {
+	if role := roleFromContext(ctx); role != dto.RoleModerator {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return nil, errForbidden
+	}
+
+	if err := validateUpdatePVZRequest(req); err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid update request: %v", err)
+		return nil, errInvalidData
+	}
+
+	pvz, err := s.service.UpdatePVZ(ctx, req.GetPvzId(), models.PVZUpdate{
+		Name:         req.Name,
+		Address:      req.Address,
+		WorkingHours: req.WorkingHours,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		Timezone:     req.Timezone,
+	})
+	if err != nil {
+		return nil, s.pvzStatusError(ctx, err)
+	}
+
+	return pvzToProto(pvz), nil
+}
+
+func (s *PVZServer) ActivatePVZ(ctx context.Context, req *pvz_v1.PVZIdRequest) (*pvz_v1.PVZ, error) {
+	return s.changePVZStatus(ctx, req.GetPvzId(), models.PVZActive)
+}
+
+func (s *PVZServer) DeactivatePVZ(ctx context.Context, req *pvz_v1.PVZIdRequest) (*pvz_v1.PVZ, error) {
+	return s.changePVZStatus(ctx, req.GetPvzId(), models.PVZInactive)
+}
+
+func (s *PVZServer) ArchivePVZ(ctx context.Context, req *pvz_v1.PVZIdRequest) (*pvz_v1.PVZ, error) {
+	return s.changePVZStatus(ctx, req.GetPvzId(), models.PVZArchived)
+}
+
+func (s *PVZServer) changePVZStatus(ctx context.Context, pvzId, pvzStatus string) (*pvz_v1.PVZ, error) {
+	if role := roleFromContext(ctx); role != dto.RoleModerator {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return nil, errForbidden
+	}
+
+	pvz, err := s.service.ChangePVZStatus(ctx, pvzId, pvzStatus)
+	if err != nil {
+		return nil, s.pvzStatusError(ctx, err)
+	}
+
+	return pvzToProto(pvz), nil
+}
+
+func (s *PVZServer) pvzStatusError(ctx context.Context, err error) error {
+	logger.FromContext(ctx, s.logger).Errorf("failed to update pvz: %v", err)
+	switch {
+	case errors.Is(err, dto.ErrPVZNotFound):
+		return errPVZNotFound
+	case errors.Is(err, dto.ErrPVZNotActive):
+		return status.Error(codes.FailedPrecondition, "ПВЗ в архиве")
+	case errors.Is(err, dto.ErrInvalidPVZStatusChange):
+		return status.Error(codes.FailedPrecondition, "Недопустимая смена статуса ПВЗ")
+	default:
+		return errInternal
+	}
+}
+
+func (s *PVZServer) CloseLastReception(ctx context.Context, req *pvz_v1.PVZIdRequest) (*pvz_v1.Reception, error) {
+	if role := roleFromContext(ctx); role != dto.RoleEmployee {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return nil, errForbidden
+	}
+
+	reception, err := s.service.CloseLastReception(ctx, req.GetPvzId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to close last reception: %v", err)
+		switch {
+		case errors.Is(err, dto.ErrPVZNotFound):
+			return nil, status.Error(codes.InvalidArgument, "ПВЗ не найден")
+		case errors.Is(err, dto.ErrNoActiveReception):
+			return nil, status.Error(codes.FailedPrecondition, "Приемка уже закрыта")
+		default:
+			return nil, errInternal
+		}
+	}
+
+	return receptionToProto(reception), nil
+}
+
+func (s *PVZServer) DeleteLastProduct(ctx context.Context, req *pvz_v1.PVZIdRequest) (*emptypb.Empty, error) {
+	if role := roleFromContext(ctx); role != dto.RoleEmployee {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return nil, errForbidden
+	}
+
+	err := s.service.DeleteLastProduct(ctx, req.GetPvzId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to delete last product: %v", err)
+		switch {
+		case errors.Is(err, dto.ErrPVZNotFound):
+			return nil, status.Error(codes.InvalidArgument, "ПВЗ не найден")
+		case errors.Is(err, dto.ErrNoActiveReception):
+			return nil, status.Error(codes.FailedPrecondition, "Нет активной приемки")
+		case errors.Is(err, dto.ErrNoProductsInReception):
+			return nil, status.Error(codes.FailedPrecondition, "Нет товаров для удаления")
+		default:
+			return nil, errInternal
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,226 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/grpc/mocks"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func withRole(role string) context.Context {
+	return context.WithValue(context.Background(), middlewares.Key("props"), jwt.MapClaims{"role": role})
+}
+
+func TestCreatePVZ_Forbidden(t *testing.T) {
+	server := NewPVZServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.CreatePVZ(withRole(dto.RoleEmployee), &pvz_v1.CreatePVZRequest{City: dto.Moscow})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCreatePVZ_InvalidCity(t *testing.T) {
+	server := NewPVZServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.CreatePVZ(withRole(dto.RoleModerator), &pvz_v1.CreatePVZRequest{City: "Berlin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCreatePVZ_ServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().CreatePVZ(gomock.Any(), dto.Moscow).Return(models.PVZ{}, errors.New("db error"))
+
+	_, err := server.CreatePVZ(withRole(dto.RoleModerator), &pvz_v1.CreatePVZRequest{City: dto.Moscow})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestCreatePVZ_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+	registered := time.Date(2025, 4, 11, 10, 0, 0, 0, time.UTC)
+
+	service.EXPECT().CreatePVZ(gomock.Any(), dto.Kazan).
+		Return(models.PVZ{Id: "123", City: dto.Kazan, RegistrationDate: registered.Format(time.RFC3339Nano)}, nil)
+
+	resp, err := server.CreatePVZ(withRole(dto.RoleModerator), &pvz_v1.CreatePVZRequest{City: dto.Kazan})
+	assert.NoError(t, err)
+	assert.Equal(t, "123", resp.GetId())
+	assert.True(t, registered.Equal(resp.GetRegistrationDate().AsTime()))
+}
+
+func TestGetPVZ_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().GetPVZ(gomock.Any(), "pvz404").Return(models.PVZ{}, dto.ErrPVZNotFound)
+
+	_, err := server.GetPVZ(context.Background(), &pvz_v1.PVZIdRequest{PvzId: "pvz404"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestUpdatePVZ_Forbidden(t *testing.T) {
+	server := NewPVZServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.UpdatePVZ(withRole(dto.RoleEmployee), &pvz_v1.UpdatePVZRequest{PvzId: "pvz1", Name: proto.String("ПВЗ")})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestUpdatePVZ_InvalidRequest(t *testing.T) {
+	server := NewPVZServer(nil, zaptest.NewLogger(t).Sugar())
+	requests := map[string]*pvz_v1.UpdatePVZRequest{
+		"empty":                  {PvzId: "pvz1"},
+		"incomplete coordinates": {PvzId: "pvz1", Latitude: proto.Float64(55.75)},
+		"invalid timezone":       {PvzId: "pvz1", Timezone: proto.String("Mars/Olympus")},
+	}
+
+	for name, req := range requests {
+		t.Run(name, func(t *testing.T) {
+			_, err := server.UpdatePVZ(withRole(dto.RoleModerator), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestUpdatePVZ_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+	hours := "10:00-22:00"
+
+	service.EXPECT().UpdatePVZ(gomock.Any(), "pvz1", models.PVZUpdate{WorkingHours: &hours}).
+		Return(models.PVZ{Id: "pvz1", WorkingHours: hours}, nil)
+
+	resp, err := server.UpdatePVZ(withRole(dto.RoleModerator), &pvz_v1.UpdatePVZRequest{PvzId: "pvz1", WorkingHours: &hours})
+	assert.NoError(t, err)
+	assert.Equal(t, hours, resp.GetWorkingHours())
+}
+
+func TestArchivePVZ_InvalidTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().ChangePVZStatus(gomock.Any(), "pvz1", models.PVZArchived).Return(models.PVZ{}, dto.ErrInvalidPVZStatusChange)
+
+	_, err := server.ArchivePVZ(withRole(dto.RoleModerator), &pvz_v1.PVZIdRequest{PvzId: "pvz1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestGetNearbyPVZs_InvalidCoordinates(t *testing.T) {
+	server := NewPVZServer(nil, zaptest.NewLogger(t).Sugar())
+	requests := map[string]*pvz_v1.GetNearbyPVZsRequest{
+		"out of range": {Latitude: proto.Float64(95), Longitude: proto.Float64(37.62)},
+		"missing lon":  {Latitude: proto.Float64(55.75)},
+	}
+
+	for name, req := range requests {
+		t.Run(name, func(t *testing.T) {
+			_, err := server.GetNearbyPVZs(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestGetNearbyPVZs_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+	lat, lon := 55.751, 37.621
+
+	service.EXPECT().GetNearbyPVZs(gomock.Any(), 55.75, 37.62, 2000.0, 10).
+		Return([]models.PVZWithDistance{
+			{PVZ: models.PVZ{Id: "pvz1", Latitude: &lat, Longitude: &lon}, Distance: 130.5},
+		}, nil)
+
+	resp, err := server.GetNearbyPVZs(context.Background(), &pvz_v1.GetNearbyPVZsRequest{
+		Latitude:     proto.Float64(55.75),
+		Longitude:    proto.Float64(37.62),
+		RadiusMeters: proto.Float64(2000),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetPvzs(), 1)
+	assert.Equal(t, "pvz1", resp.GetPvzs()[0].GetPvz().GetId())
+	assert.Equal(t, 130.5, resp.GetPvzs()[0].GetDistanceMeters())
+}
+
+func TestCloseLastReception_Forbidden(t *testing.T) {
+	server := NewPVZServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.CloseLastReception(withRole(dto.RoleModerator), &pvz_v1.PVZIdRequest{PvzId: "pvz1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCloseLastReception_ErrNoActiveReception(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().CloseLastReception(gomock.Any(), "pvz1").Return(models.Reception{}, dto.ErrNoActiveReception)
+
+	_, err := server.CloseLastReception(withRole(dto.RoleEmployee), &pvz_v1.PVZIdRequest{PvzId: "pvz1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestCloseLastReception_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+
+	rec := models.Reception{Id: "rec1", PVZId: "pvz1", Status: models.CLOSE}
+	service.EXPECT().CloseLastReception(gomock.Any(), "pvz1").Return(rec, nil)
+
+	resp, err := server.CloseLastReception(withRole(dto.RoleEmployee), &pvz_v1.PVZIdRequest{PvzId: "pvz1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rec1", resp.GetId())
+}
+
+func TestDeleteLastProduct_Forbidden(t *testing.T) {
+	server := NewPVZServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.DeleteLastProduct(withRole(dto.RoleModerator), &pvz_v1.PVZIdRequest{PvzId: "pvz1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestDeleteLastProduct_ErrNoProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().DeleteLastProduct(gomock.Any(), "pvz1").Return(dto.ErrNoProductsInReception)
+
+	_, err := server.DeleteLastProduct(withRole(dto.RoleEmployee), &pvz_v1.PVZIdRequest{PvzId: "pvz1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestDeleteLastProduct_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	server := NewPVZServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().DeleteLastProduct(gomock.Any(), "pvz1").Return(nil)
+
+	_, err := server.DeleteLastProduct(withRole(dto.RoleEmployee), &pvz_v1.PVZIdRequest{PvzId: "pvz1"})
+	assert.NoError(t, err)
+}
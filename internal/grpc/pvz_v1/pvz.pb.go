@@ -7,8 +7,12 @@
 package pvz_v1
 
 import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -68,30 +72,2196 @@ func (ReceptionStatus) EnumDescriptor() ([]byte, []int) {
 	return file_pvz_proto_rawDescGZIP(), []int{0}
 }
 
+// Информация об ошибке
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Текст ошибки
+	Message       string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_pvz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type User struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Почта
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// Роль пользователя (employee || moderator)
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// Отключен ли пользователь
+	Disabled bool `protobuf:"varint,4,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// Дата регистрации
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_pvz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type DummyLoginRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Желаемая роль (employee || moderator)
+	Role          string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DummyLoginRequest) Reset() {
+	*x = DummyLoginRequest{}
+	mi := &file_pvz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DummyLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DummyLoginRequest) ProtoMessage() {}
+
+func (x *DummyLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DummyLoginRequest.ProtoReflect.Descriptor instead.
+func (*DummyLoginRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DummyLoginRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type RegisterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Почта
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Пароль
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// Роль пользователя (только employee, по умолчанию employee)
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_pvz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type LoginRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Почта
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Пароль
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_pvz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type UserIdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор пользователя
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserIdRequest) Reset() {
+	*x = UserIdRequest{}
+	mi := &file_pvz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserIdRequest) ProtoMessage() {}
+
+func (x *UserIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserIdRequest.ProtoReflect.Descriptor instead.
+func (*UserIdRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UserIdRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Роль пользователя (employee || moderator)
+	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	// Только отключенные (true) или только активные (false) пользователи
+	Disabled *bool `protobuf:"varint,2,opt,name=disabled,proto3,oneof" json:"disabled,omitempty"`
+	// Номер страницы (по умолчанию 1)
+	Page *int32 `protobuf:"varint,3,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	// Количество элементов на странице (по умолчанию 10, максимум 30)
+	Limit         *int32 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_pvz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetDisabled() bool {
+	if x != nil && x.Disabled != nil {
+		return *x.Disabled
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пользователи на странице
+	Items []*User `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Номер страницы
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Количество элементов на странице
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Общее количество пользователей, подходящих под фильтр
+	Total int32 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Есть ли следующая страница
+	HasNext       bool `protobuf:"varint,5,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_pvz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListUsersResponse) GetItems() []*User {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type CreateUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Почта
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Пароль
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// Роль пользователя (employee || moderator)
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_pvz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ChangeUserRoleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор пользователя
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Новая роль (employee || moderator)
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeUserRoleRequest) Reset() {
+	*x = ChangeUserRoleRequest{}
+	mi := &file_pvz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeUserRoleRequest) ProtoMessage() {}
+
+func (x *ChangeUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*ChangeUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ChangeUserRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChangeUserRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ChangePasswordRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Текущий пароль
+	OldPassword string `protobuf:"bytes,1,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	// Новый пароль
+	NewPassword   string `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_pvz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type RequestPasswordResetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Почта
+	Email         string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_pvz_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResetPasswordRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Токен из письма
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Новый пароль
+	NewPassword   string `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_pvz_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JWT токен
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_pvz_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LoginResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
 type PVZ struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Дата регистрации
+	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
+	// Город
+	City string `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	// Адрес
+	Address *string `protobuf:"bytes,4,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	// Широта
+	Latitude *float64 `protobuf:"fixed64,5,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	// Долгота
+	Longitude *float64 `protobuf:"fixed64,6,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	// Часовой пояс (IANA, например Europe/Moscow)
+	Timezone *string `protobuf:"bytes,7,opt,name=timezone,proto3,oneof" json:"timezone,omitempty"`
+	// Название
+	Name *string `protobuf:"bytes,8,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Часы работы
+	WorkingHours *string `protobuf:"bytes,9,opt,name=working_hours,json=workingHours,proto3,oneof" json:"working_hours,omitempty"`
+	// Статус (active || inactive || archived)
+	Status        *string `protobuf:"bytes,10,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PVZ) Reset() {
+	*x = PVZ{}
+	mi := &file_pvz_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PVZ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PVZ) ProtoMessage() {}
+
+func (x *PVZ) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PVZ.ProtoReflect.Descriptor instead.
+func (*PVZ) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PVZ) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PVZ) GetRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegistrationDate
+	}
+	return nil
+}
+
+func (x *PVZ) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *PVZ) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *PVZ) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *PVZ) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *PVZ) GetTimezone() string {
+	if x != nil && x.Timezone != nil {
+		return *x.Timezone
+	}
+	return ""
+}
+
+func (x *PVZ) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *PVZ) GetWorkingHours() string {
+	if x != nil && x.WorkingHours != nil {
+		return *x.WorkingHours
+	}
+	return ""
+}
+
+func (x *PVZ) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+type Reception struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор приемки
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Дата и время создания приемки
+	DateTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	// Идентификатор ПВЗ
+	PvzId string `protobuf:"bytes,3,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	// Статус приемки
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reception) Reset() {
+	*x = Reception{}
+	mi := &file_pvz_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reception) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reception) ProtoMessage() {}
+
+func (x *Reception) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reception.ProtoReflect.Descriptor instead.
+func (*Reception) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Reception) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reception) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Reception) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *Reception) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Product struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Дата и время
+	DateTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	// Тип товара
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Идентификатор приемки
+	ReceptionId   string `protobuf:"bytes,4,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_pvz_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Product) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Product) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+type PVZIdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор ПВЗ
+	PvzId         string `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PVZIdRequest) Reset() {
+	*x = PVZIdRequest{}
+	mi := &file_pvz_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PVZIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PVZIdRequest) ProtoMessage() {}
+
+func (x *PVZIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PVZIdRequest.ProtoReflect.Descriptor instead.
+func (*PVZIdRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PVZIdRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type GetPVZListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPVZListRequest) Reset() {
+	*x = GetPVZListRequest{}
+	mi := &file_pvz_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPVZListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPVZListRequest) ProtoMessage() {}
+
+func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPVZListRequest.ProtoReflect.Descriptor instead.
+func (*GetPVZListRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{18}
+}
+
+type GetPVZListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvzs          []*PVZ                 `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPVZListResponse) Reset() {
+	*x = GetPVZListResponse{}
+	mi := &file_pvz_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPVZListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPVZListResponse) ProtoMessage() {}
+
+func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
+func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPVZListResponse) GetPvzs() []*PVZ {
+	if x != nil {
+		return x.Pvzs
+	}
+	return nil
+}
+
+type CreatePVZRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Город
+	City          string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePVZRequest) Reset() {
+	*x = CreatePVZRequest{}
+	mi := &file_pvz_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePVZRequest) ProtoMessage() {}
+
+func (x *CreatePVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePVZRequest.ProtoReflect.Descriptor instead.
+func (*CreatePVZRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreatePVZRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type CreatePVZResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Дата регистрации
 	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
-	City             string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Город
+	City          string `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePVZResponse) Reset() {
+	*x = CreatePVZResponse{}
+	mi := &file_pvz_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePVZResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePVZResponse) ProtoMessage() {}
+
+func (x *CreatePVZResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePVZResponse.ProtoReflect.Descriptor instead.
+func (*CreatePVZResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreatePVZResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreatePVZResponse) GetRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegistrationDate
+	}
+	return nil
+}
+
+func (x *CreatePVZResponse) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type ListPVZRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Начальная дата (RFC3339)
+	StartDate string `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Конечная дата (RFC3339)
+	EndDate string `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Номер страницы (по умолчанию 1)
+	Page *int32 `protobuf:"varint,3,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	// Количество элементов на странице (по умолчанию 10, максимум 30)
+	Limit *int32 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Показывать архивные ПВЗ (по умолчанию false)
+	IncludeArchived bool `protobuf:"varint,5,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	// Города
+	City []string `protobuf:"bytes,6,rep,name=city,proto3" json:"city,omitempty"`
+	// Идентификаторы ПВЗ
+	PvzId []string `protobuf:"bytes,7,rep,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	// Статусы приемок
+	ReceptionStatus []string `protobuf:"bytes,8,rep,name=reception_status,json=receptionStatus,proto3" json:"reception_status,omitempty"`
+	// Типы товаров
+	ProductType []string `protobuf:"bytes,9,rep,name=product_type,json=productType,proto3" json:"product_type,omitempty"`
+	// Есть ли на ПВЗ незакрытая приемка
+	HasOpenReception *bool `protobuf:"varint,10,opt,name=has_open_reception,json=hasOpenReception,proto3,oneof" json:"has_open_reception,omitempty"`
+	// Поле сортировки (registrationDate || lastActivity || productCount, по умолчанию registrationDate)
+	SortBy string `protobuf:"bytes,11,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// Направление сортировки (asc || desc, по умолчанию desc)
+	SortOrder string `protobuf:"bytes,12,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	// Детализация: pvz (только ПВЗ) || receptions (ПВЗ и приемки с количеством товаров) || products (полное дерево, по умолчанию)
+	Expand string `protobuf:"bytes,13,opt,name=expand,proto3" json:"expand,omitempty"`
+	// Вернуть ответ-конверт с метаданными пагинации (только REST)
+	Envelope      bool `protobuf:"varint,14,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPVZRequest) Reset() {
+	*x = ListPVZRequest{}
+	mi := &file_pvz_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZRequest) ProtoMessage() {}
+
+func (x *ListPVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZRequest.ProtoReflect.Descriptor instead.
+func (*ListPVZRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPVZRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ListPVZRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ListPVZRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *ListPVZRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListPVZRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+func (x *ListPVZRequest) GetCity() []string {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
+func (x *ListPVZRequest) GetPvzId() []string {
+	if x != nil {
+		return x.PvzId
+	}
+	return nil
+}
+
+func (x *ListPVZRequest) GetReceptionStatus() []string {
+	if x != nil {
+		return x.ReceptionStatus
+	}
+	return nil
+}
+
+func (x *ListPVZRequest) GetProductType() []string {
+	if x != nil {
+		return x.ProductType
+	}
+	return nil
+}
+
+func (x *ListPVZRequest) GetHasOpenReception() bool {
+	if x != nil && x.HasOpenReception != nil {
+		return *x.HasOpenReception
+	}
+	return false
+}
+
+func (x *ListPVZRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListPVZRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListPVZRequest) GetExpand() string {
+	if x != nil {
+		return x.Expand
+	}
+	return ""
+}
+
+func (x *ListPVZRequest) GetEnvelope() bool {
+	if x != nil {
+		return x.Envelope
+	}
+	return false
+}
+
+type ReceptionWithProducts struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Информация о приемке
+	Reception *Reception `protobuf:"bytes,1,opt,name=reception,proto3" json:"reception,omitempty"`
+	// Информация о товарах в приемке (при expand=products)
+	Products []*Product `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	// Количество товаров в приемке (при expand=receptions)
+	ProductCount  *int32 `protobuf:"varint,3,opt,name=product_count,json=productCount,proto3,oneof" json:"product_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceptionWithProducts) Reset() {
+	*x = ReceptionWithProducts{}
+	mi := &file_pvz_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceptionWithProducts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceptionWithProducts) ProtoMessage() {}
+
+func (x *ReceptionWithProducts) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceptionWithProducts.ProtoReflect.Descriptor instead.
+func (*ReceptionWithProducts) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReceptionWithProducts) GetReception() *Reception {
+	if x != nil {
+		return x.Reception
+	}
+	return nil
+}
+
+func (x *ReceptionWithProducts) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ReceptionWithProducts) GetProductCount() int32 {
+	if x != nil && x.ProductCount != nil {
+		return *x.ProductCount
+	}
+	return 0
+}
+
+type PVZWithReceptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Информация о ПВЗ
+	Pvz *PVZ `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	// Информация о приемках на ПВЗ (кроме expand=pvz)
+	Receptions    []*ReceptionWithProducts `protobuf:"bytes,2,rep,name=receptions,proto3" json:"receptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PVZWithReceptions) Reset() {
+	*x = PVZWithReceptions{}
+	mi := &file_pvz_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PVZWithReceptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PVZWithReceptions) ProtoMessage() {}
+
+func (x *PVZWithReceptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PVZWithReceptions.ProtoReflect.Descriptor instead.
+func (*PVZWithReceptions) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PVZWithReceptions) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *PVZWithReceptions) GetReceptions() []*ReceptionWithProducts {
+	if x != nil {
+		return x.Receptions
+	}
+	return nil
+}
+
+type ListPVZResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ПВЗ на странице
+	Items []*PVZWithReceptions `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Номер страницы
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Количество элементов на странице
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Общее количество ПВЗ, подходящих под фильтр
+	Total int32 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Есть ли следующая страница
+	HasNext       bool `protobuf:"varint,5,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPVZResponse) Reset() {
+	*x = ListPVZResponse{}
+	mi := &file_pvz_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZResponse) ProtoMessage() {}
+
+func (x *ListPVZResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZResponse.ProtoReflect.Descriptor instead.
+func (*ListPVZResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListPVZResponse) GetItems() []*PVZWithReceptions {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListPVZResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPVZResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPVZResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPVZResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type GetNearbyPVZsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Широта точки
+	Latitude *float64 `protobuf:"fixed64,1,opt,name=latitude,json=lat,proto3,oneof" json:"latitude,omitempty"`
+	// Долгота точки
+	Longitude *float64 `protobuf:"fixed64,2,opt,name=longitude,json=lon,proto3,oneof" json:"longitude,omitempty"`
+	// Радиус поиска в метрах (по умолчанию 5000, максимум 100000)
+	RadiusMeters *float64 `protobuf:"fixed64,3,opt,name=radius_meters,json=radius,proto3,oneof" json:"radius_meters,omitempty"`
+	// Максимальное количество ПВЗ (по умолчанию 10, максимум 30)
+	Limit         *int32 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNearbyPVZsRequest) Reset() {
+	*x = GetNearbyPVZsRequest{}
+	mi := &file_pvz_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNearbyPVZsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNearbyPVZsRequest) ProtoMessage() {}
+
+func (x *GetNearbyPVZsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNearbyPVZsRequest.ProtoReflect.Descriptor instead.
+func (*GetNearbyPVZsRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetNearbyPVZsRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *GetNearbyPVZsRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *GetNearbyPVZsRequest) GetRadiusMeters() float64 {
+	if x != nil && x.RadiusMeters != nil {
+		return *x.RadiusMeters
+	}
+	return 0
+}
+
+func (x *GetNearbyPVZsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type NearbyPVZ struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Информация о ПВЗ
+	Pvz *PVZ `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	// Расстояние до ПВЗ в метрах
+	DistanceMeters float64 `protobuf:"fixed64,2,opt,name=distance_meters,json=distance,proto3" json:"distance_meters,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NearbyPVZ) Reset() {
+	*x = NearbyPVZ{}
+	mi := &file_pvz_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyPVZ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyPVZ) ProtoMessage() {}
+
+func (x *NearbyPVZ) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyPVZ.ProtoReflect.Descriptor instead.
+func (*NearbyPVZ) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *NearbyPVZ) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *NearbyPVZ) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+type GetNearbyPVZsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvzs          []*NearbyPVZ           `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNearbyPVZsResponse) Reset() {
+	*x = GetNearbyPVZsResponse{}
+	mi := &file_pvz_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNearbyPVZsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNearbyPVZsResponse) ProtoMessage() {}
+
+func (x *GetNearbyPVZsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNearbyPVZsResponse.ProtoReflect.Descriptor instead.
+func (*GetNearbyPVZsResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetNearbyPVZsResponse) GetPvzs() []*NearbyPVZ {
+	if x != nil {
+		return x.Pvzs
+	}
+	return nil
+}
+
+type UpdatePVZRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор ПВЗ
+	PvzId string `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	// Название
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Адрес
+	Address *string `protobuf:"bytes,3,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	// Часы работы
+	WorkingHours *string `protobuf:"bytes,4,opt,name=working_hours,json=workingHours,proto3,oneof" json:"working_hours,omitempty"`
+	// Широта (передается вместе с долготой)
+	Latitude *float64 `protobuf:"fixed64,5,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	// Долгота (передается вместе с широтой)
+	Longitude *float64 `protobuf:"fixed64,6,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	// Часовой пояс (IANA, например Europe/Moscow)
+	Timezone      *string `protobuf:"bytes,7,opt,name=timezone,proto3,oneof" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePVZRequest) Reset() {
+	*x = UpdatePVZRequest{}
+	mi := &file_pvz_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePVZRequest) ProtoMessage() {}
+
+func (x *UpdatePVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePVZRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePVZRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdatePVZRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *UpdatePVZRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePVZRequest) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *UpdatePVZRequest) GetWorkingHours() string {
+	if x != nil && x.WorkingHours != nil {
+		return *x.WorkingHours
+	}
+	return ""
+}
+
+func (x *UpdatePVZRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdatePVZRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *UpdatePVZRequest) GetTimezone() string {
+	if x != nil && x.Timezone != nil {
+		return *x.Timezone
+	}
+	return ""
+}
+
+type CreateReceptionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор ПВЗ
+	PvzId         string `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReceptionRequest) Reset() {
+	*x = CreateReceptionRequest{}
+	mi := &file_pvz_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReceptionRequest) ProtoMessage() {}
+
+func (x *CreateReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReceptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type ReceptionIdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор приемки
+	ReceptionId   string `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceptionIdRequest) Reset() {
+	*x = ReceptionIdRequest{}
+	mi := &file_pvz_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceptionIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceptionIdRequest) ProtoMessage() {}
+
+func (x *ReceptionIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceptionIdRequest.ProtoReflect.Descriptor instead.
+func (*ReceptionIdRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReceptionIdRequest) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+type ChangeReceptionStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор приемки
+	ReceptionId string `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	// Причина смены статуса (необязательно)
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeReceptionStatusRequest) Reset() {
+	*x = ChangeReceptionStatusRequest{}
+	mi := &file_pvz_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeReceptionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeReceptionStatusRequest) ProtoMessage() {}
+
+func (x *ChangeReceptionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeReceptionStatusRequest.ProtoReflect.Descriptor instead.
+func (*ChangeReceptionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ChangeReceptionStatusRequest) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *ChangeReceptionStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReceptionStatusChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор приемки
+	ReceptionId string `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	// Предыдущий статус
+	FromStatus *string `protobuf:"bytes,2,opt,name=from_status,json=fromStatus,proto3,oneof" json:"from_status,omitempty"`
+	// Новый статус
+	ToStatus string `protobuf:"bytes,3,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	// Дата и время смены статуса
+	ChangedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	// Роль инициатора (employee || moderator || system)
+	ChangedByRole string `protobuf:"bytes,5,opt,name=changed_by_role,json=changedByRole,proto3" json:"changed_by_role,omitempty"`
+	// Причина смены статуса
+	Reason        *string `protobuf:"bytes,6,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceptionStatusChange) Reset() {
+	*x = ReceptionStatusChange{}
+	mi := &file_pvz_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceptionStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceptionStatusChange) ProtoMessage() {}
+
+func (x *ReceptionStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceptionStatusChange.ProtoReflect.Descriptor instead.
+func (*ReceptionStatusChange) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReceptionStatusChange) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *ReceptionStatusChange) GetFromStatus() string {
+	if x != nil && x.FromStatus != nil {
+		return *x.FromStatus
+	}
+	return ""
+}
+
+func (x *ReceptionStatusChange) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *ReceptionStatusChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *ReceptionStatusChange) GetChangedByRole() string {
+	if x != nil {
+		return x.ChangedByRole
+	}
+	return ""
 }
 
-func (x *PVZ) Reset() {
-	*x = PVZ{}
-	mi := &file_pvz_proto_msgTypes[0]
+func (x *ReceptionStatusChange) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+type GetReceptionStatusHistoryResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Changes       []*ReceptionStatusChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceptionStatusHistoryResponse) Reset() {
+	*x = GetReceptionStatusHistoryResponse{}
+	mi := &file_pvz_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PVZ) String() string {
+func (x *GetReceptionStatusHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PVZ) ProtoMessage() {}
+func (*GetReceptionStatusHistoryResponse) ProtoMessage() {}
 
-func (x *PVZ) ProtoReflect() protoreflect.Message {
-	mi := &file_pvz_proto_msgTypes[0]
+func (x *GetReceptionStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -102,53 +2272,130 @@ func (x *PVZ) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PVZ.ProtoReflect.Descriptor instead.
-func (*PVZ) Descriptor() ([]byte, []int) {
-	return file_pvz_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use GetReceptionStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetReceptionStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *PVZ) GetId() string {
+func (x *GetReceptionStatusHistoryResponse) GetChanges() []*ReceptionStatusChange {
 	if x != nil {
-		return x.Id
+		return x.Changes
+	}
+	return nil
+}
+
+type ListPVZReceptionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор ПВЗ
+	PvzId string `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	// Статусы приемок
+	Status []string `protobuf:"bytes,2,rep,name=status,proto3" json:"status,omitempty"`
+	// Начальная дата (RFC3339)
+	StartDate string `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Конечная дата (RFC3339)
+	EndDate string `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Номер страницы (по умолчанию 1)
+	Page *int32 `protobuf:"varint,5,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	// Количество элементов на странице (по умолчанию 10, максимум 30)
+	Limit         *int32 `protobuf:"varint,6,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPVZReceptionsRequest) Reset() {
+	*x = ListPVZReceptionsRequest{}
+	mi := &file_pvz_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZReceptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZReceptionsRequest) ProtoMessage() {}
+
+func (x *ListPVZReceptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZReceptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPVZReceptionsRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListPVZReceptionsRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
 	}
 	return ""
 }
 
-func (x *PVZ) GetRegistrationDate() *timestamppb.Timestamp {
+func (x *ListPVZReceptionsRequest) GetStatus() []string {
 	if x != nil {
-		return x.RegistrationDate
+		return x.Status
 	}
 	return nil
 }
 
-func (x *PVZ) GetCity() string {
+func (x *ListPVZReceptionsRequest) GetStartDate() string {
 	if x != nil {
-		return x.City
+		return x.StartDate
 	}
 	return ""
 }
 
-type GetPVZListRequest struct {
+func (x *ListPVZReceptionsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ListPVZReceptionsRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *ListPVZReceptionsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListPVZReceptionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receptions    []*Reception           `protobuf:"bytes,1,rep,name=receptions,proto3" json:"receptions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPVZListRequest) Reset() {
-	*x = GetPVZListRequest{}
-	mi := &file_pvz_proto_msgTypes[1]
+func (x *ListPVZReceptionsResponse) Reset() {
+	*x = ListPVZReceptionsResponse{}
+	mi := &file_pvz_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPVZListRequest) String() string {
+func (x *ListPVZReceptionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPVZListRequest) ProtoMessage() {}
+func (*ListPVZReceptionsResponse) ProtoMessage() {}
 
-func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pvz_proto_msgTypes[1]
+func (x *ListPVZReceptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,33 +2406,49 @@ func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPVZListRequest.ProtoReflect.Descriptor instead.
-func (*GetPVZListRequest) Descriptor() ([]byte, []int) {
-	return file_pvz_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListPVZReceptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPVZReceptionsResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{36}
 }
 
-type GetPVZListResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pvzs          []*PVZ                 `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+func (x *ListPVZReceptionsResponse) GetReceptions() []*Reception {
+	if x != nil {
+		return x.Receptions
+	}
+	return nil
+}
+
+type ExportReceptionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Формат выгрузки (csv || xlsx, по умолчанию csv)
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// Начальная дата (RFC3339)
+	StartDate string `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Конечная дата (RFC3339)
+	EndDate string `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Города
+	City []string `protobuf:"bytes,4,rep,name=city,proto3" json:"city,omitempty"`
+	// Идентификаторы ПВЗ
+	PvzId         []string `protobuf:"bytes,5,rep,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPVZListResponse) Reset() {
-	*x = GetPVZListResponse{}
-	mi := &file_pvz_proto_msgTypes[2]
+func (x *ExportReceptionsRequest) Reset() {
+	*x = ExportReceptionsRequest{}
+	mi := &file_pvz_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPVZListResponse) String() string {
+func (x *ExportReceptionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPVZListResponse) ProtoMessage() {}
+func (*ExportReceptionsRequest) ProtoMessage() {}
 
-func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pvz_proto_msgTypes[2]
+func (x *ExportReceptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -196,37 +2459,565 @@ func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
-func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
-	return file_pvz_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ExportReceptionsRequest.ProtoReflect.Descriptor instead.
+func (*ExportReceptionsRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetPVZListResponse) GetPvzs() []*PVZ {
+func (x *ExportReceptionsRequest) GetFormat() string {
 	if x != nil {
-		return x.Pvzs
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportReceptionsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ExportReceptionsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ExportReceptionsRequest) GetCity() []string {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
+func (x *ExportReceptionsRequest) GetPvzId() []string {
+	if x != nil {
+		return x.PvzId
+	}
+	return nil
+}
+
+type AddProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Тип товара
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Идентификатор ПВЗ, на который добавляется товар
+	PvzId         string `protobuf:"bytes,2,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductRequest) Reset() {
+	*x = AddProductRequest{}
+	mi := &file_pvz_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductRequest) ProtoMessage() {}
+
+func (x *AddProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductRequest.ProtoReflect.Descriptor instead.
+func (*AddProductRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AddProductRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type ListReceptionProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Идентификатор приемки
+	ReceptionId string `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	// Типы товаров
+	Type []string `protobuf:"bytes,2,rep,name=type,proto3" json:"type,omitempty"`
+	// Порядок по дате добавления (asc || desc, по умолчанию asc)
+	Order string `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	// Количество товаров на странице (по умолчанию 20, максимум 100)
+	Limit *int32 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Курсор следующей страницы из предыдущего ответа
+	Cursor        string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReceptionProductsRequest) Reset() {
+	*x = ListReceptionProductsRequest{}
+	mi := &file_pvz_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReceptionProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReceptionProductsRequest) ProtoMessage() {}
+
+func (x *ListReceptionProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReceptionProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListReceptionProductsRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListReceptionProductsRequest) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *ListReceptionProductsRequest) GetType() []string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *ListReceptionProductsRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListReceptionProductsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListReceptionProductsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListReceptionProductsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Товары на странице
+	Items []*Product `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Курсор следующей страницы (отсутствует на последней странице)
+	NextCursor    *string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReceptionProductsResponse) Reset() {
+	*x = ListReceptionProductsResponse{}
+	mi := &file_pvz_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReceptionProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReceptionProductsResponse) ProtoMessage() {}
+
+func (x *ListReceptionProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReceptionProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListReceptionProductsResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListReceptionProductsResponse) GetItems() []*Product {
+	if x != nil {
+		return x.Items
 	}
 	return nil
 }
 
+func (x *ListReceptionProductsResponse) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
 var File_pvz_proto protoreflect.FileDescriptor
 
 const file_pvz_proto_rawDesc = "" +
 	"\n" +
-	"\tpvz.proto\x12\x06pvz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"r\n" +
+	"\tpvz.proto\x12\x06pvz.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"!\n" +
+	"\x05Error\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x97\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1a\n" +
+	"\bdisabled\x18\x04 \x01(\bR\bdisabled\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"'\n" +
+	"\x11DummyLoginRequest\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\"W\n" +
+	"\x0fRegisterRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"@\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"(\n" +
+	"\rUserIdRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x9b\x01\n" +
+	"\x10ListUsersRequest\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x1f\n" +
+	"\bdisabled\x18\x02 \x01(\bH\x00R\bdisabled\x88\x01\x01\x12\x17\n" +
+	"\x04page\x18\x03 \x01(\x05H\x01R\x04page\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x04 \x01(\x05H\x02R\x05limit\x88\x01\x01B\v\n" +
+	"\t_disabledB\a\n" +
+	"\x05_pageB\b\n" +
+	"\x06_limit\"\x92\x01\n" +
+	"\x11ListUsersResponse\x12\"\n" +
+	"\x05items\x18\x01 \x03(\v2\f.pvz.v1.UserR\x05items\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x19\n" +
+	"\bhas_next\x18\x05 \x01(\bR\ahasNext\"Y\n" +
+	"\x11CreateUserRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"D\n" +
+	"\x15ChangeUserRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"]\n" +
+	"\x15ChangePasswordRequest\x12!\n" +
+	"\fold_password\x18\x01 \x01(\tR\voldPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"3\n" +
+	"\x1bRequestPasswordResetRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"O\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"%\n" +
+	"\rLoginResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xb0\x03\n" +
 	"\x03PVZ\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
 	"\x11registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x12\n" +
-	"\x04city\x18\x03 \x01(\tR\x04city\"\x13\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x1d\n" +
+	"\aaddress\x18\x04 \x01(\tH\x00R\aaddress\x88\x01\x01\x12\x1f\n" +
+	"\blatitude\x18\x05 \x01(\x01H\x01R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x06 \x01(\x01H\x02R\tlongitude\x88\x01\x01\x12\x1f\n" +
+	"\btimezone\x18\a \x01(\tH\x03R\btimezone\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\b \x01(\tH\x04R\x04name\x88\x01\x01\x12(\n" +
+	"\rworking_hours\x18\t \x01(\tH\x05R\fworkingHours\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\n" +
+	" \x01(\tH\x06R\x06status\x88\x01\x01B\n" +
+	"\n" +
+	"\b_addressB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\v\n" +
+	"\t_timezoneB\a\n" +
+	"\x05_nameB\x10\n" +
+	"\x0e_working_hoursB\t\n" +
+	"\a_status\"\x83\x01\n" +
+	"\tReception\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12\x15\n" +
+	"\x06pvz_id\x18\x03 \x01(\tR\x05pvzId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\x89\x01\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12!\n" +
+	"\freception_id\x18\x04 \x01(\tR\vreceptionId\"%\n" +
+	"\fPVZIdRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"\x13\n" +
 	"\x11GetPVZListRequest\"5\n" +
 	"\x12GetPVZListResponse\x12\x1f\n" +
-	"\x04pvzs\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x04pvzs*P\n" +
+	"\x04pvzs\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x04pvzs\"&\n" +
+	"\x10CreatePVZRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\"\x80\x01\n" +
+	"\x11CreatePVZResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
+	"\x11registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\"\xeb\x03\n" +
+	"\x0eListPVZRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x17\n" +
+	"\x04page\x18\x03 \x01(\x05H\x00R\x04page\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x04 \x01(\x05H\x01R\x05limit\x88\x01\x01\x12)\n" +
+	"\x10include_archived\x18\x05 \x01(\bR\x0fincludeArchived\x12\x12\n" +
+	"\x04city\x18\x06 \x03(\tR\x04city\x12\x15\n" +
+	"\x06pvz_id\x18\a \x03(\tR\x05pvzId\x12)\n" +
+	"\x10reception_status\x18\b \x03(\tR\x0freceptionStatus\x12!\n" +
+	"\fproduct_type\x18\t \x03(\tR\vproductType\x121\n" +
+	"\x12has_open_reception\x18\n" +
+	" \x01(\bH\x02R\x10hasOpenReception\x88\x01\x01\x12\x17\n" +
+	"\asort_by\x18\v \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\f \x01(\tR\tsortOrder\x12\x16\n" +
+	"\x06expand\x18\r \x01(\tR\x06expand\x12\x1a\n" +
+	"\benvelope\x18\x0e \x01(\bR\benvelopeB\a\n" +
+	"\x05_pageB\b\n" +
+	"\x06_limitB\x15\n" +
+	"\x13_has_open_reception\"\xb1\x01\n" +
+	"\x15ReceptionWithProducts\x12/\n" +
+	"\treception\x18\x01 \x01(\v2\x11.pvz.v1.ReceptionR\treception\x12+\n" +
+	"\bproducts\x18\x02 \x03(\v2\x0f.pvz.v1.ProductR\bproducts\x12(\n" +
+	"\rproduct_count\x18\x03 \x01(\x05H\x00R\fproductCount\x88\x01\x01B\x10\n" +
+	"\x0e_product_count\"q\n" +
+	"\x11PVZWithReceptions\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\x12=\n" +
+	"\n" +
+	"receptions\x18\x02 \x03(\v2\x1d.pvz.v1.ReceptionWithProductsR\n" +
+	"receptions\"\x9d\x01\n" +
+	"\x0fListPVZResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.pvz.v1.PVZWithReceptionsR\x05items\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x19\n" +
+	"\bhas_next\x18\x05 \x01(\bR\ahasNext\"\xc5\x01\n" +
+	"\x14GetNearbyPVZsRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01H\x00R\x03lat\x88\x01\x01\x12\x1b\n" +
+	"\tlongitude\x18\x02 \x01(\x01H\x01R\x03lon\x88\x01\x01\x12\"\n" +
+	"\rradius_meters\x18\x03 \x01(\x01H\x02R\x06radius\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x04 \x01(\x05H\x03R\x05limit\x88\x01\x01B\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\x10\n" +
+	"\x0e_radius_metersB\b\n" +
+	"\x06_limit\"M\n" +
+	"\tNearbyPVZ\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\x12!\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\bdistance\">\n" +
+	"\x15GetNearbyPVZsResponse\x12%\n" +
+	"\x04pvzs\x18\x01 \x03(\v2\x11.pvz.v1.NearbyPVZR\x04pvzs\"\xbf\x02\n" +
+	"\x10UpdatePVZRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1d\n" +
+	"\aaddress\x18\x03 \x01(\tH\x01R\aaddress\x88\x01\x01\x12(\n" +
+	"\rworking_hours\x18\x04 \x01(\tH\x02R\fworkingHours\x88\x01\x01\x12\x1f\n" +
+	"\blatitude\x18\x05 \x01(\x01H\x03R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x06 \x01(\x01H\x04R\tlongitude\x88\x01\x01\x12\x1f\n" +
+	"\btimezone\x18\a \x01(\tH\x05R\btimezone\x88\x01\x01B\a\n" +
+	"\x05_nameB\n" +
+	"\n" +
+	"\b_addressB\x10\n" +
+	"\x0e_working_hoursB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\v\n" +
+	"\t_timezone\"/\n" +
+	"\x16CreateReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"7\n" +
+	"\x12ReceptionIdRequest\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\"Y\n" +
+	"\x1cChangeReceptionStatusRequest\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x98\x02\n" +
+	"\x15ReceptionStatusChange\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\x12$\n" +
+	"\vfrom_status\x18\x02 \x01(\tH\x00R\n" +
+	"fromStatus\x88\x01\x01\x12\x1b\n" +
+	"\tto_status\x18\x03 \x01(\tR\btoStatus\x129\n" +
+	"\n" +
+	"changed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x12&\n" +
+	"\x0fchanged_by_role\x18\x05 \x01(\tR\rchangedByRole\x12\x1b\n" +
+	"\x06reason\x18\x06 \x01(\tH\x01R\x06reason\x88\x01\x01B\x0e\n" +
+	"\f_from_statusB\t\n" +
+	"\a_reason\"\\\n" +
+	"!GetReceptionStatusHistoryResponse\x127\n" +
+	"\achanges\x18\x01 \x03(\v2\x1d.pvz.v1.ReceptionStatusChangeR\achanges\"\xca\x01\n" +
+	"\x18ListPVZReceptionsRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x16\n" +
+	"\x06status\x18\x02 \x03(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x04 \x01(\tR\aendDate\x12\x17\n" +
+	"\x04page\x18\x05 \x01(\x05H\x00R\x04page\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x06 \x01(\x05H\x01R\x05limit\x88\x01\x01B\a\n" +
+	"\x05_pageB\b\n" +
+	"\x06_limit\"N\n" +
+	"\x19ListPVZReceptionsResponse\x121\n" +
+	"\n" +
+	"receptions\x18\x01 \x03(\v2\x11.pvz.v1.ReceptionR\n" +
+	"receptions\"\x96\x01\n" +
+	"\x17ExportReceptionsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x12\x12\n" +
+	"\x04city\x18\x04 \x03(\tR\x04city\x12\x15\n" +
+	"\x06pvz_id\x18\x05 \x03(\tR\x05pvzId\">\n" +
+	"\x11AddProductRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x15\n" +
+	"\x06pvz_id\x18\x02 \x01(\tR\x05pvzId\"\xa8\x01\n" +
+	"\x1cListReceptionProductsRequest\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\x12\x12\n" +
+	"\x04type\x18\x02 \x03(\tR\x04type\x12\x14\n" +
+	"\x05order\x18\x03 \x01(\tR\x05order\x12\x19\n" +
+	"\x05limit\x18\x04 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursorB\b\n" +
+	"\x06_limit\"|\n" +
+	"\x1dListReceptionProductsResponse\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.pvz.v1.ProductR\x05items\x12$\n" +
+	"\vnext_cursor\x18\x02 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor*P\n" +
 	"\x0fReceptionStatus\x12 \n" +
 	"\x1cRECEPTION_STATUS_IN_PROGRESS\x10\x00\x12\x1b\n" +
-	"\x17RECEPTION_STATUS_CLOSED\x10\x012Q\n" +
+	"\x17RECEPTION_STATUS_CLOSED\x10\x012\xa0\x0f\n" +
+	"\vUserService\x12[\n" +
+	"\n" +
+	"DummyLogin\x12\x19.pvz.v1.DummyLoginRequest\x1a\x15.pvz.v1.LoginResponse\"\x1b\x92A\x02b\x00\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/dummyLogin\x12\xce\x02\n" +
+	"\bRegister\x12\x17.pvz.v1.RegisterRequest\x1a\f.pvz.v1.User\"\x9a\x02\x92A\x82\x02Ja\n" +
+	"\x03201\x12Z\n" +
+	"FПользователь успешно зарегистрирован\x12\x10\n" +
+	"\x0e\x1a\f.pvz.v1.UserJ\x9a\x01\n" +
+	"\x03403\x12\x92\x01\n" +
+	"}Регистрация с этой ролью или самостоятельная регистрация запрещена\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.Errorb\x00\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/register\x12L\n" +
+	"\x05Login\x12\x14.pvz.v1.LoginRequest\x1a\x15.pvz.v1.LoginResponse\"\x16\x92A\x02b\x00\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12\x9d\x01\n" +
+	"\n" +
+	"UnlockUser\x12\x15.pvz.v1.UserIdRequest\x1a\f.pvz.v1.User\"j\x92AHJF\n" +
+	"\x03404\x12?\n" +
+	"*Пользователь не найден\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.Error\x82\xd3\xe4\x93\x02\x19\"\x17/users/{user_id}/unlock\x12f\n" +
+	"\x0eChangePassword\x12\x1d.pvz.v1.ChangePasswordRequest\x1a\x16.google.protobuf.Empty\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/users/me/password\x12\xb5\x01\n" +
+	"\x14RequestPasswordReset\x12#.pvz.v1.RequestPasswordResetRequest\x1a\x16.google.protobuf.Empty\"`\x92ACJ?\n" +
+	"\x03202\x128\n" +
+	"6Запрос на сброс пароля принятb\x00\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/password/reset\x12n\n" +
+	"\rResetPassword\x12\x1c.pvz.v1.ResetPasswordRequest\x1a\x16.google.protobuf.Empty\"'\x92A\x02b\x00\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/password/reset/confirm\x12P\n" +
+	"\tListUsers\x12\x18.pvz.v1.ListUsersRequest\x1a\x19.pvz.v1.ListUsersResponse\"\x0e\x82\xd3\xe4\x93\x02\b\x12\x06/users\x12\x93\x01\n" +
+	"\aGetUser\x12\x15.pvz.v1.UserIdRequest\x1a\f.pvz.v1.User\"c\x92AHJF\n" +
+	"\x03404\x12?\n" +
+	"*Пользователь не найден\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.Error\x82\xd3\xe4\x93\x02\x12\x12\x10/users/{user_id}\x12\x8d\x01\n" +
+	"\n" +
+	"CreateUser\x12\x19.pvz.v1.CreateUserRequest\x1a\f.pvz.v1.User\"V\x92ABJ@\n" +
+	"\x03201\x129\n" +
+	"%Пользователь создан\x12\x10\n" +
+	"\x0e\x1a\f.pvz.v1.User\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/users\x12\x9f\x01\n" +
+	"\vDisableUser\x12\x15.pvz.v1.UserIdRequest\x1a\f.pvz.v1.User\"k\x92AHJF\n" +
+	"\x03404\x12?\n" +
+	"*Пользователь не найден\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.Error\x82\xd3\xe4\x93\x02\x1a\"\x18/users/{user_id}/disable\x12\x9d\x01\n" +
+	"\n" +
+	"EnableUser\x12\x15.pvz.v1.UserIdRequest\x1a\f.pvz.v1.User\"j\x92AHJF\n" +
+	"\x03404\x12?\n" +
+	"*Пользователь не найден\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.Error\x82\xd3\xe4\x93\x02\x19\"\x17/users/{user_id}/enable\x12\xaa\x01\n" +
+	"\x0eChangeUserRole\x12\x1d.pvz.v1.ChangeUserRoleRequest\x1a\f.pvz.v1.User\"k\x92AHJF\n" +
+	"\x03404\x12?\n" +
+	"*Пользователь не найден\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.Error\x82\xd3\xe4\x93\x02\x1a:\x01*\x1a\x15/users/{user_id}/role2\x98\b\n" +
+	"\n" +
+	"PVZService\x12J\n" +
+	"\n" +
+	"GetPVZList\x12\x19.pvz.v1.GetPVZListRequest\x1a\x1a.pvz.v1.GetPVZListResponse\"\x05\x92A\x02b\x00\x12\xa0\x01\n" +
+	"\tCreatePVZ\x12\x18.pvz.v1.CreatePVZRequest\x1a\x19.pvz.v1.CreatePVZResponse\"^\x92ALJJ\n" +
+	"\x03201\x12C\n" +
+	"\"ПВЗ успешно создан\x12\x1d\n" +
+	"\x1b\x1a\x19.pvz.v1.CreatePVZResponse\x82\xd3\xe4\x93\x02\t:\x01*\"\x04/pvz\x12O\n" +
+	"\aListPVZ\x12\x16.pvz.v1.ListPVZRequest\x1a\x17.pvz.v1.ListPVZResponse\"\x13\x82\xd3\xe4\x93\x02\rb\x05items\x12\x04/pvz\x12B\n" +
+	"\x06GetPVZ\x12\x14.pvz.v1.PVZIdRequest\x1a\v.pvz.v1.PVZ\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/pvz/{pvz_id}\x12g\n" +
+	"\rGetNearbyPVZs\x12\x1c.pvz.v1.GetNearbyPVZsRequest\x1a\x1d.pvz.v1.GetNearbyPVZsResponse\"\x19\x82\xd3\xe4\x93\x02\x13b\x04pvzs\x12\v/pvz/nearby\x12L\n" +
+	"\tUpdatePVZ\x12\x18.pvz.v1.UpdatePVZRequest\x1a\v.pvz.v1.PVZ\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*2\r/pvz/{pvz_id}\x12P\n" +
+	"\vActivatePVZ\x12\x14.pvz.v1.PVZIdRequest\x1a\v.pvz.v1.PVZ\"\x1e\x82\xd3\xe4\x93\x02\x18\"\x16/pvz/{pvz_id}/activate\x12T\n" +
+	"\rDeactivatePVZ\x12\x14.pvz.v1.PVZIdRequest\x1a\v.pvz.v1.PVZ\" \x82\xd3\xe4\x93\x02\x1a\"\x18/pvz/{pvz_id}/deactivate\x12N\n" +
+	"\n" +
+	"ArchivePVZ\x12\x14.pvz.v1.PVZIdRequest\x1a\v.pvz.v1.PVZ\"\x1d\x82\xd3\xe4\x93\x02\x17\"\x15/pvz/{pvz_id}/archive\x12i\n" +
+	"\x12CloseLastReception\x12\x14.pvz.v1.PVZIdRequest\x1a\x11.pvz.v1.Reception\"*\x82\xd3\xe4\x93\x02$\"\"/pvz/{pvz_id}/close_last_reception\x12l\n" +
+	"\x11DeleteLastProduct\x12\x14.pvz.v1.PVZIdRequest\x1a\x16.google.protobuf.Empty\")\x82\xd3\xe4\x93\x02#\"!/pvz/{pvz_id}/delete_last_product2\xb3\t\n" +
+	"\x10ReceptionService\x12\xad\x01\n" +
+	"\x0fCreateReception\x12\x1e.pvz.v1.CreateReceptionRequest\x1a\x11.pvz.v1.Reception\"g\x92ANJL\n" +
+	"\x03201\x12E\n" +
+	",Приемка успешно создана\x12\x15\n" +
+	"\x13\x1a\x11.pvz.v1.Reception\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/receptions\x12v\n" +
+	"\x0ePauseReception\x12$.pvz.v1.ChangeReceptionStatusRequest\x1a\x11.pvz.v1.Reception\"+\x82\xd3\xe4\x93\x02%:\x01*\" /receptions/{reception_id}/pause\x12x\n" +
+	"\x0fResumeReception\x12$.pvz.v1.ChangeReceptionStatusRequest\x1a\x11.pvz.v1.Reception\",\x82\xd3\xe4\x93\x02&:\x01*\"!/receptions/{reception_id}/resume\x12v\n" +
+	"\x0eCloseReception\x12$.pvz.v1.ChangeReceptionStatusRequest\x1a\x11.pvz.v1.Reception\"+\x82\xd3\xe4\x93\x02%:\x01*\" /receptions/{reception_id}/close\x12x\n" +
+	"\x0fCancelReception\x12$.pvz.v1.ChangeReceptionStatusRequest\x1a\x11.pvz.v1.Reception\",\x82\xd3\xe4\x93\x02&:\x01*\"!/receptions/{reception_id}/cancel\x12x\n" +
+	"\x0fReopenReception\x12$.pvz.v1.ChangeReceptionStatusRequest\x1a\x11.pvz.v1.Reception\",\x82\xd3\xe4\x93\x02&:\x01*\"!/receptions/{reception_id}/reopen\x12\x9e\x01\n" +
+	"\x19GetReceptionStatusHistory\x12\x1a.pvz.v1.ReceptionIdRequest\x1a).pvz.v1.GetReceptionStatusHistoryResponse\":\x82\xd3\xe4\x93\x024b\achanges\x12)/receptions/{reception_id}/status_history\x12\x86\x01\n" +
+	"\x11ListPVZReceptions\x12 .pvz.v1.ListPVZReceptionsRequest\x1a!.pvz.v1.ListPVZReceptionsResponse\",\x82\xd3\xe4\x93\x02&b\n" +
+	"receptions\x12\x18/pvz/{pvz_id}/receptions\x12g\n" +
+	"\x10ExportReceptions\x12\x1f.pvz.v1.ExportReceptionsRequest\x1a\x14.google.api.HttpBody\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/export/receptions0\x012\xc2\x02\n" +
+	"\x0eProductService\x12\x9b\x01\n" +
+	"\n" +
+	"AddProduct\x12\x19.pvz.v1.AddProductRequest\x1a\x0f.pvz.v1.Product\"a\x92AJJH\n" +
+	"\x03201\x12A\n" +
+	"*Товар успешно добавлен\x12\x13\n" +
+	"\x11\x1a\x0f.pvz.v1.Product\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/products\x12\x91\x01\n" +
+	"\x15ListReceptionProducts\x12$.pvz.v1.ListReceptionProductsRequest\x1a%.pvz.v1.ListReceptionProductsResponse\"+\x82\xd3\xe4\x93\x02%\x12#/receptions/{reception_id}/productsB\xfd\x04\x92A\xb6\x04\x12*\n" +
+	"\vPVZ Service\x12\x16Avito PVZ Service 20252\x031.02\x10application/json:\x10application/jsonRA\n" +
+	"\x03400\x12:\n" +
+	"%Некорректные данные\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.ErrorRS\n" +
+	"\x03401\x12L\n" +
+	"7Токен отсутствует или неверен\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.ErrorR9\n" +
+	"\x03403\x122\n" +
+	"\x1dДоступ запрещен\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.ErrorR~\n" +
+	"\x03429\x12w\n" +
+	"bСлишком много запросов, повторить после Retry-After секунд\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.ErrorRL\n" +
+	"\x03500\x12E\n" +
+	"0Внутренняя ошибка сервера\x12\x11\n" +
+	"\x0f\x1a\r.pvz.v1.ErrorZ1\n" +
+	"/\n" +
 	"\n" +
-	"PVZService\x12C\n" +
+	"ApiKeyAuth\x12!\b\x02\x12\x13Authorization check\x1a\x06auth-x \x02b\x10\n" +
+	"\x0e\n" +
 	"\n" +
-	"GetPVZList\x12\x19.pvz.v1.GetPVZListRequest\x1a\x1a.pvz.v1.GetPVZListResponseBCZAgithub.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1;pvz_v1b\x06proto3"
+	"ApiKeyAuth\x12\x00ZAgithub.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1;pvz_v1b\x06proto3"
 
 var (
 	file_pvz_proto_rawDescOnce sync.Once
@@ -241,24 +3032,148 @@ func file_pvz_proto_rawDescGZIP() []byte {
 }
 
 var file_pvz_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_pvz_proto_goTypes = []any{
-	(ReceptionStatus)(0),          // 0: pvz.v1.ReceptionStatus
-	(*PVZ)(nil),                   // 1: pvz.v1.PVZ
-	(*GetPVZListRequest)(nil),     // 2: pvz.v1.GetPVZListRequest
-	(*GetPVZListResponse)(nil),    // 3: pvz.v1.GetPVZListResponse
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(ReceptionStatus)(0),                      // 0: pvz.v1.ReceptionStatus
+	(*Error)(nil),                             // 1: pvz.v1.Error
+	(*User)(nil),                              // 2: pvz.v1.User
+	(*DummyLoginRequest)(nil),                 // 3: pvz.v1.DummyLoginRequest
+	(*RegisterRequest)(nil),                   // 4: pvz.v1.RegisterRequest
+	(*LoginRequest)(nil),                      // 5: pvz.v1.LoginRequest
+	(*UserIdRequest)(nil),                     // 6: pvz.v1.UserIdRequest
+	(*ListUsersRequest)(nil),                  // 7: pvz.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),                 // 8: pvz.v1.ListUsersResponse
+	(*CreateUserRequest)(nil),                 // 9: pvz.v1.CreateUserRequest
+	(*ChangeUserRoleRequest)(nil),             // 10: pvz.v1.ChangeUserRoleRequest
+	(*ChangePasswordRequest)(nil),             // 11: pvz.v1.ChangePasswordRequest
+	(*RequestPasswordResetRequest)(nil),       // 12: pvz.v1.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),              // 13: pvz.v1.ResetPasswordRequest
+	(*LoginResponse)(nil),                     // 14: pvz.v1.LoginResponse
+	(*PVZ)(nil),                               // 15: pvz.v1.PVZ
+	(*Reception)(nil),                         // 16: pvz.v1.Reception
+	(*Product)(nil),                           // 17: pvz.v1.Product
+	(*PVZIdRequest)(nil),                      // 18: pvz.v1.PVZIdRequest
+	(*GetPVZListRequest)(nil),                 // 19: pvz.v1.GetPVZListRequest
+	(*GetPVZListResponse)(nil),                // 20: pvz.v1.GetPVZListResponse
+	(*CreatePVZRequest)(nil),                  // 21: pvz.v1.CreatePVZRequest
+	(*CreatePVZResponse)(nil),                 // 22: pvz.v1.CreatePVZResponse
+	(*ListPVZRequest)(nil),                    // 23: pvz.v1.ListPVZRequest
+	(*ReceptionWithProducts)(nil),             // 24: pvz.v1.ReceptionWithProducts
+	(*PVZWithReceptions)(nil),                 // 25: pvz.v1.PVZWithReceptions
+	(*ListPVZResponse)(nil),                   // 26: pvz.v1.ListPVZResponse
+	(*GetNearbyPVZsRequest)(nil),              // 27: pvz.v1.GetNearbyPVZsRequest
+	(*NearbyPVZ)(nil),                         // 28: pvz.v1.NearbyPVZ
+	(*GetNearbyPVZsResponse)(nil),             // 29: pvz.v1.GetNearbyPVZsResponse
+	(*UpdatePVZRequest)(nil),                  // 30: pvz.v1.UpdatePVZRequest
+	(*CreateReceptionRequest)(nil),            // 31: pvz.v1.CreateReceptionRequest
+	(*ReceptionIdRequest)(nil),                // 32: pvz.v1.ReceptionIdRequest
+	(*ChangeReceptionStatusRequest)(nil),      // 33: pvz.v1.ChangeReceptionStatusRequest
+	(*ReceptionStatusChange)(nil),             // 34: pvz.v1.ReceptionStatusChange
+	(*GetReceptionStatusHistoryResponse)(nil), // 35: pvz.v1.GetReceptionStatusHistoryResponse
+	(*ListPVZReceptionsRequest)(nil),          // 36: pvz.v1.ListPVZReceptionsRequest
+	(*ListPVZReceptionsResponse)(nil),         // 37: pvz.v1.ListPVZReceptionsResponse
+	(*ExportReceptionsRequest)(nil),           // 38: pvz.v1.ExportReceptionsRequest
+	(*AddProductRequest)(nil),                 // 39: pvz.v1.AddProductRequest
+	(*ListReceptionProductsRequest)(nil),      // 40: pvz.v1.ListReceptionProductsRequest
+	(*ListReceptionProductsResponse)(nil),     // 41: pvz.v1.ListReceptionProductsResponse
+	(*timestamppb.Timestamp)(nil),             // 42: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                     // 43: google.protobuf.Empty
+	(*httpbody.HttpBody)(nil),                 // 44: google.api.HttpBody
 }
 var file_pvz_proto_depIdxs = []int32{
-	4, // 0: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
-	1, // 1: pvz.v1.GetPVZListResponse.pvzs:type_name -> pvz.v1.PVZ
-	2, // 2: pvz.v1.PVZService.GetPVZList:input_type -> pvz.v1.GetPVZListRequest
-	3, // 3: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	42, // 0: pvz.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 1: pvz.v1.ListUsersResponse.items:type_name -> pvz.v1.User
+	42, // 2: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
+	42, // 3: pvz.v1.Reception.date_time:type_name -> google.protobuf.Timestamp
+	42, // 4: pvz.v1.Product.date_time:type_name -> google.protobuf.Timestamp
+	15, // 5: pvz.v1.GetPVZListResponse.pvzs:type_name -> pvz.v1.PVZ
+	42, // 6: pvz.v1.CreatePVZResponse.registration_date:type_name -> google.protobuf.Timestamp
+	16, // 7: pvz.v1.ReceptionWithProducts.reception:type_name -> pvz.v1.Reception
+	17, // 8: pvz.v1.ReceptionWithProducts.products:type_name -> pvz.v1.Product
+	15, // 9: pvz.v1.PVZWithReceptions.pvz:type_name -> pvz.v1.PVZ
+	24, // 10: pvz.v1.PVZWithReceptions.receptions:type_name -> pvz.v1.ReceptionWithProducts
+	25, // 11: pvz.v1.ListPVZResponse.items:type_name -> pvz.v1.PVZWithReceptions
+	15, // 12: pvz.v1.NearbyPVZ.pvz:type_name -> pvz.v1.PVZ
+	28, // 13: pvz.v1.GetNearbyPVZsResponse.pvzs:type_name -> pvz.v1.NearbyPVZ
+	42, // 14: pvz.v1.ReceptionStatusChange.changed_at:type_name -> google.protobuf.Timestamp
+	34, // 15: pvz.v1.GetReceptionStatusHistoryResponse.changes:type_name -> pvz.v1.ReceptionStatusChange
+	16, // 16: pvz.v1.ListPVZReceptionsResponse.receptions:type_name -> pvz.v1.Reception
+	17, // 17: pvz.v1.ListReceptionProductsResponse.items:type_name -> pvz.v1.Product
+	3,  // 18: pvz.v1.UserService.DummyLogin:input_type -> pvz.v1.DummyLoginRequest
+	4,  // 19: pvz.v1.UserService.Register:input_type -> pvz.v1.RegisterRequest
+	5,  // 20: pvz.v1.UserService.Login:input_type -> pvz.v1.LoginRequest
+	6,  // 21: pvz.v1.UserService.UnlockUser:input_type -> pvz.v1.UserIdRequest
+	11, // 22: pvz.v1.UserService.ChangePassword:input_type -> pvz.v1.ChangePasswordRequest
+	12, // 23: pvz.v1.UserService.RequestPasswordReset:input_type -> pvz.v1.RequestPasswordResetRequest
+	13, // 24: pvz.v1.UserService.ResetPassword:input_type -> pvz.v1.ResetPasswordRequest
+	7,  // 25: pvz.v1.UserService.ListUsers:input_type -> pvz.v1.ListUsersRequest
+	6,  // 26: pvz.v1.UserService.GetUser:input_type -> pvz.v1.UserIdRequest
+	9,  // 27: pvz.v1.UserService.CreateUser:input_type -> pvz.v1.CreateUserRequest
+	6,  // 28: pvz.v1.UserService.DisableUser:input_type -> pvz.v1.UserIdRequest
+	6,  // 29: pvz.v1.UserService.EnableUser:input_type -> pvz.v1.UserIdRequest
+	10, // 30: pvz.v1.UserService.ChangeUserRole:input_type -> pvz.v1.ChangeUserRoleRequest
+	19, // 31: pvz.v1.PVZService.GetPVZList:input_type -> pvz.v1.GetPVZListRequest
+	21, // 32: pvz.v1.PVZService.CreatePVZ:input_type -> pvz.v1.CreatePVZRequest
+	23, // 33: pvz.v1.PVZService.ListPVZ:input_type -> pvz.v1.ListPVZRequest
+	18, // 34: pvz.v1.PVZService.GetPVZ:input_type -> pvz.v1.PVZIdRequest
+	27, // 35: pvz.v1.PVZService.GetNearbyPVZs:input_type -> pvz.v1.GetNearbyPVZsRequest
+	30, // 36: pvz.v1.PVZService.UpdatePVZ:input_type -> pvz.v1.UpdatePVZRequest
+	18, // 37: pvz.v1.PVZService.ActivatePVZ:input_type -> pvz.v1.PVZIdRequest
+	18, // 38: pvz.v1.PVZService.DeactivatePVZ:input_type -> pvz.v1.PVZIdRequest
+	18, // 39: pvz.v1.PVZService.ArchivePVZ:input_type -> pvz.v1.PVZIdRequest
+	18, // 40: pvz.v1.PVZService.CloseLastReception:input_type -> pvz.v1.PVZIdRequest
+	18, // 41: pvz.v1.PVZService.DeleteLastProduct:input_type -> pvz.v1.PVZIdRequest
+	31, // 42: pvz.v1.ReceptionService.CreateReception:input_type -> pvz.v1.CreateReceptionRequest
+	33, // 43: pvz.v1.ReceptionService.PauseReception:input_type -> pvz.v1.ChangeReceptionStatusRequest
+	33, // 44: pvz.v1.ReceptionService.ResumeReception:input_type -> pvz.v1.ChangeReceptionStatusRequest
+	33, // 45: pvz.v1.ReceptionService.CloseReception:input_type -> pvz.v1.ChangeReceptionStatusRequest
+	33, // 46: pvz.v1.ReceptionService.CancelReception:input_type -> pvz.v1.ChangeReceptionStatusRequest
+	33, // 47: pvz.v1.ReceptionService.ReopenReception:input_type -> pvz.v1.ChangeReceptionStatusRequest
+	32, // 48: pvz.v1.ReceptionService.GetReceptionStatusHistory:input_type -> pvz.v1.ReceptionIdRequest
+	36, // 49: pvz.v1.ReceptionService.ListPVZReceptions:input_type -> pvz.v1.ListPVZReceptionsRequest
+	38, // 50: pvz.v1.ReceptionService.ExportReceptions:input_type -> pvz.v1.ExportReceptionsRequest
+	39, // 51: pvz.v1.ProductService.AddProduct:input_type -> pvz.v1.AddProductRequest
+	40, // 52: pvz.v1.ProductService.ListReceptionProducts:input_type -> pvz.v1.ListReceptionProductsRequest
+	14, // 53: pvz.v1.UserService.DummyLogin:output_type -> pvz.v1.LoginResponse
+	2,  // 54: pvz.v1.UserService.Register:output_type -> pvz.v1.User
+	14, // 55: pvz.v1.UserService.Login:output_type -> pvz.v1.LoginResponse
+	2,  // 56: pvz.v1.UserService.UnlockUser:output_type -> pvz.v1.User
+	43, // 57: pvz.v1.UserService.ChangePassword:output_type -> google.protobuf.Empty
+	43, // 58: pvz.v1.UserService.RequestPasswordReset:output_type -> google.protobuf.Empty
+	43, // 59: pvz.v1.UserService.ResetPassword:output_type -> google.protobuf.Empty
+	8,  // 60: pvz.v1.UserService.ListUsers:output_type -> pvz.v1.ListUsersResponse
+	2,  // 61: pvz.v1.UserService.GetUser:output_type -> pvz.v1.User
+	2,  // 62: pvz.v1.UserService.CreateUser:output_type -> pvz.v1.User
+	2,  // 63: pvz.v1.UserService.DisableUser:output_type -> pvz.v1.User
+	2,  // 64: pvz.v1.UserService.EnableUser:output_type -> pvz.v1.User
+	2,  // 65: pvz.v1.UserService.ChangeUserRole:output_type -> pvz.v1.User
+	20, // 66: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
+	22, // 67: pvz.v1.PVZService.CreatePVZ:output_type -> pvz.v1.CreatePVZResponse
+	26, // 68: pvz.v1.PVZService.ListPVZ:output_type -> pvz.v1.ListPVZResponse
+	15, // 69: pvz.v1.PVZService.GetPVZ:output_type -> pvz.v1.PVZ
+	29, // 70: pvz.v1.PVZService.GetNearbyPVZs:output_type -> pvz.v1.GetNearbyPVZsResponse
+	15, // 71: pvz.v1.PVZService.UpdatePVZ:output_type -> pvz.v1.PVZ
+	15, // 72: pvz.v1.PVZService.ActivatePVZ:output_type -> pvz.v1.PVZ
+	15, // 73: pvz.v1.PVZService.DeactivatePVZ:output_type -> pvz.v1.PVZ
+	15, // 74: pvz.v1.PVZService.ArchivePVZ:output_type -> pvz.v1.PVZ
+	16, // 75: pvz.v1.PVZService.CloseLastReception:output_type -> pvz.v1.Reception
+	43, // 76: pvz.v1.PVZService.DeleteLastProduct:output_type -> google.protobuf.Empty
+	16, // 77: pvz.v1.ReceptionService.CreateReception:output_type -> pvz.v1.Reception
+	16, // 78: pvz.v1.ReceptionService.PauseReception:output_type -> pvz.v1.Reception
+	16, // 79: pvz.v1.ReceptionService.ResumeReception:output_type -> pvz.v1.Reception
+	16, // 80: pvz.v1.ReceptionService.CloseReception:output_type -> pvz.v1.Reception
+	16, // 81: pvz.v1.ReceptionService.CancelReception:output_type -> pvz.v1.Reception
+	16, // 82: pvz.v1.ReceptionService.ReopenReception:output_type -> pvz.v1.Reception
+	35, // 83: pvz.v1.ReceptionService.GetReceptionStatusHistory:output_type -> pvz.v1.GetReceptionStatusHistoryResponse
+	37, // 84: pvz.v1.ReceptionService.ListPVZReceptions:output_type -> pvz.v1.ListPVZReceptionsResponse
+	44, // 85: pvz.v1.ReceptionService.ExportReceptions:output_type -> google.api.HttpBody
+	17, // 86: pvz.v1.ProductService.AddProduct:output_type -> pvz.v1.Product
+	41, // 87: pvz.v1.ProductService.ListReceptionProducts:output_type -> pvz.v1.ListReceptionProductsResponse
+	53, // [53:88] is the sub-list for method output_type
+	18, // [18:53] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_pvz_proto_init() }
@@ -266,15 +3181,25 @@ func file_pvz_proto_init() {
 	if File_pvz_proto != nil {
 		return
 	}
+	file_pvz_proto_msgTypes[6].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[14].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[22].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[23].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[26].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[29].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[33].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[35].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[39].OneofWrappers = []any{}
+	file_pvz_proto_msgTypes[40].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pvz_proto_rawDesc), len(file_pvz_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   41,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   4,
 		},
 		GoTypes:           file_pvz_proto_goTypes,
 		DependencyIndexes: file_pvz_proto_depIdxs,
@@ -8,9 +8,11 @@ package pvz_v1
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -19,101 +21,1847 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PVZService_GetPVZList_FullMethodName = "/pvz.v1.PVZService/GetPVZList"
+	UserService_DummyLogin_FullMethodName           = "/pvz.v1.UserService/DummyLogin"
+	UserService_Register_FullMethodName             = "/pvz.v1.UserService/Register"
+	UserService_Login_FullMethodName                = "/pvz.v1.UserService/Login"
+	UserService_UnlockUser_FullMethodName           = "/pvz.v1.UserService/UnlockUser"
+	UserService_ChangePassword_FullMethodName       = "/pvz.v1.UserService/ChangePassword"
+	UserService_RequestPasswordReset_FullMethodName = "/pvz.v1.UserService/RequestPasswordReset"
+	UserService_ResetPassword_FullMethodName        = "/pvz.v1.UserService/ResetPassword"
+	UserService_ListUsers_FullMethodName            = "/pvz.v1.UserService/ListUsers"
+	UserService_GetUser_FullMethodName              = "/pvz.v1.UserService/GetUser"
+	UserService_CreateUser_FullMethodName           = "/pvz.v1.UserService/CreateUser"
+	UserService_DisableUser_FullMethodName          = "/pvz.v1.UserService/DisableUser"
+	UserService_EnableUser_FullMethodName           = "/pvz.v1.UserService/EnableUser"
+	UserService_ChangeUserRole_FullMethodName       = "/pvz.v1.UserService/ChangeUserRole"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserServiceClient interface {
+	// Упрощенная авторизация
+	//
+	// Создает JWT токен с указанной ролью без проверки учетных данных (для тестирования). Токен не привязан
+	// к пользователю, поэтому управлять пользователями с ним нельзя
+	DummyLogin(ctx context.Context, in *DummyLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// Регистрация пользователя
+	//
+	// Регистрирует нового сотрудника с указанными email и паролем. Самостоятельно зарегистрироваться
+	// модератором нельзя, а в режиме регистрации invite пользователей заводит только модератор через /users
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*User, error)
+	// Авторизация пользователя
+	//
+	// Авторизует пользователя по email и паролю и возвращает JWT токен. После неудачной попытки
+	// следующая разрешена только через растущую задержку, а после серии неудач учетная запись
+	// или IP адрес временно блокируются: в этом случае возвращается 429 с заголовком Retry-After
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// Разблокировать пользователя
+	//
+	// Снимает блокировку входа после серии неудачных попыток и обнуляет счетчик неудач учетной записи.
+	// Доступно только модератору
+	UnlockUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error)
+	// Сменить пароль
+	//
+	// Меняет пароль текущего пользователя после проверки старого пароля. Новый пароль должен
+	// соответствовать политике паролей. Недоступно для токенов из /dummyLogin
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Запросить сброс пароля
+	//
+	// Отправляет на почту одноразовый токен для сброса пароля. Ответ не зависит от того,
+	// зарегистрирована ли почта
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Сбросить пароль
+	//
+	// Задает новый пароль по токену из письма. Токен одноразовый и действует ограниченное время,
+	// после сброса снимается блокировка входа учетной записи
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Получить список пользователей
+	//
+	// Возвращает пользователей постранично в порядке регистрации с фильтром по роли и признаку
+	// отключения. Доступно только модератору
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// Получить пользователя
+	//
+	// Возвращает пользователя по идентификатору. Доступно только модератору
+	GetUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error)
+	// Создать пользователя
+	//
+	// Заводит пользователя с любой ролью, в том числе в режиме регистрации invite. Пароль должен
+	// соответствовать политике паролей. Доступно только модератору
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
+	// Отключить пользователя
+	//
+	// Запрещает вход, а уже выданные токены пользователя перестают приниматься. Отключить себя нельзя.
+	// Доступно только модератору
+	DisableUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error)
+	// Включить пользователя
+	//
+	// Снова разрешает пользователю вход. Доступно только модератору
+	EnableUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error)
+	// Сменить роль пользователя
+	//
+	// Новая роль действует сразу, в том числе для выданных ранее токенов. Сменить роль себе нельзя.
+	// Доступно только модератору
+	ChangeUserRole(ctx context.Context, in *ChangeUserRoleRequest, opts ...grpc.CallOption) (*User, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) DummyLogin(ctx context.Context, in *DummyLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_DummyLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnlockUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_UnlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_RequestPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DisableUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_DisableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) EnableUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_EnableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ChangeUserRole(ctx context.Context, in *ChangeUserRoleRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_ChangeUserRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+type UserServiceServer interface {
+	// Упрощенная авторизация
+	//
+	// Создает JWT токен с указанной ролью без проверки учетных данных (для тестирования). Токен не привязан
+	// к пользователю, поэтому управлять пользователями с ним нельзя
+	DummyLogin(context.Context, *DummyLoginRequest) (*LoginResponse, error)
+	// Регистрация пользователя
+	//
+	// Регистрирует нового сотрудника с указанными email и паролем. Самостоятельно зарегистрироваться
+	// модератором нельзя, а в режиме регистрации invite пользователей заводит только модератор через /users
+	Register(context.Context, *RegisterRequest) (*User, error)
+	// Авторизация пользователя
+	//
+	// Авторизует пользователя по email и паролю и возвращает JWT токен. После неудачной попытки
+	// следующая разрешена только через растущую задержку, а после серии неудач учетная запись
+	// или IP адрес временно блокируются: в этом случае возвращается 429 с заголовком Retry-After
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// Разблокировать пользователя
+	//
+	// Снимает блокировку входа после серии неудачных попыток и обнуляет счетчик неудач учетной записи.
+	// Доступно только модератору
+	UnlockUser(context.Context, *UserIdRequest) (*User, error)
+	// Сменить пароль
+	//
+	// Меняет пароль текущего пользователя после проверки старого пароля. Новый пароль должен
+	// соответствовать политике паролей. Недоступно для токенов из /dummyLogin
+	ChangePassword(context.Context, *ChangePasswordRequest) (*emptypb.Empty, error)
+	// Запросить сброс пароля
+	//
+	// Отправляет на почту одноразовый токен для сброса пароля. Ответ не зависит от того,
+	// зарегистрирована ли почта
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*emptypb.Empty, error)
+	// Сбросить пароль
+	//
+	// Задает новый пароль по токену из письма. Токен одноразовый и действует ограниченное время,
+	// после сброса снимается блокировка входа учетной записи
+	ResetPassword(context.Context, *ResetPasswordRequest) (*emptypb.Empty, error)
+	// Получить список пользователей
+	//
+	// Возвращает пользователей постранично в порядке регистрации с фильтром по роли и признаку
+	// отключения. Доступно только модератору
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// Получить пользователя
+	//
+	// Возвращает пользователя по идентификатору. Доступно только модератору
+	GetUser(context.Context, *UserIdRequest) (*User, error)
+	// Создать пользователя
+	//
+	// Заводит пользователя с любой ролью, в том числе в режиме регистрации invite. Пароль должен
+	// соответствовать политике паролей. Доступно только модератору
+	CreateUser(context.Context, *CreateUserRequest) (*User, error)
+	// Отключить пользователя
+	//
+	// Запрещает вход, а уже выданные токены пользователя перестают приниматься. Отключить себя нельзя.
+	// Доступно только модератору
+	DisableUser(context.Context, *UserIdRequest) (*User, error)
+	// Включить пользователя
+	//
+	// Снова разрешает пользователю вход. Доступно только модератору
+	EnableUser(context.Context, *UserIdRequest) (*User, error)
+	// Сменить роль пользователя
+	//
+	// Новая роль действует сразу, в том числе для выданных ранее токенов. Сменить роль себе нельзя.
+	// Доступно только модератору
+	ChangeUserRole(context.Context, *ChangeUserRoleRequest) (*User, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) DummyLogin(context.Context, *DummyLoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DummyLogin not implemented")
+}
+func (UnimplementedUserServiceServer) Register(context.Context, *RegisterRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedUserServiceServer) UnlockUser(context.Context, *UserIdRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
+func (UnimplementedUserServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedUserServiceServer) RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedUserServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *UserIdRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) CreateUser(context.Context, *CreateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedUserServiceServer) DisableUser(context.Context, *UserIdRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableUser not implemented")
+}
+func (UnimplementedUserServiceServer) EnableUser(context.Context, *UserIdRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableUser not implemented")
+}
+func (UnimplementedUserServiceServer) ChangeUserRole(context.Context, *ChangeUserRoleRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeUserRole not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_DummyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DummyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DummyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DummyLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DummyLogin(ctx, req.(*DummyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnlockUser(ctx, req.(*UserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RequestPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequestPasswordReset(ctx, req.(*RequestPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*UserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DisableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DisableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DisableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DisableUser(ctx, req.(*UserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_EnableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).EnableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_EnableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).EnableUser(ctx, req.(*UserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ChangeUserRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeUserRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangeUserRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ChangeUserRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangeUserRole(ctx, req.(*ChangeUserRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pvz.v1.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DummyLogin",
+			Handler:    _UserService_DummyLogin_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _UserService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
+		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _UserService_UnlockUser_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _UserService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _UserService_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _UserService_ResetPassword_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _UserService_ListUsers_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "DisableUser",
+			Handler:    _UserService_DisableUser_Handler,
+		},
+		{
+			MethodName: "EnableUser",
+			Handler:    _UserService_EnableUser_Handler,
+		},
+		{
+			MethodName: "ChangeUserRole",
+			Handler:    _UserService_ChangeUserRole_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pvz.proto",
+}
+
+const (
+	PVZService_GetPVZList_FullMethodName         = "/pvz.v1.PVZService/GetPVZList"
+	PVZService_CreatePVZ_FullMethodName          = "/pvz.v1.PVZService/CreatePVZ"
+	PVZService_ListPVZ_FullMethodName            = "/pvz.v1.PVZService/ListPVZ"
+	PVZService_GetPVZ_FullMethodName             = "/pvz.v1.PVZService/GetPVZ"
+	PVZService_GetNearbyPVZs_FullMethodName      = "/pvz.v1.PVZService/GetNearbyPVZs"
+	PVZService_UpdatePVZ_FullMethodName          = "/pvz.v1.PVZService/UpdatePVZ"
+	PVZService_ActivatePVZ_FullMethodName        = "/pvz.v1.PVZService/ActivatePVZ"
+	PVZService_DeactivatePVZ_FullMethodName      = "/pvz.v1.PVZService/DeactivatePVZ"
+	PVZService_ArchivePVZ_FullMethodName         = "/pvz.v1.PVZService/ArchivePVZ"
+	PVZService_CloseLastReception_FullMethodName = "/pvz.v1.PVZService/CloseLastReception"
+	PVZService_DeleteLastProduct_FullMethodName  = "/pvz.v1.PVZService/DeleteLastProduct"
 )
 
 // PVZServiceClient is the client API for PVZService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type PVZServiceClient interface {
-	GetPVZList(ctx context.Context, in *GetPVZListRequest, opts ...grpc.CallOption) (*GetPVZListResponse, error)
+type PVZServiceClient interface {
+	// Получить все ПВЗ
+	//
+	// Возвращает все ПВЗ, кроме архивных, без приемок. Доступен только по gRPC и без токена
+	GetPVZList(ctx context.Context, in *GetPVZListRequest, opts ...grpc.CallOption) (*GetPVZListResponse, error)
+	// Завести ПВЗ
+	//
+	// Создает новый пункт выдачи заказов в указанном городе
+	CreatePVZ(ctx context.Context, in *CreatePVZRequest, opts ...grpc.CallOption) (*CreatePVZResponse, error)
+	// Получить список ПВЗ с пагинацией
+	//
+	// Возвращает список ПВЗ с их приемками и товарами с фильтрацией, сортировкой и пагинацией.
+	// Фильтры по дате, статусу приемки и типу товара ограничивают и сами ПВЗ, и вложенные приемки и товары.
+	// В REST ответ дополнительно содержит заголовок Link, а его форма зависит от expand и envelope
+	// (или Accept: application/json; profile="paginated"), поэтому этот маршрут обслуживается
+	// отдельным обработчиком поверх тех же usecases
+	ListPVZ(ctx context.Context, in *ListPVZRequest, opts ...grpc.CallOption) (*ListPVZResponse, error)
+	// Получить ПВЗ
+	//
+	// Возвращает информацию о ПВЗ по его идентификатору
+	GetPVZ(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*PVZ, error)
+	// Найти ближайшие ПВЗ
+	//
+	// Возвращает неархивные ПВЗ в заданном радиусе от точки, отсортированные по расстоянию.
+	// Объявлен после GetPVZ: шлюз проверяет маршруты в обратном порядке регистрации,
+	// и /pvz/nearby должен иметь приоритет над /pvz/{pvz_id}
+	GetNearbyPVZs(ctx context.Context, in *GetNearbyPVZsRequest, opts ...grpc.CallOption) (*GetNearbyPVZsResponse, error)
+	// Изменить ПВЗ
+	//
+	// Изменяет название, адрес, координаты, часовой пояс и часы работы ПВЗ. Архивный ПВЗ изменить нельзя
+	UpdatePVZ(ctx context.Context, in *UpdatePVZRequest, opts ...grpc.CallOption) (*PVZ, error)
+	// Активировать ПВЗ
+	//
+	// Переводит деактивированный ПВЗ в статус active
+	ActivatePVZ(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*PVZ, error)
+	// Деактивировать ПВЗ
+	//
+	// Переводит ПВЗ в статус inactive, после чего на нем нельзя создавать приемки
+	DeactivatePVZ(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*PVZ, error)
+	// Архивировать ПВЗ
+	//
+	// Переводит ПВЗ в архив: он скрывается из списка ПВЗ и больше не может быть изменен
+	ArchivePVZ(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*PVZ, error)
+	// Закрыть последнюю приемку
+	//
+	// Закрывает последнюю активную приемку для указанного ПВЗ
+	CloseLastReception(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*Reception, error)
+	// Удалить последний товар
+	//
+	// Удаляет последний добавленный товар из последней активной приемки для указанного ПВЗ
+	DeleteLastProduct(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type pVZServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPVZServiceClient(cc grpc.ClientConnInterface) PVZServiceClient {
+	return &pVZServiceClient{cc}
+}
+
+func (c *pVZServiceClient) GetPVZList(ctx context.Context, in *GetPVZListRequest, opts ...grpc.CallOption) (*GetPVZListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPVZListResponse)
+	err := c.cc.Invoke(ctx, PVZService_GetPVZList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CreatePVZ(ctx context.Context, in *CreatePVZRequest, opts ...grpc.CallOption) (*CreatePVZResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePVZResponse)
+	err := c.cc.Invoke(ctx, PVZService_CreatePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListPVZ(ctx context.Context, in *ListPVZRequest, opts ...grpc.CallOption) (*ListPVZResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPVZResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListPVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) GetPVZ(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*PVZ, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PVZ)
+	err := c.cc.Invoke(ctx, PVZService_GetPVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) GetNearbyPVZs(ctx context.Context, in *GetNearbyPVZsRequest, opts ...grpc.CallOption) (*GetNearbyPVZsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNearbyPVZsResponse)
+	err := c.cc.Invoke(ctx, PVZService_GetNearbyPVZs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) UpdatePVZ(ctx context.Context, in *UpdatePVZRequest, opts ...grpc.CallOption) (*PVZ, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PVZ)
+	err := c.cc.Invoke(ctx, PVZService_UpdatePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ActivatePVZ(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*PVZ, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PVZ)
+	err := c.cc.Invoke(ctx, PVZService_ActivatePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeactivatePVZ(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*PVZ, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PVZ)
+	err := c.cc.Invoke(ctx, PVZService_DeactivatePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ArchivePVZ(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*PVZ, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PVZ)
+	err := c.cc.Invoke(ctx, PVZService_ArchivePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CloseLastReception(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, PVZService_CloseLastReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeleteLastProduct(ctx context.Context, in *PVZIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_DeleteLastProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PVZServiceServer is the server API for PVZService service.
+// All implementations must embed UnimplementedPVZServiceServer
+// for forward compatibility.
+type PVZServiceServer interface {
+	// Получить все ПВЗ
+	//
+	// Возвращает все ПВЗ, кроме архивных, без приемок. Доступен только по gRPC и без токена
+	GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error)
+	// Завести ПВЗ
+	//
+	// Создает новый пункт выдачи заказов в указанном городе
+	CreatePVZ(context.Context, *CreatePVZRequest) (*CreatePVZResponse, error)
+	// Получить список ПВЗ с пагинацией
+	//
+	// Возвращает список ПВЗ с их приемками и товарами с фильтрацией, сортировкой и пагинацией.
+	// Фильтры по дате, статусу приемки и типу товара ограничивают и сами ПВЗ, и вложенные приемки и товары.
+	// В REST ответ дополнительно содержит заголовок Link, а его форма зависит от expand и envelope
+	// (или Accept: application/json; profile="paginated"), поэтому этот маршрут обслуживается
+	// отдельным обработчиком поверх тех же usecases
+	ListPVZ(context.Context, *ListPVZRequest) (*ListPVZResponse, error)
+	// Получить ПВЗ
+	//
+	// Возвращает информацию о ПВЗ по его идентификатору
+	GetPVZ(context.Context, *PVZIdRequest) (*PVZ, error)
+	// Найти ближайшие ПВЗ
+	//
+	// Возвращает неархивные ПВЗ в заданном радиусе от точки, отсортированные по расстоянию.
+	// Объявлен после GetPVZ: шлюз проверяет маршруты в обратном порядке регистрации,
+	// и /pvz/nearby должен иметь приоритет над /pvz/{pvz_id}
+	GetNearbyPVZs(context.Context, *GetNearbyPVZsRequest) (*GetNearbyPVZsResponse, error)
+	// Изменить ПВЗ
+	//
+	// Изменяет название, адрес, координаты, часовой пояс и часы работы ПВЗ. Архивный ПВЗ изменить нельзя
+	UpdatePVZ(context.Context, *UpdatePVZRequest) (*PVZ, error)
+	// Активировать ПВЗ
+	//
+	// Переводит деактивированный ПВЗ в статус active
+	ActivatePVZ(context.Context, *PVZIdRequest) (*PVZ, error)
+	// Деактивировать ПВЗ
+	//
+	// Переводит ПВЗ в статус inactive, после чего на нем нельзя создавать приемки
+	DeactivatePVZ(context.Context, *PVZIdRequest) (*PVZ, error)
+	// Архивировать ПВЗ
+	//
+	// Переводит ПВЗ в архив: он скрывается из списка ПВЗ и больше не может быть изменен
+	ArchivePVZ(context.Context, *PVZIdRequest) (*PVZ, error)
+	// Закрыть последнюю приемку
+	//
+	// Закрывает последнюю активную приемку для указанного ПВЗ
+	CloseLastReception(context.Context, *PVZIdRequest) (*Reception, error)
+	// Удалить последний товар
+	//
+	// Удаляет последний добавленный товар из последней активной приемки для указанного ПВЗ
+	DeleteLastProduct(context.Context, *PVZIdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPVZServiceServer()
+}
+
+// UnimplementedPVZServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPVZServiceServer struct{}
+
+func (UnimplementedPVZServiceServer) GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPVZList not implemented")
+}
+func (UnimplementedPVZServiceServer) CreatePVZ(context.Context, *CreatePVZRequest) (*CreatePVZResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) ListPVZ(context.Context, *ListPVZRequest) (*ListPVZResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) GetPVZ(context.Context, *PVZIdRequest) (*PVZ, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) GetNearbyPVZs(context.Context, *GetNearbyPVZsRequest) (*GetNearbyPVZsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearbyPVZs not implemented")
+}
+func (UnimplementedPVZServiceServer) UpdatePVZ(context.Context, *UpdatePVZRequest) (*PVZ, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) ActivatePVZ(context.Context, *PVZIdRequest) (*PVZ, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivatePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) DeactivatePVZ(context.Context, *PVZIdRequest) (*PVZ, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivatePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) ArchivePVZ(context.Context, *PVZIdRequest) (*PVZ, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchivePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) CloseLastReception(context.Context, *PVZIdRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseLastReception not implemented")
+}
+func (UnimplementedPVZServiceServer) DeleteLastProduct(context.Context, *PVZIdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLastProduct not implemented")
+}
+func (UnimplementedPVZServiceServer) mustEmbedUnimplementedPVZServiceServer() {}
+func (UnimplementedPVZServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafePVZServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PVZServiceServer will
+// result in compilation errors.
+type UnsafePVZServiceServer interface {
+	mustEmbedUnimplementedPVZServiceServer()
+}
+
+func RegisterPVZServiceServer(s grpc.ServiceRegistrar, srv PVZServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPVZServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PVZService_ServiceDesc, srv)
+}
+
+func _PVZService_GetPVZList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPVZListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetPVZList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetPVZList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetPVZList(ctx, req.(*GetPVZListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CreatePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CreatePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CreatePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CreatePVZ(ctx, req.(*CreatePVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ListPVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ListPVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ListPVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ListPVZ(ctx, req.(*ListPVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_GetPVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PVZIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetPVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetPVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetPVZ(ctx, req.(*PVZIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_GetNearbyPVZs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNearbyPVZsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetNearbyPVZs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetNearbyPVZs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetNearbyPVZs(ctx, req.(*GetNearbyPVZsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_UpdatePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).UpdatePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_UpdatePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).UpdatePVZ(ctx, req.(*UpdatePVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ActivatePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PVZIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ActivatePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ActivatePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ActivatePVZ(ctx, req.(*PVZIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeactivatePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PVZIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeactivatePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeactivatePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeactivatePVZ(ctx, req.(*PVZIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ArchivePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PVZIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ArchivePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ArchivePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ArchivePVZ(ctx, req.(*PVZIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CloseLastReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PVZIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CloseLastReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CloseLastReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CloseLastReception(ctx, req.(*PVZIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeleteLastProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PVZIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeleteLastProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeleteLastProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeleteLastProduct(ctx, req.(*PVZIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PVZService_ServiceDesc is the grpc.ServiceDesc for PVZService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PVZService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pvz.v1.PVZService",
+	HandlerType: (*PVZServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPVZList",
+			Handler:    _PVZService_GetPVZList_Handler,
+		},
+		{
+			MethodName: "CreatePVZ",
+			Handler:    _PVZService_CreatePVZ_Handler,
+		},
+		{
+			MethodName: "ListPVZ",
+			Handler:    _PVZService_ListPVZ_Handler,
+		},
+		{
+			MethodName: "GetPVZ",
+			Handler:    _PVZService_GetPVZ_Handler,
+		},
+		{
+			MethodName: "GetNearbyPVZs",
+			Handler:    _PVZService_GetNearbyPVZs_Handler,
+		},
+		{
+			MethodName: "UpdatePVZ",
+			Handler:    _PVZService_UpdatePVZ_Handler,
+		},
+		{
+			MethodName: "ActivatePVZ",
+			Handler:    _PVZService_ActivatePVZ_Handler,
+		},
+		{
+			MethodName: "DeactivatePVZ",
+			Handler:    _PVZService_DeactivatePVZ_Handler,
+		},
+		{
+			MethodName: "ArchivePVZ",
+			Handler:    _PVZService_ArchivePVZ_Handler,
+		},
+		{
+			MethodName: "CloseLastReception",
+			Handler:    _PVZService_CloseLastReception_Handler,
+		},
+		{
+			MethodName: "DeleteLastProduct",
+			Handler:    _PVZService_DeleteLastProduct_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pvz.proto",
+}
+
+const (
+	ReceptionService_CreateReception_FullMethodName           = "/pvz.v1.ReceptionService/CreateReception"
+	ReceptionService_PauseReception_FullMethodName            = "/pvz.v1.ReceptionService/PauseReception"
+	ReceptionService_ResumeReception_FullMethodName           = "/pvz.v1.ReceptionService/ResumeReception"
+	ReceptionService_CloseReception_FullMethodName            = "/pvz.v1.ReceptionService/CloseReception"
+	ReceptionService_CancelReception_FullMethodName           = "/pvz.v1.ReceptionService/CancelReception"
+	ReceptionService_ReopenReception_FullMethodName           = "/pvz.v1.ReceptionService/ReopenReception"
+	ReceptionService_GetReceptionStatusHistory_FullMethodName = "/pvz.v1.ReceptionService/GetReceptionStatusHistory"
+	ReceptionService_ListPVZReceptions_FullMethodName         = "/pvz.v1.ReceptionService/ListPVZReceptions"
+	ReceptionService_ExportReceptions_FullMethodName          = "/pvz.v1.ReceptionService/ExportReceptions"
+)
+
+// ReceptionServiceClient is the client API for ReceptionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReceptionServiceClient interface {
+	// Создать приемку
+	//
+	// Создает новую приемку товаров для указанного ПВЗ
+	CreateReception(ctx context.Context, in *CreateReceptionRequest, opts ...grpc.CallOption) (*Reception, error)
+	// Приостановить приемку
+	//
+	// Переводит приемку из статуса in_progress в paused. Переоткрытую для исправления приемку
+	// приостановить нельзя, ее можно только закрыть
+	PauseReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error)
+	// Возобновить приемку
+	//
+	// Переводит приостановленную приемку обратно в статус in_progress
+	ResumeReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error)
+	// Закрыть приемку
+	//
+	// Закрывает приемку по ее идентификатору
+	CloseReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error)
+	// Отменить приемку
+	//
+	// Переводит незакрытую приемку в статус cancelled
+	CancelReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error)
+	// Переоткрыть приемку для исправления
+	//
+	// Переводит закрытую приемку в статус reopened_for_correction. Переоткрыть можно только последнюю
+	// приемку ПВЗ и только если у ПВЗ нет другой незакрытой приемки. Доступно только модератору
+	ReopenReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error)
+	// Получить историю статусов приемки
+	//
+	// Возвращает все смены статуса приемки в хронологическом порядке
+	GetReceptionStatusHistory(ctx context.Context, in *ReceptionIdRequest, opts ...grpc.CallOption) (*GetReceptionStatusHistoryResponse, error)
+	// Получить приемки ПВЗ
+	//
+	// Возвращает приемки ПВЗ от новых к старым с фильтрацией по статусу и дате.
+	// В REST ответ дополнительно содержит заголовок Link, поэтому этот маршрут
+	// обслуживается отдельным обработчиком поверх тех же usecases
+	ListPVZReceptions(ctx context.Context, in *ListPVZReceptionsRequest, opts ...grpc.CallOption) (*ListPVZReceptionsResponse, error)
+	// Выгрузить приемки
+	//
+	// Потоково выгружает приемки с товарами в CSV или XLSX.
+	// В REST файл отдается с заголовком Content-Disposition, поэтому этот маршрут
+	// обслуживается отдельным обработчиком поверх тех же usecases
+	ExportReceptions(ctx context.Context, in *ExportReceptionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error)
+}
+
+type receptionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReceptionServiceClient(cc grpc.ClientConnInterface) ReceptionServiceClient {
+	return &receptionServiceClient{cc}
+}
+
+func (c *receptionServiceClient) CreateReception(ctx context.Context, in *CreateReceptionRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, ReceptionService_CreateReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receptionServiceClient) PauseReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, ReceptionService_PauseReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receptionServiceClient) ResumeReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, ReceptionService_ResumeReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receptionServiceClient) CloseReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, ReceptionService_CloseReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receptionServiceClient) CancelReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, ReceptionService_CancelReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receptionServiceClient) ReopenReception(ctx context.Context, in *ChangeReceptionStatusRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, ReceptionService_ReopenReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receptionServiceClient) GetReceptionStatusHistory(ctx context.Context, in *ReceptionIdRequest, opts ...grpc.CallOption) (*GetReceptionStatusHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReceptionStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, ReceptionService_GetReceptionStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receptionServiceClient) ListPVZReceptions(ctx context.Context, in *ListPVZReceptionsRequest, opts ...grpc.CallOption) (*ListPVZReceptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPVZReceptionsResponse)
+	err := c.cc.Invoke(ctx, ReceptionService_ListPVZReceptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receptionServiceClient) ExportReceptions(ctx context.Context, in *ExportReceptionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReceptionService_ServiceDesc.Streams[0], ReceptionService_ExportReceptions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportReceptionsRequest, httpbody.HttpBody]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReceptionService_ExportReceptionsClient = grpc.ServerStreamingClient[httpbody.HttpBody]
+
+// ReceptionServiceServer is the server API for ReceptionService service.
+// All implementations must embed UnimplementedReceptionServiceServer
+// for forward compatibility.
+type ReceptionServiceServer interface {
+	// Создать приемку
+	//
+	// Создает новую приемку товаров для указанного ПВЗ
+	CreateReception(context.Context, *CreateReceptionRequest) (*Reception, error)
+	// Приостановить приемку
+	//
+	// Переводит приемку из статуса in_progress в paused. Переоткрытую для исправления приемку
+	// приостановить нельзя, ее можно только закрыть
+	PauseReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error)
+	// Возобновить приемку
+	//
+	// Переводит приостановленную приемку обратно в статус in_progress
+	ResumeReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error)
+	// Закрыть приемку
+	//
+	// Закрывает приемку по ее идентификатору
+	CloseReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error)
+	// Отменить приемку
+	//
+	// Переводит незакрытую приемку в статус cancelled
+	CancelReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error)
+	// Переоткрыть приемку для исправления
+	//
+	// Переводит закрытую приемку в статус reopened_for_correction. Переоткрыть можно только последнюю
+	// приемку ПВЗ и только если у ПВЗ нет другой незакрытой приемки. Доступно только модератору
+	ReopenReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error)
+	// Получить историю статусов приемки
+	//
+	// Возвращает все смены статуса приемки в хронологическом порядке
+	GetReceptionStatusHistory(context.Context, *ReceptionIdRequest) (*GetReceptionStatusHistoryResponse, error)
+	// Получить приемки ПВЗ
+	//
+	// Возвращает приемки ПВЗ от новых к старым с фильтрацией по статусу и дате.
+	// В REST ответ дополнительно содержит заголовок Link, поэтому этот маршрут
+	// обслуживается отдельным обработчиком поверх тех же usecases
+	ListPVZReceptions(context.Context, *ListPVZReceptionsRequest) (*ListPVZReceptionsResponse, error)
+	// Выгрузить приемки
+	//
+	// Потоково выгружает приемки с товарами в CSV или XLSX.
+	// В REST файл отдается с заголовком Content-Disposition, поэтому этот маршрут
+	// обслуживается отдельным обработчиком поверх тех же usecases
+	ExportReceptions(*ExportReceptionsRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error
+	mustEmbedUnimplementedReceptionServiceServer()
+}
+
+// UnimplementedReceptionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReceptionServiceServer struct{}
+
+func (UnimplementedReceptionServiceServer) CreateReception(context.Context, *CreateReceptionRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReception not implemented")
+}
+func (UnimplementedReceptionServiceServer) PauseReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseReception not implemented")
+}
+func (UnimplementedReceptionServiceServer) ResumeReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeReception not implemented")
+}
+func (UnimplementedReceptionServiceServer) CloseReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseReception not implemented")
+}
+func (UnimplementedReceptionServiceServer) CancelReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReception not implemented")
+}
+func (UnimplementedReceptionServiceServer) ReopenReception(context.Context, *ChangeReceptionStatusRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReopenReception not implemented")
+}
+func (UnimplementedReceptionServiceServer) GetReceptionStatusHistory(context.Context, *ReceptionIdRequest) (*GetReceptionStatusHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceptionStatusHistory not implemented")
+}
+func (UnimplementedReceptionServiceServer) ListPVZReceptions(context.Context, *ListPVZReceptionsRequest) (*ListPVZReceptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPVZReceptions not implemented")
+}
+func (UnimplementedReceptionServiceServer) ExportReceptions(*ExportReceptionsRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportReceptions not implemented")
+}
+func (UnimplementedReceptionServiceServer) mustEmbedUnimplementedReceptionServiceServer() {}
+func (UnimplementedReceptionServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeReceptionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReceptionServiceServer will
+// result in compilation errors.
+type UnsafeReceptionServiceServer interface {
+	mustEmbedUnimplementedReceptionServiceServer()
+}
+
+func RegisterReceptionServiceServer(s grpc.ServiceRegistrar, srv ReceptionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReceptionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReceptionService_ServiceDesc, srv)
+}
+
+func _ReceptionService_CreateReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).CreateReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_CreateReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).CreateReception(ctx, req.(*CreateReceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceptionService_PauseReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeReceptionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).PauseReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_PauseReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).PauseReception(ctx, req.(*ChangeReceptionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceptionService_ResumeReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeReceptionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).ResumeReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_ResumeReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).ResumeReception(ctx, req.(*ChangeReceptionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceptionService_CloseReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeReceptionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).CloseReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_CloseReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).CloseReception(ctx, req.(*ChangeReceptionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceptionService_CancelReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeReceptionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).CancelReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_CancelReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).CancelReception(ctx, req.(*ChangeReceptionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceptionService_ReopenReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeReceptionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).ReopenReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_ReopenReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).ReopenReception(ctx, req.(*ChangeReceptionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceptionService_GetReceptionStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceptionIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).GetReceptionStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_GetReceptionStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).GetReceptionStatusHistory(ctx, req.(*ReceptionIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceptionService_ListPVZReceptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPVZReceptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).ListPVZReceptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_ListPVZReceptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).ListPVZReceptions(ctx, req.(*ListPVZReceptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceptionService_ExportReceptions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportReceptionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReceptionServiceServer).ExportReceptions(m, &grpc.GenericServerStream[ExportReceptionsRequest, httpbody.HttpBody]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReceptionService_ExportReceptionsServer = grpc.ServerStreamingServer[httpbody.HttpBody]
+
+// ReceptionService_ServiceDesc is the grpc.ServiceDesc for ReceptionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReceptionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pvz.v1.ReceptionService",
+	HandlerType: (*ReceptionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateReception",
+			Handler:    _ReceptionService_CreateReception_Handler,
+		},
+		{
+			MethodName: "PauseReception",
+			Handler:    _ReceptionService_PauseReception_Handler,
+		},
+		{
+			MethodName: "ResumeReception",
+			Handler:    _ReceptionService_ResumeReception_Handler,
+		},
+		{
+			MethodName: "CloseReception",
+			Handler:    _ReceptionService_CloseReception_Handler,
+		},
+		{
+			MethodName: "CancelReception",
+			Handler:    _ReceptionService_CancelReception_Handler,
+		},
+		{
+			MethodName: "ReopenReception",
+			Handler:    _ReceptionService_ReopenReception_Handler,
+		},
+		{
+			MethodName: "GetReceptionStatusHistory",
+			Handler:    _ReceptionService_GetReceptionStatusHistory_Handler,
+		},
+		{
+			MethodName: "ListPVZReceptions",
+			Handler:    _ReceptionService_ListPVZReceptions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportReceptions",
+			Handler:       _ReceptionService_ExportReceptions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pvz.proto",
+}
+
+const (
+	ProductService_AddProduct_FullMethodName            = "/pvz.v1.ProductService/AddProduct"
+	ProductService_ListReceptionProducts_FullMethodName = "/pvz.v1.ProductService/ListReceptionProducts"
+)
+
+// ProductServiceClient is the client API for ProductService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProductServiceClient interface {
+	// Добавить товар в приемку
+	//
+	// Добавляет товар в последнюю активную приемку указанного ПВЗ
+	AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*Product, error)
+	// Получить товары приемки
+	//
+	// Возвращает товары приемки постранично. Для следующей страницы передайте nextCursor из ответа
+	ListReceptionProducts(ctx context.Context, in *ListReceptionProductsRequest, opts ...grpc.CallOption) (*ListReceptionProductsResponse, error)
 }
 
-type pVZServiceClient struct {
+type productServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewPVZServiceClient(cc grpc.ClientConnInterface) PVZServiceClient {
-	return &pVZServiceClient{cc}
+func NewProductServiceClient(cc grpc.ClientConnInterface) ProductServiceClient {
+	return &productServiceClient{cc}
 }
 
-func (c *pVZServiceClient) GetPVZList(ctx context.Context, in *GetPVZListRequest, opts ...grpc.CallOption) (*GetPVZListResponse, error) {
+func (c *productServiceClient) AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*Product, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetPVZListResponse)
-	err := c.cc.Invoke(ctx, PVZService_GetPVZList_FullMethodName, in, out, cOpts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, ProductService_AddProduct_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// PVZServiceServer is the server API for PVZService service.
-// All implementations must embed UnimplementedPVZServiceServer
+func (c *productServiceClient) ListReceptionProducts(ctx context.Context, in *ListReceptionProductsRequest, opts ...grpc.CallOption) (*ListReceptionProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReceptionProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListReceptionProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProductServiceServer is the server API for ProductService service.
+// All implementations must embed UnimplementedProductServiceServer
 // for forward compatibility.
-type PVZServiceServer interface {
-	GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error)
-	mustEmbedUnimplementedPVZServiceServer()
+type ProductServiceServer interface {
+	// Добавить товар в приемку
+	//
+	// Добавляет товар в последнюю активную приемку указанного ПВЗ
+	AddProduct(context.Context, *AddProductRequest) (*Product, error)
+	// Получить товары приемки
+	//
+	// Возвращает товары приемки постранично. Для следующей страницы передайте nextCursor из ответа
+	ListReceptionProducts(context.Context, *ListReceptionProductsRequest) (*ListReceptionProductsResponse, error)
+	mustEmbedUnimplementedProductServiceServer()
 }
 
-// UnimplementedPVZServiceServer must be embedded to have
+// UnimplementedProductServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedPVZServiceServer struct{}
+type UnimplementedProductServiceServer struct{}
 
-func (UnimplementedPVZServiceServer) GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPVZList not implemented")
+func (UnimplementedProductServiceServer) AddProduct(context.Context, *AddProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProduct not implemented")
 }
-func (UnimplementedPVZServiceServer) mustEmbedUnimplementedPVZServiceServer() {}
-func (UnimplementedPVZServiceServer) testEmbeddedByValue()                    {}
+func (UnimplementedProductServiceServer) ListReceptionProducts(context.Context, *ListReceptionProductsRequest) (*ListReceptionProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReceptionProducts not implemented")
+}
+func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
+func (UnimplementedProductServiceServer) testEmbeddedByValue()                        {}
 
-// UnsafePVZServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PVZServiceServer will
+// UnsafeProductServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProductServiceServer will
 // result in compilation errors.
-type UnsafePVZServiceServer interface {
-	mustEmbedUnimplementedPVZServiceServer()
+type UnsafeProductServiceServer interface {
+	mustEmbedUnimplementedProductServiceServer()
 }
 
-func RegisterPVZServiceServer(s grpc.ServiceRegistrar, srv PVZServiceServer) {
-	// If the following call pancis, it indicates UnimplementedPVZServiceServer was
+func RegisterProductServiceServer(s grpc.ServiceRegistrar, srv ProductServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProductServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&PVZService_ServiceDesc, srv)
+	s.RegisterService(&ProductService_ServiceDesc, srv)
 }
 
-func _PVZService_GetPVZList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPVZListRequest)
+func _ProductService_AddProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProductRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PVZServiceServer).GetPVZList(ctx, in)
+		return srv.(ProductServiceServer).AddProduct(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PVZService_GetPVZList_FullMethodName,
+		FullMethod: ProductService_AddProduct_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PVZServiceServer).GetPVZList(ctx, req.(*GetPVZListRequest))
+		return srv.(ProductServiceServer).AddProduct(ctx, req.(*AddProductRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// PVZService_ServiceDesc is the grpc.ServiceDesc for PVZService service.
+func _ProductService_ListReceptionProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReceptionProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListReceptionProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListReceptionProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListReceptionProducts(ctx, req.(*ListReceptionProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProductService_ServiceDesc is the grpc.ServiceDesc for ProductService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var PVZService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pvz.v1.PVZService",
-	HandlerType: (*PVZServiceServer)(nil),
+var ProductService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pvz.v1.ProductService",
+	HandlerType: (*ProductServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetPVZList",
-			Handler:    _PVZService_GetPVZList_Handler,
+			MethodName: "AddProduct",
+			Handler:    _ProductService_AddProduct_Handler,
+		},
+		{
+			MethodName: "ListReceptionProducts",
+			Handler:    _ProductService_ListReceptionProducts_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
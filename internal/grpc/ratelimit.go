@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	retryAfterMetadataKey = "retry-after"
+
+	// health check оркестратора не должен упираться в лимиты API
+	healthServicePrefix = "/grpc.health.v1."
+)
+
+// checkRateLimit списывает токен для вызова и при отказе отправляет клиенту
+// retry-after в заголовках ответа
+func checkRateLimit(ctx context.Context, limiter *ratelimit.Limiter, fullMethod string) error {
+	if strings.HasPrefix(fullMethod, healthServicePrefix) {
+		return nil
+	}
+
+	principal := ratelimit.Principal(userIdFromContext(ctx), middlewares.ClientIPFromContext(ctx))
+
+	res := limiter.Allow(ctx, fullMethod, principal)
+	if res.Allowed {
+		return nil
+	}
+
+	retryAfter := strconv.Itoa(ratelimit.RetryAfterSeconds(res.RetryAfter))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterMetadataKey, retryAfter))
+
+	return status.Error(codes.ResourceExhausted, "Слишком много запросов")
+}
+
+// UnaryRateLimitInterceptor ограничивает частоту унарных вызовов. Ставится
+// после интерсепторов адреса клиента и авторизации, чтобы лимит считался по
+// пользователю, а для публичных методов — по IP адресу
+func UnaryRateLimitInterceptor(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkRateLimit(ctx, limiter, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor ограничивает частоту открытия потоков
+func StreamRateLimitInterceptor(limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkRateLimit(ss.Context(), limiter, info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryRateLimitInterceptor(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Config{
+		Default: ratelimit.Limit{Rate: 1, Burst: 1},
+	}, ratelimit.NewMemory())
+	interceptor := UnaryRateLimitInterceptor(limiter)
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	call := func(ctx context.Context, fullMethod string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+		return err
+	}
+
+	token, err := middlewares.CreateToken("user1", dto.RoleEmployee)
+	require.NoError(t, err)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	ctx = withClientIP(ctx, limiter.ClientIP)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authMetadataKey, "Bearer "+token))
+	ctx, err = authenticate(ctx, nil)
+	require.NoError(t, err)
+
+	const method = "/pvz.v1.ProductService/AddProduct"
+	require.NoError(t, call(ctx, method))
+
+	err = call(ctx, method)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// лимит считается по пользователю, у вызова без токена с того же адреса свой бакет
+	anonymous := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5001}})
+	anonymous = withClientIP(anonymous, limiter.ClientIP)
+	assert.NoError(t, call(anonymous, method))
+
+	// health check не ограничивается
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, call(ctx, "/grpc.health.v1.Health/Check"))
+	}
+}
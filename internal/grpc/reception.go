@@ -0,0 +1,262 @@
+//go:generate mockgen -source=reception.go -destination=./mocks/mock_reception.go -package=mocks
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/hamillka/avitoTechSpring25/internal/export"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultReceptionLimit = 10
+	maxReceptionLimit     = 30
+	exportChunkSize       = 64 * 1024
+)
+
+type ReceptionService interface {
+	CreateReception(ctx context.Context, pvzId string) (models.Reception, error)
+	ChangeReceptionStatus(ctx context.Context, recId, status, role, reason string) (models.Reception, error)
+	GetStatusHistory(ctx context.Context, recId string) ([]models.ReceptionStatusChange, error)
+	GetPVZReceptions(ctx context.Context, pvzId string, filter models.ReceptionListFilter, page, limit int) ([]models.Reception, error)
+	ExportReceptions(ctx context.Context, filter models.PVZFilter, fn func(row models.ReceptionExportRow) error) error
+}
+
+type ReceptionServer struct {
+	pvz_v1.UnimplementedReceptionServiceServer
+	service ReceptionService
+	logger  *zap.SugaredLogger
+}
+
+func NewReceptionServer(s ReceptionService, logger *zap.SugaredLogger) *ReceptionServer {
+	return &ReceptionServer{
+		service: s,
+		logger:  logger,
+	}
+}
+
+var errReceptionNotFound = status.Error(codes.InvalidArgument, "Приемка не найдена")
+
+func (s *ReceptionServer) CreateReception(ctx context.Context, req *pvz_v1.CreateReceptionRequest) (*pvz_v1.Reception, error) {
+	if role := roleFromContext(ctx); role != dto.RoleEmployee {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return nil, errForbidden
+	}
+
+	reception, err := s.service.CreateReception(ctx, req.GetPvzId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to create reception: %v", err)
+		switch {
+		case errors.Is(err, dto.ErrPVZNotFound):
+			return nil, status.Error(codes.InvalidArgument, "ПВЗ не найден")
+		case errors.Is(err, dto.ErrPVZAlreadyHasReception):
+			return nil, status.Error(codes.FailedPrecondition, "ПВЗ уже имеет незакрытую приемку")
+		case errors.Is(err, dto.ErrPVZNotActive):
+			return nil, status.Error(codes.FailedPrecondition, "ПВЗ деактивирован или находится в архиве")
+		default:
+			return nil, errInternal
+		}
+	}
+
+	return receptionToProto(reception), nil
+}
+
+func (s *ReceptionServer) PauseReception(ctx context.Context, req *pvz_v1.ChangeReceptionStatusRequest) (*pvz_v1.Reception, error) {
+	return s.changeReceptionStatus(ctx, req, models.PAUSED)
+}
+
+func (s *ReceptionServer) ResumeReception(ctx context.Context, req *pvz_v1.ChangeReceptionStatusRequest) (*pvz_v1.Reception, error) {
+	return s.changeReceptionStatus(ctx, req, models.INPROGRESS)
+}
+
+func (s *ReceptionServer) CloseReception(ctx context.Context, req *pvz_v1.ChangeReceptionStatusRequest) (*pvz_v1.Reception, error) {
+	return s.changeReceptionStatus(ctx, req, models.CLOSE)
+}
+
+func (s *ReceptionServer) CancelReception(ctx context.Context, req *pvz_v1.ChangeReceptionStatusRequest) (*pvz_v1.Reception, error) {
+	return s.changeReceptionStatus(ctx, req, models.CANCELLED)
+}
+
+func (s *ReceptionServer) ReopenReception(ctx context.Context, req *pvz_v1.ChangeReceptionStatusRequest) (*pvz_v1.Reception, error) {
+	return s.changeReceptionStatus(ctx, req, models.REOPENED)
+}
+
+func (s *ReceptionServer) changeReceptionStatus(
+	ctx context.Context,
+	req *pvz_v1.ChangeReceptionStatusRequest,
+	receptionStatus string,
+) (*pvz_v1.Reception, error) {
+	role := roleFromContext(ctx)
+
+	reception, err := s.service.ChangeReceptionStatus(ctx, req.GetReceptionId(), receptionStatus, role, req.GetReason())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to change reception status to %s: %v", receptionStatus, err)
+		switch {
+		case errors.Is(err, dto.ErrReceptionNotFound):
+			return nil, errReceptionNotFound
+		case errors.Is(err, dto.ErrInvalidStatusChange):
+			return nil, status.Error(codes.FailedPrecondition, "Недопустимая смена статуса приемки")
+		case errors.Is(err, dto.ErrStatusChangeForbidden):
+			return nil, errForbidden
+		case errors.Is(err, dto.ErrReceptionNotLast):
+			return nil, status.Error(codes.FailedPrecondition, "Переоткрыть можно только последнюю приемку ПВЗ")
+		case errors.Is(err, dto.ErrPVZAlreadyHasReception):
+			return nil, status.Error(codes.FailedPrecondition, "ПВЗ уже имеет незакрытую приемку")
+		default:
+			return nil, errInternal
+		}
+	}
+
+	return receptionToProto(reception), nil
+}
+
+func (s *ReceptionServer) GetReceptionStatusHistory(
+	ctx context.Context,
+	req *pvz_v1.ReceptionIdRequest,
+) (*pvz_v1.GetReceptionStatusHistoryResponse, error) {
+	history, err := s.service.GetStatusHistory(ctx, req.GetReceptionId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to get reception status history: %v", err)
+		if errors.Is(err, dto.ErrReceptionNotFound) {
+			return nil, errReceptionNotFound
+		}
+		return nil, errInternal
+	}
+
+	response := &pvz_v1.GetReceptionStatusHistoryResponse{
+		Changes: make([]*pvz_v1.ReceptionStatusChange, 0, len(history)),
+	}
+	for _, change := range history {
+		response.Changes = append(response.Changes, statusChangeToProto(change))
+	}
+	return response, nil
+}
+
+func (s *ReceptionServer) ListPVZReceptions(
+	ctx context.Context,
+	req *pvz_v1.ListPVZReceptionsRequest,
+) (*pvz_v1.ListPVZReceptionsResponse, error) {
+	if !allAllowed(req.GetStatus(), dto.ReceptionStatuses) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid reception status: %v", req.GetStatus())
+		return nil, invalidParam("status")
+	}
+
+	startDate, endDate, err := parseDateRange(req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid date range: %v", err)
+		return nil, dateRangeError(err)
+	}
+
+	page, limit, err := pageParams(req.Page, req.Limit, defaultReceptionLimit, maxReceptionLimit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid pagination params: %v", err)
+		return nil, err
+	}
+
+	filter := models.ReceptionListFilter{
+		Statuses:  req.GetStatus(),
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	receptions, err := s.service.GetPVZReceptions(ctx, req.GetPvzId(), filter, page, limit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to get pvz receptions: %v", err)
+		if errors.Is(err, dto.ErrPVZNotFound) {
+			return nil, errPVZNotFound
+		}
+		return nil, errInternal
+	}
+
+	return &pvz_v1.ListPVZReceptionsResponse{Receptions: receptionsToProto(receptions)}, nil
+}
+
+// exportSender режет выгрузку на сообщения HttpBody не больше exportChunkSize
+type exportSender struct {
+	stream      grpc.ServerStreamingServer[httpbody.HttpBody]
+	contentType string
+	sent        bool
+}
+
+func (es *exportSender) Write(p []byte) (int, error) {
+	for offset := 0; offset < len(p); offset += exportChunkSize {
+		end := min(offset+exportChunkSize, len(p))
+		chunk := &httpbody.HttpBody{Data: slices.Clone(p[offset:end])}
+		if !es.sent {
+			chunk.ContentType = es.contentType
+		}
+
+		if err := es.stream.Send(chunk); err != nil {
+			return offset, err
+		}
+		es.sent = true
+	}
+
+	return len(p), nil
+}
+
+func (s *ReceptionServer) ExportReceptions(
+	req *pvz_v1.ExportReceptionsRequest,
+	stream grpc.ServerStreamingServer[httpbody.HttpBody],
+) error {
+	ctx := stream.Context()
+
+	format := export.FormatCSV
+	if req.GetFormat() != "" {
+		format = req.GetFormat()
+	}
+	if format != export.FormatCSV && format != export.FormatXLSX {
+		logger.FromContext(ctx, s.logger).Errorf("invalid export format: %v", format)
+		return invalidParam("format")
+	}
+
+	startDate, endDate, err := parseDateRange(req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid date range: %v", err)
+		return dateRangeError(err)
+	}
+
+	if !allAllowed(req.GetCity(), dto.Cities) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid city: %v", req.GetCity())
+		return invalidParam("city")
+	}
+
+	filter := models.PVZFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Cities:    req.GetCity(),
+		PVZIds:    req.GetPvzId(),
+	}
+
+	writer, err := export.NewWriter(format, &exportSender{stream: stream, contentType: export.ContentType(format)})
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to create export writer: %v", err)
+		return errInternal
+	}
+
+	err = writer.Write(export.Header)
+	if err == nil {
+		err = s.service.ExportReceptions(ctx, filter, func(row models.ReceptionExportRow) error {
+			return writer.Write(export.Record(row))
+		})
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to export receptions: %v", err)
+		return errInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/grpc/mocks"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateReception_Forbidden(t *testing.T) {
+	server := NewReceptionServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.CreateReception(withRole(dto.RoleModerator), &pvz_v1.CreateReceptionRequest{PvzId: "pvz1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCreateReception_ServiceErrors(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		code codes.Code
+	}{
+		"pvz not found":    {dto.ErrPVZNotFound, codes.InvalidArgument},
+		"already has":      {dto.ErrPVZAlreadyHasReception, codes.FailedPrecondition},
+		"internal failure": {errors.New("db failure"), codes.Internal},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			service := mocks.NewMockReceptionService(ctrl)
+			server := NewReceptionServer(service, zaptest.NewLogger(t).Sugar())
+
+			service.EXPECT().CreateReception(gomock.Any(), "pvz1").Return(models.Reception{}, tc.err)
+
+			_, err := server.CreateReception(withRole(dto.RoleEmployee), &pvz_v1.CreateReceptionRequest{PvzId: "pvz1"})
+			assert.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func TestCreateReception_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockReceptionService(ctrl)
+	server := NewReceptionServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().CreateReception(gomock.Any(), "pvz1").
+		Return(models.Reception{Id: "rec123", PVZId: "pvz1", Status: models.INPROGRESS}, nil)
+
+	resp, err := server.CreateReception(withRole(dto.RoleEmployee), &pvz_v1.CreateReceptionRequest{PvzId: "pvz1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rec123", resp.GetId())
+	assert.Equal(t, models.INPROGRESS, resp.GetStatus())
+}
+
+func TestPauseReception_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockReceptionService(ctrl)
+	server := NewReceptionServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().ChangeReceptionStatus(gomock.Any(), "rec1", models.PAUSED, dto.RoleEmployee, "").
+		Return(models.Reception{Id: "rec1", Status: models.PAUSED}, nil)
+
+	_, err := server.PauseReception(withRole(dto.RoleEmployee), &pvz_v1.ChangeReceptionStatusRequest{ReceptionId: "rec1"})
+	assert.NoError(t, err)
+}
+
+func TestReopenReception_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockReceptionService(ctrl)
+	server := NewReceptionServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().ChangeReceptionStatus(gomock.Any(), "rec1", models.REOPENED, dto.RoleEmployee, "typo").
+		Return(models.Reception{}, dto.ErrStatusChangeForbidden)
+
+	_, err := server.ReopenReception(withRole(dto.RoleEmployee), &pvz_v1.ChangeReceptionStatusRequest{
+		ReceptionId: "rec1",
+		Reason:      "typo",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestReopenReception_NotLast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockReceptionService(ctrl)
+	server := NewReceptionServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().ChangeReceptionStatus(gomock.Any(), "rec1", models.REOPENED, dto.RoleModerator, "").
+		Return(models.Reception{}, dto.ErrReceptionNotLast)
+
+	_, err := server.ReopenReception(withRole(dto.RoleModerator), &pvz_v1.ChangeReceptionStatusRequest{ReceptionId: "rec1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestCancelReception_InvalidTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockReceptionService(ctrl)
+	server := NewReceptionServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().ChangeReceptionStatus(gomock.Any(), "rec1", models.CANCELLED, dto.RoleModerator, "").
+		Return(models.Reception{}, dto.ErrInvalidStatusChange)
+
+	_, err := server.CancelReception(withRole(dto.RoleModerator), &pvz_v1.ChangeReceptionStatusRequest{ReceptionId: "rec1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestGetReceptionStatusHistory_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockReceptionService(ctrl)
+	server := NewReceptionServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().GetStatusHistory(gomock.Any(), "rec1").Return([]models.ReceptionStatusChange{
+		{ReceptionId: "rec1", FromStatus: models.INPROGRESS, ToStatus: models.CLOSE, ChangedByRole: dto.RoleEmployee},
+	}, nil)
+
+	resp, err := server.GetReceptionStatusHistory(context.Background(), &pvz_v1.ReceptionIdRequest{ReceptionId: "rec1"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetChanges(), 1)
+}
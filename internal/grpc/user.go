@@ -0,0 +1,247 @@
+//go:generate mockgen -source=user.go -destination=./mocks/mock_user.go -package=mocks
+package grpc
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/hamillka/avitoTechSpring25/internal/ratelimit"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type UserService interface {
+	UserRegister(ctx context.Context, email, password, role string) (models.User, error)
+	UserLogin(ctx context.Context, email, password, ip string) (models.User, error)
+	UnlockUser(ctx context.Context, userId string) (models.User, error)
+	ChangePassword(ctx context.Context, userId, oldPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	CreateUser(ctx context.Context, actorId, email, password, role string) (models.User, error)
+	ListUsers(ctx context.Context, filter models.UserListFilter, page, limit int) ([]models.User, int, error)
+	GetUser(ctx context.Context, userId string) (models.User, error)
+	DisableUser(ctx context.Context, actorId, userId string) (models.User, error)
+	EnableUser(ctx context.Context, actorId, userId string) (models.User, error)
+	ChangeUserRole(ctx context.Context, actorId, userId, role string) (models.User, error)
+	ActiveUserRole(ctx context.Context, userId string) (string, error)
+}
+
+type UserServer struct {
+	pvz_v1.UnimplementedUserServiceServer
+	service UserService
+	logger  *zap.SugaredLogger
+}
+
+func NewUserServer(s UserService, logger *zap.SugaredLogger) *UserServer {
+	return &UserServer{
+		service: s,
+		logger:  logger,
+	}
+}
+
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
+func validateEmail(email string) bool {
+	return emailRegex.MatchString(email)
+}
+
+func validRole(role string) bool {
+	return role == dto.RoleEmployee || role == dto.RoleModerator
+}
+
+var (
+	errInvalidEmail   = status.Error(codes.InvalidArgument, "Неверный формат электронной почты")
+	errInvalidRequest = status.Error(codes.InvalidArgument, "Неверный запрос")
+	errTokenCreation  = status.Error(codes.Internal, "Внутренняя ошибка сервера при авторизации")
+	errUserDisabled   = status.Error(codes.PermissionDenied, "Учетная запись отключена")
+	errUserNotFound   = status.Error(codes.NotFound, "Пользователь не найден")
+)
+
+func (s *UserServer) Login(ctx context.Context, req *pvz_v1.LoginRequest) (*pvz_v1.LoginResponse, error) {
+	if !validateEmail(req.GetEmail()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid email format: %v", req.GetEmail())
+		return nil, errInvalidEmail
+	}
+
+	user, err := s.service.UserLogin(ctx, req.GetEmail(), req.GetPassword(), middlewares.ClientIPFromContext(ctx))
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to login user: %v", err)
+		return nil, loginError(ctx, err)
+	}
+
+	t, err := middlewares.CreateToken(user.Id, user.Role)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to create token: %v", err)
+		return nil, errTokenCreation
+	}
+
+	return &pvz_v1.LoginResponse{Token: t}, nil
+}
+
+// loginError отвечает на заблокированный вход кодом RESOURCE_EXHAUSTED
+// с retry-after, как и ограничение частоты запросов
+func loginError(ctx context.Context, err error) error {
+	if errors.Is(err, dto.ErrUserDisabled) {
+		return errUserDisabled
+	}
+
+	var blocked *dto.LoginBlockedError
+	if !errors.As(err, &blocked) {
+		return status.Error(codes.Unauthenticated, "Неверные учетные данные")
+	}
+
+	retryAfter := strconv.Itoa(ratelimit.RetryAfterSeconds(blocked.RetryAfter))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterMetadataKey, retryAfter))
+
+	if errors.Is(err, dto.ErrAccountLocked) {
+		return status.Error(codes.ResourceExhausted, "Учетная запись временно заблокирована")
+	}
+	return status.Error(codes.ResourceExhausted, "Слишком много неудачных попыток входа")
+}
+
+func (s *UserServer) UnlockUser(ctx context.Context, req *pvz_v1.UserIdRequest) (*pvz_v1.User, error) {
+	if err := s.requireModerator(ctx, &req.UserId); err != nil {
+		return nil, err
+	}
+
+	user, err := s.service.UnlockUser(ctx, req.GetUserId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to unlock user: %v", err)
+		if errors.Is(err, dto.ErrUserNotFound) {
+			return nil, errUserNotFound
+		}
+		return nil, errInternal
+	}
+
+	return userToProto(user), nil
+}
+
+func (s *UserServer) Register(ctx context.Context, req *pvz_v1.RegisterRequest) (*pvz_v1.User, error) {
+	if !validateEmail(req.GetEmail()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid email format: %v", req.GetEmail())
+		return nil, errInvalidEmail
+	}
+
+	role := dto.RoleEmployee
+	if req.GetRole() != "" {
+		role = req.GetRole()
+	}
+	if !validRole(role) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid role: %v", role)
+		return nil, errInvalidRequest
+	}
+
+	user, err := s.service.UserRegister(ctx, req.GetEmail(), req.GetPassword(), role)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to register user: %v", err)
+		if err := weakPasswordError(err); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case errors.Is(err, dto.ErrRegistrationClosed):
+			return nil, status.Error(codes.PermissionDenied, "Самостоятельная регистрация отключена")
+		case errors.Is(err, dto.ErrRoleNotAllowed):
+			return nil, status.Error(codes.PermissionDenied, "Самостоятельно можно зарегистрироваться только сотрудником")
+		default:
+			return nil, errInvalidRequest
+		}
+	}
+
+	return userToProto(user), nil
+}
+
+func (s *UserServer) DummyLogin(ctx context.Context, req *pvz_v1.DummyLoginRequest) (*pvz_v1.LoginResponse, error) {
+	if !validRole(req.GetRole()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid role: %v", req.GetRole())
+		return nil, errInvalidRequest
+	}
+
+	t, err := middlewares.CreateToken("", req.GetRole())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to create token: %v", err)
+		return nil, errTokenCreation
+	}
+
+	return &pvz_v1.LoginResponse{Token: t}, nil
+}
+
+// weakPasswordError перечисляет клиенту нарушенные требования к паролю.
+// Для остальных ошибок возвращает nil
+func weakPasswordError(err error) error {
+	var weak *dto.WeakPasswordError
+	if !errors.As(err, &weak) {
+		return nil
+	}
+
+	return status.Error(codes.InvalidArgument,
+		"Пароль не соответствует требованиям: "+strings.Join(weak.Violations, ", "))
+}
+
+func (s *UserServer) ChangePassword(ctx context.Context, req *pvz_v1.ChangePasswordRequest) (*emptypb.Empty, error) {
+	err := s.service.ChangePassword(ctx, userIdFromContext(ctx), req.GetOldPassword(), req.GetNewPassword())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to change password: %v", err)
+		if err := weakPasswordError(err); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case errors.Is(err, dto.ErrPasswordChangeDenied):
+			return nil, status.Error(codes.PermissionDenied, "Смена пароля доступна только зарегистрированным пользователям")
+		case errors.Is(err, dto.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "Неверный текущий пароль")
+		case errors.Is(err, dto.ErrUserNotFound):
+			return nil, errUserNotFound
+		default:
+			return nil, errInternal
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserServer) RequestPasswordReset(
+	ctx context.Context,
+	req *pvz_v1.RequestPasswordResetRequest,
+) (*emptypb.Empty, error) {
+	if !validateEmail(req.GetEmail()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid email format: %v", req.GetEmail())
+		return nil, errInvalidEmail
+	}
+
+	if err := s.service.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to request password reset: %v", err)
+		return nil, errInternal
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserServer) ResetPassword(ctx context.Context, req *pvz_v1.ResetPasswordRequest) (*emptypb.Empty, error) {
+	if err := s.service.ResetPassword(ctx, req.GetToken(), req.GetNewPassword()); err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to reset password: %v", err)
+		if err := weakPasswordError(err); err != nil {
+			return nil, err
+		}
+
+		if errors.Is(err, dto.ErrInvalidResetToken) {
+			return nil, status.Error(codes.InvalidArgument, "Токен сброса пароля недействителен или истек")
+		}
+		return nil, errInternal
+	}
+
+	return &emptypb.Empty{}, nil
+}
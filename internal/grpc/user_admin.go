@@ -0,0 +1,199 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultUserLimit = 10
+	maxUserLimit     = 30
+)
+
+// requireModerator проверяет роль и идентификатор пользователя из запроса
+// методов управления пользователями. Роли из токена недостаточно: токены
+// dummyLogin не привязаны к пользователю, поэтому модератор должен быть
+// действующей учетной записью в базе
+func (s *UserServer) requireModerator(ctx context.Context, userId *string) error {
+	if role := roleFromContext(ctx); role != dto.RoleModerator {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return errForbidden
+	}
+
+	actorId := userIdFromContext(ctx)
+	if actorId == "" {
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : token without user")
+		return errForbidden
+	}
+
+	role, err := s.service.ActiveUserRole(ctx, actorId)
+	switch {
+	case errors.Is(err, dto.ErrUserNotFound), errors.Is(err, dto.ErrUserDisabled):
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : inactive user %v: %v", actorId, err)
+		return errForbidden
+	case err != nil:
+		logger.FromContext(ctx, s.logger).Errorf("failed to check user %v: %v", actorId, err)
+		return errInternal
+	case role != dto.RoleModerator:
+		logger.FromContext(ctx, s.logger).Errorf("forbidden action : invalid role: %v", role)
+		return errForbidden
+	}
+
+	if userId == nil {
+		return nil
+	}
+
+	if _, err := uuid.Parse(*userId); err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid user id: %v", *userId)
+		return invalidParam("userId")
+	}
+
+	return nil
+}
+
+// userAdminError переводит ошибки управления пользователями в коды gRPC
+func userAdminError(err error) error {
+	if err := weakPasswordError(err); err != nil {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, dto.ErrUserNotFound):
+		return errUserNotFound
+	case errors.Is(err, dto.ErrUserAlreadyExists):
+		return status.Error(codes.InvalidArgument, "Пользователь с такой почтой уже существует")
+	case errors.Is(err, dto.ErrSelfModification):
+		return status.Error(codes.FailedPrecondition, "Нельзя изменить собственную учетную запись")
+	default:
+		return errInternal
+	}
+}
+
+func (s *UserServer) ListUsers(ctx context.Context, req *pvz_v1.ListUsersRequest) (*pvz_v1.ListUsersResponse, error) {
+	if err := s.requireModerator(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	if req.GetRole() != "" && !validRole(req.GetRole()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid role: %v", req.GetRole())
+		return nil, invalidParam("role")
+	}
+
+	page, limit, err := pageParams(req.Page, req.Limit, defaultUserLimit, maxUserLimit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("invalid pagination params: %v", err)
+		return nil, err
+	}
+
+	filter := models.UserListFilter{
+		Role:     req.GetRole(),
+		Disabled: req.Disabled,
+	}
+
+	users, total, err := s.service.ListUsers(ctx, filter, page, limit)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to list users: %v", err)
+		return nil, errInternal
+	}
+
+	return &pvz_v1.ListUsersResponse{
+		Items:   usersToProto(users),
+		Page:    int32(page),
+		Limit:   int32(limit),
+		Total:   int32(total),
+		HasNext: page*limit < total,
+	}, nil
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *pvz_v1.UserIdRequest) (*pvz_v1.User, error) {
+	if err := s.requireModerator(ctx, &req.UserId); err != nil {
+		return nil, err
+	}
+
+	user, err := s.service.GetUser(ctx, req.GetUserId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to get user: %v", err)
+		return nil, userAdminError(err)
+	}
+
+	return userToProto(user), nil
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *pvz_v1.CreateUserRequest) (*pvz_v1.User, error) {
+	if err := s.requireModerator(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	if !validateEmail(req.GetEmail()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid email format: %v", req.GetEmail())
+		return nil, errInvalidEmail
+	}
+
+	if !validRole(req.GetRole()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid role: %v", req.GetRole())
+		return nil, invalidParam("role")
+	}
+
+	user, err := s.service.CreateUser(ctx, userIdFromContext(ctx), req.GetEmail(), req.GetPassword(), req.GetRole())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to create user: %v", err)
+		return nil, userAdminError(err)
+	}
+
+	return userToProto(user), nil
+}
+
+func (s *UserServer) DisableUser(ctx context.Context, req *pvz_v1.UserIdRequest) (*pvz_v1.User, error) {
+	if err := s.requireModerator(ctx, &req.UserId); err != nil {
+		return nil, err
+	}
+
+	user, err := s.service.DisableUser(ctx, userIdFromContext(ctx), req.GetUserId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to disable user: %v", err)
+		return nil, userAdminError(err)
+	}
+
+	return userToProto(user), nil
+}
+
+func (s *UserServer) EnableUser(ctx context.Context, req *pvz_v1.UserIdRequest) (*pvz_v1.User, error) {
+	if err := s.requireModerator(ctx, &req.UserId); err != nil {
+		return nil, err
+	}
+
+	user, err := s.service.EnableUser(ctx, userIdFromContext(ctx), req.GetUserId())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to enable user: %v", err)
+		return nil, userAdminError(err)
+	}
+
+	return userToProto(user), nil
+}
+
+func (s *UserServer) ChangeUserRole(ctx context.Context, req *pvz_v1.ChangeUserRoleRequest) (*pvz_v1.User, error) {
+	if err := s.requireModerator(ctx, &req.UserId); err != nil {
+		return nil, err
+	}
+
+	if !validRole(req.GetRole()) {
+		logger.FromContext(ctx, s.logger).Errorf("invalid role: %v", req.GetRole())
+		return nil, invalidParam("role")
+	}
+
+	user, err := s.service.ChangeUserRole(ctx, userIdFromContext(ctx), req.GetUserId(), req.GetRole())
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Errorf("failed to change user role: %v", err)
+		return nil, userAdminError(err)
+	}
+
+	return userToProto(user), nil
+}
@@ -0,0 +1,181 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/grpc/mocks"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	moderatorId = "11111111-1111-1111-1111-111111111111"
+	employeeId  = "22222222-2222-2222-2222-222222222222"
+)
+
+func moderatorContext() context.Context {
+	return context.WithValue(context.Background(), middlewares.Key("props"),
+		jwt.MapClaims{"user_id": moderatorId, "role": dto.RoleModerator})
+}
+
+// expectActiveModerator разрешает проверку модератора из moderatorContext
+func expectActiveModerator(service *mocks.MockUserService) {
+	service.EXPECT().ActiveUserRole(gomock.Any(), moderatorId).Return(dto.RoleModerator, nil).AnyTimes()
+}
+
+func TestRequireModerator_InactiveActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	// токен dummyLogin без пользователя
+	_, err := server.ListUsers(withRole(dto.RoleModerator), &pvz_v1.ListUsersRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	service.EXPECT().ActiveUserRole(gomock.Any(), moderatorId).Return("", dto.ErrUserDisabled)
+	_, err = server.ListUsers(moderatorContext(), &pvz_v1.ListUsersRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	service.EXPECT().ActiveUserRole(gomock.Any(), moderatorId).Return("", dto.ErrUserNotFound)
+	_, err = server.ListUsers(moderatorContext(), &pvz_v1.ListUsersRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	service.EXPECT().ActiveUserRole(gomock.Any(), moderatorId).Return(dto.RoleEmployee, nil)
+	_, err = server.ListUsers(moderatorContext(), &pvz_v1.ListUsersRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+	expectActiveModerator(service)
+
+	_, err := server.ListUsers(withRole(dto.RoleEmployee), &pvz_v1.ListUsersRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.ListUsers(moderatorContext(), &pvz_v1.ListUsersRequest{Role: "admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	disabled := true
+	createdAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	service.EXPECT().ListUsers(gomock.Any(), models.UserListFilter{Role: dto.RoleEmployee, Disabled: &disabled}, 1, 10).
+		Return([]models.User{{Id: employeeId, Role: dto.RoleEmployee, CreatedAt: createdAt, DisabledAt: &createdAt}}, 11, nil)
+
+	resp, err := server.ListUsers(moderatorContext(), &pvz_v1.ListUsersRequest{Role: dto.RoleEmployee, Disabled: &disabled})
+	require.NoError(t, err)
+	require.Len(t, resp.GetItems(), 1)
+	assert.True(t, resp.GetItems()[0].GetDisabled())
+	assert.Equal(t, createdAt, resp.GetItems()[0].GetCreatedAt().AsTime())
+	assert.EqualValues(t, 11, resp.GetTotal())
+	assert.True(t, resp.GetHasNext())
+}
+
+func TestCreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+	expectActiveModerator(service)
+
+	req := &pvz_v1.CreateUserRequest{Email: "mod@mail.com", Password: "Password1", Role: dto.RoleModerator}
+
+	_, err := server.CreateUser(withRole(dto.RoleEmployee), req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.CreateUser(moderatorContext(), &pvz_v1.CreateUserRequest{Email: "mod@mail.com", Role: "admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	service.EXPECT().CreateUser(gomock.Any(), moderatorId, "mod@mail.com", "Password1", dto.RoleModerator).
+		Return(models.User{}, dto.ErrUserAlreadyExists)
+	_, err = server.CreateUser(moderatorContext(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	service.EXPECT().CreateUser(gomock.Any(), moderatorId, "mod@mail.com", "Password1", dto.RoleModerator).
+		Return(models.User{Id: employeeId, Email: "mod@mail.com", Role: dto.RoleModerator}, nil)
+	resp, err := server.CreateUser(moderatorContext(), req)
+	require.NoError(t, err)
+	assert.Equal(t, dto.RoleModerator, resp.GetRole())
+}
+
+func TestDisableUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+	expectActiveModerator(service)
+
+	_, err := server.DisableUser(moderatorContext(), &pvz_v1.UserIdRequest{UserId: "abc"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	service.EXPECT().DisableUser(gomock.Any(), moderatorId, moderatorId).Return(models.User{}, dto.ErrSelfModification)
+	_, err = server.DisableUser(moderatorContext(), &pvz_v1.UserIdRequest{UserId: moderatorId})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	service.EXPECT().DisableUser(gomock.Any(), moderatorId, employeeId).Return(models.User{}, dto.ErrUserNotFound)
+	_, err = server.DisableUser(moderatorContext(), &pvz_v1.UserIdRequest{UserId: employeeId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	now := time.Now()
+	service.EXPECT().DisableUser(gomock.Any(), moderatorId, employeeId).Return(models.User{Id: employeeId, DisabledAt: &now}, nil)
+	resp, err := server.DisableUser(moderatorContext(), &pvz_v1.UserIdRequest{UserId: employeeId})
+	require.NoError(t, err)
+	assert.True(t, resp.GetDisabled())
+}
+
+func TestChangeUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+	expectActiveModerator(service)
+
+	_, err := server.ChangeUserRole(moderatorContext(), &pvz_v1.ChangeUserRoleRequest{UserId: employeeId, Role: "admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	service.EXPECT().ChangeUserRole(gomock.Any(), moderatorId, employeeId, dto.RoleModerator).
+		Return(models.User{Id: employeeId, Role: dto.RoleModerator}, nil)
+	resp, err := server.ChangeUserRole(moderatorContext(), &pvz_v1.ChangeUserRoleRequest{UserId: employeeId, Role: dto.RoleModerator})
+	require.NoError(t, err)
+	assert.Equal(t, dto.RoleModerator, resp.GetRole())
+}
+
+func TestRegister_Restricted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().UserRegister(gomock.Any(), "test@mail.com", "pass", dto.RoleEmployee).Return(models.User{}, dto.ErrRegistrationClosed)
+	_, err := server.Register(context.Background(), &pvz_v1.RegisterRequest{Email: "test@mail.com", Password: "pass"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	service.EXPECT().UserRegister(gomock.Any(), "test@mail.com", "pass", dto.RoleModerator).Return(models.User{}, dto.ErrRoleNotAllowed)
+	_, err = server.Register(context.Background(), &pvz_v1.RegisterRequest{Email: "test@mail.com", Password: "pass", Role: dto.RoleModerator})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestLogin_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().UserLogin(gomock.Any(), "test@mail.com", "pass", "").Return(models.User{}, dto.ErrUserDisabled)
+
+	_, err := server.Login(context.Background(), &pvz_v1.LoginRequest{Email: "test@mail.com", Password: "pass"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,220 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/grpc/mocks"
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogin_InvalidEmail(t *testing.T) {
+	server := NewUserServer(nil, zaptest.NewLogger(t).Sugar())
+	_, err := server.Login(context.Background(), &pvz_v1.LoginRequest{Email: "invalid", Password: "pass"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestLogin_AuthFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().UserLogin(gomock.Any(), "test@mail.com", "pass", "").Return(models.User{}, errors.New("unauthorized"))
+
+	_, err := server.Login(context.Background(), &pvz_v1.LoginRequest{Email: "test@mail.com", Password: "pass"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestLogin_Blocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	ctx := middlewares.WithClientIP(context.Background(), "10.0.0.1")
+	service.EXPECT().UserLogin(gomock.Any(), "test@mail.com", "pass", "10.0.0.1").
+		Return(models.User{}, &dto.LoginBlockedError{Err: dto.ErrAccountLocked, RetryAfter: time.Minute})
+
+	_, err := server.Login(ctx, &pvz_v1.LoginRequest{Email: "test@mail.com", Password: "pass"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, "Учетная запись временно заблокирована", status.Convert(err).Message())
+}
+
+func TestLogin_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().UserLogin(gomock.Any(), "test@mail.com", "pass", "").
+		Return(models.User{Id: "1", Email: "test@mail.com", Role: dto.RoleEmployee}, nil)
+
+	resp, err := server.Login(context.Background(), &pvz_v1.LoginRequest{Email: "test@mail.com", Password: "pass"})
+	assert.NoError(t, err)
+
+	claims, err := middlewares.ParseToken("Bearer " + resp.GetToken())
+	assert.NoError(t, err)
+	assert.Equal(t, dto.RoleEmployee, claims["role"])
+}
+
+func TestRegister_InvalidRequest(t *testing.T) {
+	server := NewUserServer(nil, zaptest.NewLogger(t).Sugar())
+
+	_, err := server.Register(context.Background(), &pvz_v1.RegisterRequest{Email: "bad", Password: "pass", Role: dto.RoleEmployee})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.Register(context.Background(), &pvz_v1.RegisterRequest{Email: "test@mail.com", Password: "pass", Role: "admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRegister_ServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().UserRegister(gomock.Any(), "test@mail.com", "pass", dto.RoleEmployee).Return(models.User{}, errors.New("db error"))
+
+	_, err := server.Register(context.Background(), &pvz_v1.RegisterRequest{Email: "test@mail.com", Password: "pass", Role: dto.RoleEmployee})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRegister_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().UserRegister(gomock.Any(), "test@mail.com", "pass", dto.RoleModerator).
+		Return(models.User{Id: "1", Email: "test@mail.com", Role: dto.RoleModerator}, nil)
+
+	resp, err := server.Register(context.Background(), &pvz_v1.RegisterRequest{Email: "test@mail.com", Password: "pass", Role: dto.RoleModerator})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.GetId())
+}
+
+func TestDummyLogin(t *testing.T) {
+	server := NewUserServer(nil, zaptest.NewLogger(t).Sugar())
+
+	_, err := server.DummyLogin(context.Background(), &pvz_v1.DummyLoginRequest{Role: "admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := server.DummyLogin(context.Background(), &pvz_v1.DummyLoginRequest{Role: dto.RoleModerator})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.GetToken())
+}
+
+func TestUnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+	expectActiveModerator(service)
+
+	req := &pvz_v1.UserIdRequest{UserId: employeeId}
+
+	_, err := server.UnlockUser(withRole(dto.RoleEmployee), req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// токен dummyLogin не привязан к модератору
+	_, err = server.UnlockUser(withRole(dto.RoleModerator), req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.UnlockUser(moderatorContext(), &pvz_v1.UserIdRequest{UserId: "abc"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	service.EXPECT().UnlockUser(gomock.Any(), employeeId).Return(models.User{}, dto.ErrUserNotFound)
+	_, err = server.UnlockUser(moderatorContext(), req)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	service.EXPECT().UnlockUser(gomock.Any(), employeeId).
+		Return(models.User{Id: employeeId, Email: "test@mail.com", Role: dto.RoleEmployee}, nil)
+	resp, err := server.UnlockUser(moderatorContext(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, employeeId, resp.GetId())
+}
+
+func TestRegister_WeakPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	service.EXPECT().UserRegister(gomock.Any(), "test@mail.com", "short", dto.RoleEmployee).
+		Return(models.User{}, &dto.WeakPasswordError{Violations: []string{"не короче 8 символов", "хотя бы одна цифра"}})
+
+	_, err := server.Register(context.Background(), &pvz_v1.RegisterRequest{
+		Email:    "test@mail.com",
+		Password: "short",
+		Role:     dto.RoleEmployee,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "Пароль не соответствует требованиям: не короче 8 символов, хотя бы одна цифра", status.Convert(err).Message())
+}
+
+func TestChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	ctx := context.WithValue(context.Background(), middlewares.Key("props"),
+		jwt.MapClaims{"user_id": "u1", "role": dto.RoleEmployee})
+	req := &pvz_v1.ChangePasswordRequest{OldPassword: "old", NewPassword: "new"}
+
+	service.EXPECT().ChangePassword(gomock.Any(), "u1", "old", "new").Return(nil)
+	_, err := server.ChangePassword(ctx, req)
+	assert.NoError(t, err)
+
+	service.EXPECT().ChangePassword(gomock.Any(), "u1", "old", "new").Return(dto.ErrInvalidCredentials)
+	_, err = server.ChangePassword(ctx, req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	service.EXPECT().ChangePassword(gomock.Any(), "", "old", "new").Return(dto.ErrPasswordChangeDenied)
+	_, err = server.ChangePassword(withRole(dto.RoleEmployee), req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	_, err := server.RequestPasswordReset(context.Background(), &pvz_v1.RequestPasswordResetRequest{Email: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	service.EXPECT().RequestPasswordReset(gomock.Any(), "test@mail.com").Return(nil)
+	_, err = server.RequestPasswordReset(context.Background(), &pvz_v1.RequestPasswordResetRequest{Email: "test@mail.com"})
+	assert.NoError(t, err)
+}
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockUserService(ctrl)
+	server := NewUserServer(service, zaptest.NewLogger(t).Sugar())
+
+	req := &pvz_v1.ResetPasswordRequest{Token: "token", NewPassword: "new"}
+
+	service.EXPECT().ResetPassword(gomock.Any(), "token", "new").Return(dto.ErrInvalidResetToken)
+	_, err := server.ResetPassword(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "Токен сброса пароля недействителен или истек", status.Convert(err).Message())
+
+	service.EXPECT().ResetPassword(gomock.Any(), "token", "new").Return(nil)
+	_, err = server.ResetPassword(context.Background(), req)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	pvz_v1 "github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errInvalidDate      = status.Error(codes.InvalidArgument, "Неверный формат даты. Используйте формат RFC3339: 2025-04-11T18:57:00+03:00")
+	errInvalidDateOrder = status.Error(codes.InvalidArgument, "Дата начала периода должна быть раньше даты окончания")
+)
+
+func invalidParam(name string) error {
+	return status.Error(codes.InvalidArgument, "Невалидный параметр "+name)
+}
+
+// allAllowed проверяет, что все значения параметра-списка входят в allowed
+func allAllowed(values, allowed []string) bool {
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			return false
+		}
+	}
+	return true
+}
+
+var errInvalidDateRange = errors.New("startDate should be before endDate")
+
+func parseDateRange(startDateStr, endDateStr string) (*time.Time, *time.Time, error) {
+	var startDate, endDate *time.Time
+	if startDateStr != "" {
+		tStart, err := time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		startDate = &tStart
+	}
+
+	if endDateStr != "" {
+		tEnd, err := time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		endDate = &tEnd
+	}
+
+	if startDate != nil && endDate != nil && !startDate.Before(*endDate) {
+		return nil, nil, errInvalidDateRange
+	}
+
+	return startDate, endDate, nil
+}
+
+// dateRangeError отличает для клиента перепутанные границы периода от
+// неверного формата даты
+func dateRangeError(err error) error {
+	if errors.Is(err, errInvalidDateRange) {
+		return errInvalidDateOrder
+	}
+	return errInvalidDate
+}
+
+// pageParams проверяет номер страницы и размер страницы, подставляя значения по умолчанию
+func pageParams(page, limit *int32, defaultLimit, maxLimit int) (int, int, error) {
+	p, l := 1, defaultLimit
+
+	if page != nil {
+		if *page < 1 {
+			return 0, 0, invalidParam("page")
+		}
+		p = int(*page)
+	}
+
+	if limit != nil {
+		if *limit < 1 || int(*limit) > maxLimit {
+			return 0, 0, invalidParam("limit")
+		}
+		l = int(*limit)
+	}
+
+	return p, l, nil
+}
+
+var (
+	errEmptyPVZUpdate        = errors.New("no fields to update")
+	errIncompleteCoordinates = errors.New("latitude and longitude should be passed together")
+	errInvalidCoordinates    = errors.New("coordinates out of range")
+	errInvalidTimezone       = errors.New("invalid timezone")
+)
+
+func validCoordinates(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
+
+func validateUpdatePVZRequest(req *pvz_v1.UpdatePVZRequest) error {
+	if req.Name == nil && req.Address == nil && req.WorkingHours == nil &&
+		req.Latitude == nil && req.Longitude == nil && req.Timezone == nil {
+		return errEmptyPVZUpdate
+	}
+
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return errIncompleteCoordinates
+	}
+
+	if req.Latitude != nil && !validCoordinates(*req.Latitude, *req.Longitude) {
+		return errInvalidCoordinates
+	}
+
+	if req.Timezone != nil {
+		if *req.Timezone == "" {
+			return errInvalidTimezone
+		}
+		if _, err := time.LoadLocation(*req.Timezone); err != nil {
+			return fmt.Errorf("%w: %v", errInvalidTimezone, err)
+		}
+	}
+
+	return nil
+}
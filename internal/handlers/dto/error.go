@@ -2,6 +2,9 @@ package dto
 
 import (
 	goErrors "errors"
+	"fmt"
+	"strings"
+	"time"
 )
 
 var (
@@ -12,11 +15,55 @@ var (
 	ErrPVZAlreadyHasReception = goErrors.New("PVZ already has active reception")
 	ErrUserAlreadyExists      = goErrors.New("user already exists")
 	ErrInvalidCredentials     = goErrors.New("user login invalid credentials")
+	ErrUserNotFound           = goErrors.New("no such user")
+	ErrLoginThrottled         = goErrors.New("too many failed login attempts")
+	ErrAccountLocked          = goErrors.New("account is temporarily locked")
+	ErrWeakPassword           = goErrors.New("password does not meet policy")
+	ErrInvalidResetToken      = goErrors.New("invalid or expired password reset token")
+	ErrPasswordChangeDenied   = goErrors.New("password change requires a registered user")
+	ErrUserDisabled           = goErrors.New("user is disabled")
+	ErrRegistrationClosed     = goErrors.New("self-registration is disabled")
+	ErrRoleNotAllowed         = goErrors.New("role is not allowed for self-registration")
+	ErrSelfModification       = goErrors.New("moderator cannot disable or change role of own account")
 	ErrDBInsert               = goErrors.New("failed to insert into DB")
 	ErrDBRead                 = goErrors.New("failed to read from DB")
 	ErrDBUpdate               = goErrors.New("failer to update in DB")
+	ErrReceptionNotFound      = goErrors.New("no such reception")
+	ErrPVZNotActive           = goErrors.New("PVZ is not active")
+	ErrInvalidPVZStatusChange = goErrors.New("invalid PVZ status transition")
+	ErrInvalidStatusChange    = goErrors.New("invalid reception status transition")
+	ErrStatusChangeForbidden  = goErrors.New("reception status transition is forbidden for role")
+	ErrReceptionNotLast       = goErrors.New("only the last reception of PVZ can be reopened")
 )
 
+// LoginBlockedError сообщает, что попытка входа отклонена без проверки пароля
+// из-за прошлых неудач. Err — ErrLoginThrottled или ErrAccountLocked
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%v: retry after %v", e.Err, e.RetryAfter)
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// WeakPasswordError перечисляет нарушенные требования политики паролей
+type WeakPasswordError struct {
+	Violations []string
+}
+
+func (e *WeakPasswordError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrWeakPassword, strings.Join(e.Violations, ", "))
+}
+
+func (e *WeakPasswordError) Unwrap() error {
+	return ErrWeakPassword
+}
+
 // ErrorDto model info
 // @Description Информация об ошибке (DTO)
 type ErrorDto struct {
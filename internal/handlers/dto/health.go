@@ -0,0 +1,14 @@
+package dto
+
+const (
+	HealthStatusOK           = "ok"
+	HealthStatusUnavailable  = "unavailable"
+	HealthStatusShuttingDown = "shutting_down"
+)
+
+// HealthDto model info
+// @Description Состояние сервиса и его зависимостей
+type HealthDto struct {
+	Status string            `json:"status"`           // Общее состояние (ok || unavailable || shutting_down)
+	Checks map[string]string `json:"checks,omitempty"` // Результат каждой проверки: ok или текст ошибки
+}
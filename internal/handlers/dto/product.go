@@ -1,11 +1,15 @@
 package dto
 
+import "github.com/hamillka/avitoTechSpring25/internal/models"
+
 const (
 	ProductTypeElectronics = "электроника"
 	ProductTypeClothes     = "одежда"
 	ProductTypeShoes       = "обувь"
 )
 
+var ProductTypes = []string{ProductTypeElectronics, ProductTypeClothes, ProductTypeShoes}
+
 // ProductDto model info
 // @Description Информация о товаре
 type ProductDto struct {
@@ -30,3 +34,25 @@ type AddProductResponseDto struct {
 	Type        string `json:"type"`        // Тип товара
 	ReceptionId string `json:"receptionId"` // Идентификатор приемки
 }
+
+// ProductPageDto model info
+// @Description Страница товаров приемки
+type ProductPageDto struct {
+	Items      []ProductDto `json:"items"`                // Товары на странице
+	NextCursor string       `json:"nextCursor,omitempty"` // Курсор следующей страницы (отсутствует на последней странице)
+}
+
+func ProductsToDto(products []models.Product) []ProductDto {
+	result := make([]ProductDto, 0, len(products))
+
+	for _, product := range products {
+		result = append(result, ProductDto{
+			Id:          product.Id,
+			DateTime:    product.DateTime,
+			Type:        product.Type,
+			ReceptionId: product.ReceptionId,
+		})
+	}
+
+	return result
+}
@@ -2,18 +2,40 @@ package dto
 
 import "github.com/hamillka/avitoTechSpring25/internal/models"
 
+const (
+	// PageProfile включает ответ-конверт с метаданными пагинации
+	// через заголовок Accept: application/json; profile="paginated"
+	PageProfile     = "paginated"
+	PageContentType = `application/json; profile="paginated"`
+)
+
 const (
 	Moscow = "Москва"
 	SaintP = "Санкт-Петербург"
 	Kazan  = "Казань"
 )
 
+// Допустимые значения параметров списка ПВЗ
+var (
+	Cities        = []string{Moscow, SaintP, Kazan}
+	PVZSortFields = []string{models.PVZSortRegistrationDate, models.PVZSortLastActivity, models.PVZSortProductCount}
+	SortOrders    = []string{models.SortAsc, models.SortDesc}
+	ExpandLevels  = []string{models.ExpandPVZ, models.ExpandReceptions, models.ExpandProducts}
+)
+
 // PVZDto model info
 // @Description Информация о ПВЗ
 type PVZDto struct {
-	Id               string `json:"id"`               // Идентификатор
-	RegistrationDate string `json:"registrationDate"` // Дата регистрации
-	City             string `json:"city"`             // Город
+	Id               string   `json:"id"`                     // Идентификатор
+	RegistrationDate string   `json:"registrationDate"`       // Дата регистрации
+	City             string   `json:"city"`                   // Город
+	Name             string   `json:"name,omitempty"`         // Название
+	Address          string   `json:"address,omitempty"`      // Адрес
+	WorkingHours     string   `json:"workingHours,omitempty"` // Часы работы
+	Status           string   `json:"status,omitempty"`       // Статус (active || inactive || archived)
+	Latitude         *float64 `json:"latitude,omitempty"`     // Широта
+	Longitude        *float64 `json:"longitude,omitempty"`    // Долгота
+	Timezone         string   `json:"timezone,omitempty"`     // Часовой пояс (IANA, например Europe/Moscow)
 }
 
 // CreatePVZRequestDto model info
@@ -30,6 +52,24 @@ type CreatePVZResponseDto struct {
 	City             string `json:"city"`             // Город
 }
 
+// UpdatePVZRequestDto model info
+// @Description Информация о ПВЗ при его изменении (передаются только изменяемые поля)
+type UpdatePVZRequestDto struct {
+	Name         *string  `json:"name,omitempty"`         // Название
+	Address      *string  `json:"address,omitempty"`      // Адрес
+	WorkingHours *string  `json:"workingHours,omitempty"` // Часы работы
+	Latitude     *float64 `json:"latitude,omitempty"`     // Широта (передается вместе с долготой)
+	Longitude    *float64 `json:"longitude,omitempty"`    // Долгота (передается вместе с широтой)
+	Timezone     *string  `json:"timezone,omitempty"`     // Часовой пояс (IANA, например Europe/Moscow)
+}
+
+// NearbyPVZDto model info
+// @Description Информация о ПВЗ и расстоянии до него
+type NearbyPVZDto struct {
+	PVZ      PVZDto  `json:"pvz"`      // Информация о ПВЗ
+	Distance float64 `json:"distance"` // Расстояние до ПВЗ в метрах
+}
+
 // PVZWithReceptions model info
 // @Description Информация о ПВЗ и приемках, связанных с ним
 type PVZWithReceptionsDto struct {
@@ -37,15 +77,93 @@ type PVZWithReceptionsDto struct {
 	Receptions []ReceptionWithProductsDto `json:"receptions"` // Информация о всех приемках на ПВЗ
 }
 
+// PVZWithReceptionSummariesDto model info
+// @Description Информация о ПВЗ и сводка по его приемкам без товаров
+type PVZWithReceptionSummariesDto struct {
+	PVZ        PVZDto                `json:"pvz"`        // Информация о ПВЗ
+	Receptions []ReceptionSummaryDto `json:"receptions"` // Сводка по приемкам на ПВЗ
+}
+
+// PVZPageDto model info
+// @Description Страница списка ПВЗ с метаданными пагинации
+type PVZPageDto struct {
+	Items   any  `json:"items" swaggertype:"array,object"` // ПВЗ на странице (в форме, заданной параметром expand)
+	Page    int  `json:"page"`                             // Номер страницы
+	Limit   int  `json:"limit"`                            // Количество элементов на странице
+	Total   int  `json:"total"`                            // Общее количество ПВЗ, подходящих под фильтр
+	HasNext bool `json:"hasNext"`                          // Есть ли следующая страница
+}
+
+func PVZToDto(pvz models.PVZ) PVZDto {
+	return PVZDto{
+		Id:               pvz.Id,
+		RegistrationDate: pvz.RegistrationDate,
+		City:             pvz.City,
+		Name:             pvz.Name,
+		Address:          pvz.Address,
+		WorkingHours:     pvz.WorkingHours,
+		Status:           pvz.Status,
+		Latitude:         pvz.Latitude,
+		Longitude:        pvz.Longitude,
+		Timezone:         pvz.Timezone,
+	}
+}
+
+func NearbyPVZsToDto(pvzs []models.PVZWithDistance) []NearbyPVZDto {
+	result := make([]NearbyPVZDto, 0, len(pvzs))
+
+	for _, pvz := range pvzs {
+		result = append(result, NearbyPVZDto{
+			PVZ:      PVZToDto(pvz.PVZ),
+			Distance: pvz.Distance,
+		})
+	}
+
+	return result
+}
+
+// PVZListToDto конвертирует список ПВЗ в форму, соответствующую уровню детализации expand
+func PVZListToDto(pvzs []models.PVZWithReceptions, expand string) any {
+	switch expand {
+	case models.ExpandPVZ:
+		result := make([]PVZDto, 0, len(pvzs))
+		for _, pvz := range pvzs {
+			result = append(result, PVZToDto(pvz.PVZ))
+		}
+		return result
+
+	case models.ExpandReceptions:
+		result := make([]PVZWithReceptionSummariesDto, 0, len(pvzs))
+		for _, pvz := range pvzs {
+			summaries := make([]ReceptionSummaryDto, 0, len(pvz.Receptions))
+			for _, reception := range pvz.Receptions {
+				summaries = append(summaries, ReceptionSummaryDto{
+					Reception: ReceptionDto{
+						Id:       reception.Reception.Id,
+						DateTime: reception.Reception.DateTime,
+						PVZId:    reception.Reception.PVZId,
+						Status:   reception.Reception.Status,
+					},
+					ProductCount: reception.ProductCount,
+				})
+			}
+			result = append(result, PVZWithReceptionSummariesDto{
+				PVZ:        PVZToDto(pvz.PVZ),
+				Receptions: summaries,
+			})
+		}
+		return result
+
+	default:
+		return PVZConvertBLtoDto(pvzs)
+	}
+}
+
 func PVZConvertBLtoDto(pvzs []models.PVZWithReceptions) []PVZWithReceptionsDto {
 	result := make([]PVZWithReceptionsDto, 0, len(pvzs))
 
 	for _, pvz := range pvzs {
-		pvzDto := PVZDto{
-			Id:               pvz.PVZ.Id,
-			RegistrationDate: pvz.PVZ.RegistrationDate,
-			City:             pvz.PVZ.City,
-		}
+		pvzDto := PVZToDto(pvz.PVZ)
 
 		receptionsDto := make([]ReceptionWithProductsDto, 0, len(pvz.Receptions))
 
@@ -1,5 +1,9 @@
 package dto
 
+import "github.com/hamillka/avitoTechSpring25/internal/models"
+
+var ReceptionStatuses = []string{models.INPROGRESS, models.PAUSED, models.CLOSE, models.CANCELLED, models.REOPENED}
+
 // ReceptionDto model info
 // @Description Информация о приемке
 type ReceptionDto struct {
@@ -16,6 +20,13 @@ type ReceptionWithProductsDto struct {
 	Products  []ProductDto `json:"products"`  // Информация о всех товарах в приемке
 }
 
+// ReceptionSummaryDto model info
+// @Description Информация о приемке и количестве товаров в ней
+type ReceptionSummaryDto struct {
+	Reception    ReceptionDto `json:"reception"`    // Информация о приемке
+	ProductCount int          `json:"productCount"` // Количество товаров в приемке
+}
+
 // CreateReceptionRequestDto model info
 // @Description Информация о приемке при ее создании
 type CreateReceptionRequestDto struct {
@@ -39,3 +50,20 @@ type CloseReceptionResponseDto struct {
 	PVZId    string `json:"pvzId"`    // Идентификатор ПВЗ
 	Status   string `json:"status"`   // Статус приемки
 }
+
+// ChangeReceptionStatusRequestDto model info
+// @Description Информация о смене статуса приемки
+type ChangeReceptionStatusRequestDto struct {
+	Reason string `json:"reason"` // Причина смены статуса (необязательно)
+}
+
+// ReceptionStatusChangeDto model info
+// @Description Запись истории смены статусов приемки
+type ReceptionStatusChangeDto struct {
+	ReceptionId   string `json:"receptionId"`          // Идентификатор приемки
+	FromStatus    string `json:"fromStatus,omitempty"` // Предыдущий статус
+	ToStatus      string `json:"toStatus"`             // Новый статус
+	ChangedAt     string `json:"changedAt"`            // Дата и время смены статуса
+	ChangedByRole string `json:"changedByRole"`        // Роль инициатора (employee || moderator || system)
+	Reason        string `json:"reason,omitempty"`     // Причина смены статуса
+}
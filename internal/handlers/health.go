@@ -0,0 +1,93 @@
+//go:generate mockgen -source=health.go -destination=./mocks/mock_health.go -package=mocks
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"go.uber.org/zap"
+)
+
+type HealthChecker interface {
+	Check(ctx context.Context) map[string]error
+	ShuttingDown() bool
+}
+
+type HealthHandler struct {
+	checker HealthChecker
+	logger  *zap.SugaredLogger
+}
+
+func NewHealthHandler(checker HealthChecker, logger *zap.SugaredLogger) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+		logger:  logger,
+	}
+}
+
+// Healthz godoc
+//
+//	@Summary		Проверка здоровья
+//	@Description	Проверяет подключение к базе и версию схемы
+//	@ID				healthz
+//	@Tags			health
+//	@Produce		json
+//
+//	@Success		200	{object}	dto.HealthDto	"Сервис и зависимости доступны"
+//	@Failure		503	{object}	dto.HealthDto	"Одна из зависимостей недоступна"
+//	@Router			/healthz [get]
+func (hh *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	hh.writeHealth(w, r, false)
+}
+
+// Readyz godoc
+//
+//	@Summary		Проверка готовности
+//	@Description	Проверяет подключение к базе и версию схемы. Во время остановки сервиса возвращает 503, чтобы балансировщик перестал слать запросы
+//	@ID				readyz
+//	@Tags			health
+//	@Produce		json
+//
+//	@Success		200	{object}	dto.HealthDto	"Сервис готов принимать запросы"
+//	@Failure		503	{object}	dto.HealthDto	"Сервис не готов или останавливается"
+//	@Router			/readyz [get]
+func (hh *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	hh.writeHealth(w, r, true)
+}
+
+func (hh *HealthHandler) writeHealth(w http.ResponseWriter, r *http.Request, readiness bool) {
+	w.Header().Add("Content-Type", "application/json")
+
+	healthDto := dto.HealthDto{
+		Status: dto.HealthStatusOK,
+		Checks: make(map[string]string),
+	}
+
+	for name, err := range hh.checker.Check(r.Context()) {
+		if err != nil {
+			hh.logger.Errorf("health check %s failed: %v", name, err)
+			healthDto.Status = dto.HealthStatusUnavailable
+			healthDto.Checks[name] = err.Error()
+			continue
+		}
+		healthDto.Checks[name] = dto.HealthStatusOK
+	}
+
+	if readiness && hh.checker.ShuttingDown() {
+		healthDto.Status = dto.HealthStatusShuttingDown
+	}
+
+	if healthDto.Status == dto.HealthStatusOK {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	err := json.NewEncoder(w).Encode(healthDto)
+	if err != nil {
+		hh.logger.Errorf("failed to encode response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestHealthz_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	checker := mocks.NewMockHealthChecker(ctrl)
+	handler := NewHealthHandler(checker, zaptest.NewLogger(t).Sugar())
+
+	checker.EXPECT().Check(gomock.Any()).Return(map[string]error{"database": nil, "schema": nil})
+
+	w := httptest.NewRecorder()
+	handler.Healthz(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var healthDto dto.HealthDto
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&healthDto))
+	assert.Equal(t, dto.HealthStatusOK, healthDto.Status)
+	assert.Equal(t, dto.HealthStatusOK, healthDto.Checks["database"])
+}
+
+func TestHealthz_SchemaOutdated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	checker := mocks.NewMockHealthChecker(ctrl)
+	handler := NewHealthHandler(checker, zaptest.NewLogger(t).Sugar())
+
+	checker.EXPECT().Check(gomock.Any()).Return(map[string]error{
+		"database": nil,
+		"schema":   errors.New("schema version 0 is older than required 1"),
+	})
+
+	w := httptest.NewRecorder()
+	handler.Healthz(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var healthDto dto.HealthDto
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&healthDto))
+	assert.Equal(t, dto.HealthStatusUnavailable, healthDto.Status)
+	assert.Contains(t, healthDto.Checks["schema"], "older than required")
+}
+
+func TestReadyz_ShuttingDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	checker := mocks.NewMockHealthChecker(ctrl)
+	handler := NewHealthHandler(checker, zaptest.NewLogger(t).Sugar())
+
+	checker.EXPECT().Check(gomock.Any()).Return(map[string]error{"database": nil})
+	checker.EXPECT().ShuttingDown().Return(true)
+
+	w := httptest.NewRecorder()
+	handler.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
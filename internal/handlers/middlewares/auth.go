@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 )
 
@@ -16,53 +18,132 @@ type Key string
 
 var Secret = []byte(os.Getenv("JWT_SECRET"))
 
-var errSigningMethod = errors.New("signing method error")
+const tokenTTL = 12 * time.Hour
 
-func AuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := strings.Split(r.Header.Get("auth-x"), "Bearer ")
-		if len(authHeader) != 2 {
-			w.WriteHeader(http.StatusUnauthorized)
-			errorDto := &dto.ErrorDto{
-				Message: "Токен сформирован неверно",
-			}
-			err := json.NewEncoder(w).Encode(errorDto)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-			return
+var (
+	errSigningMethod = errors.New("signing method error")
+
+	ErrMalformedToken = errors.New("malformed token")
+	ErrInvalidToken   = errors.New("invalid token")
+)
+
+// CreateToken выпускает JWT с идентификатором и ролью пользователя.
+// У токенов dummyLogin идентификатора нет
+func CreateToken(userId, role string) (string, error) {
+	payload := jwt.MapClaims{
+		"user_id": userId,
+		"role":    role,
+		"exp":     time.Now().Add(tokenTTL).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	t, err := token.SignedString(Secret)
+	if err != nil {
+		return "", err
+	}
+
+	return t, nil
+}
+
+// ParseToken проверяет значение заголовка auth-x ("Bearer <jwt>") и возвращает
+// claims токена. Используется и HTTP middleware, и gRPC интерсепторами
+func ParseToken(header string) (jwt.MapClaims, error) {
+	authHeader := strings.Split(header, "Bearer ")
+	if len(authHeader) != 2 {
+		return nil, ErrMalformedToken
+	}
+
+	token, err := jwt.Parse(authHeader[1], func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errSigningMethod
 		}
-		jwtToken := authHeader[1]
-		token, err := jwt.Parse(jwtToken, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errSigningMethod
-			}
-			return Secret, nil
-		})
-		if err != nil {
-			w.WriteHeader(http.StatusUnauthorized)
-			errorDto := &dto.ErrorDto{
-				Message: "Неверный токен",
-			}
-			err = json.NewEncoder(w).Encode(errorDto)
+		return Secret, nil
+	})
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if _, ok = claims["role"].(string); !ok {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// UserChecker проверяет, что владелец токена существует и не отключен,
+// и возвращает его текущую роль
+type UserChecker interface {
+	ActiveUserRole(ctx context.Context, userId string) (string, error)
+}
+
+// CheckUser сверяет claims с базой: отключенный пользователь получает
+// dto.ErrUserDisabled, а роль в claims заменяется текущей, поэтому смена
+// роли действует и для выданных ранее токенов. Токены dummyLogin не привязаны
+// к пользователю и не проверяются
+func CheckUser(ctx context.Context, users UserChecker, claims jwt.MapClaims) error {
+	userId, _ := claims["user_id"].(string)
+	if users == nil || userId == "" {
+		return nil
+	}
+
+	role, err := users.ActiveUserRole(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	claims["role"] = role
+	return nil
+}
+
+func writeAuthError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	errorDto := &dto.ErrorDto{
+		Message: message,
+	}
+	err := json.NewEncoder(w).Encode(errorDto)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// AuthMiddleware проверяет токен из заголовка auth-x, а через users —
+// что его владелец не отключен. users может быть nil, тогда проверяется
+// только сам токен
+func AuthMiddleware(users UserChecker) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := ParseToken(r.Header.Get("auth-x"))
 			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
+				message := "Неверный токен"
+				if errors.Is(err, ErrMalformedToken) {
+					message = "Токен сформирован неверно"
+				}
+
+				writeAuthError(w, http.StatusUnauthorized, message)
+				return
+			}
+
+			err = CheckUser(r.Context(), users, claims)
+			switch {
+			case errors.Is(err, dto.ErrUserDisabled):
+				writeAuthError(w, http.StatusForbidden, "Учетная запись отключена")
+				return
+			case errors.Is(err, dto.ErrUserNotFound):
+				writeAuthError(w, http.StatusUnauthorized, "Неверный токен")
+				return
+			case err != nil:
+				writeAuthError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+				return
 			}
-			return
-		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 			ctx := context.WithValue(r.Context(), Key("props"), claims)
+			SetRequestUser(ctx, claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
-		} else {
-			w.WriteHeader(http.StatusUnauthorized)
-			errorDto := &dto.ErrorDto{
-				Message: "Неверный токен",
-			}
-			err = json.NewEncoder(w).Encode(errorDto)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-		}
-	})
+		})
+	}
 }
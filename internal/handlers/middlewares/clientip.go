@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const forwardedForHeader = "X-Forwarded-For"
+
+type clientIPKey struct{}
+
+// WithClientIP кладет в контекст адрес клиента
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext возвращает адрес клиента, положенный ClientIPMiddleware
+// или gRPC интерсептором, либо пустую строку
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// ClientIPMiddleware определяет адрес клиента один раз для ограничения
+// частоты запросов и счетчиков неудачных входов. resolve решает, можно ли
+// верить X-Forwarded-For
+func ClientIPMiddleware(resolve func(remoteAddr, forwardedFor string) string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// прокси может дописать адрес отдельной строкой заголовка, поэтому
+			// строки склеиваются в один список
+			ip := resolve(r.RemoteAddr, strings.Join(r.Header.Values(forwardedForHeader), ","))
+			next.ServeHTTP(w, r.WithContext(WithClientIP(r.Context(), ip)))
+		})
+	}
+}
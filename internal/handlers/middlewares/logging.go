@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	maxRequestIDLength = 128
+)
+
+// RequestUser — пользователь запроса для access log. Middleware логирования
+// кладет его в контекст до проверки токена, а AuthMiddleware заполняет после,
+// поэтому в контексте лежит указатель
+type RequestUser struct {
+	Id   string
+	Role string
+}
+
+type requestUserKey struct{}
+
+// WithRequestUser кладет в контекст пустого пользователя запроса
+func WithRequestUser(ctx context.Context) (context.Context, *RequestUser) {
+	user := &RequestUser{}
+	return context.WithValue(ctx, requestUserKey{}, user), user
+}
+
+// SetRequestUser запоминает пользователя из claims токена, если в контексте
+// есть RequestUser
+func SetRequestUser(ctx context.Context, claims jwt.MapClaims) {
+	user, ok := ctx.Value(requestUserKey{}).(*RequestUser)
+	if !ok {
+		return
+	}
+
+	user.Id, _ = claims["user_id"].(string)
+	user.Role, _ = claims["role"].(string)
+}
+
+// RequestID возвращает идентификатор запроса клиента или новый, если клиент
+// его не передал или передал что-то непохожее на идентификатор
+func RequestID(incoming string) string {
+	if incoming == "" || len(incoming) > maxRequestIDLength {
+		return uuid.NewString()
+	}
+
+	for _, c := range incoming {
+		if c < 0x21 || c > 0x7e {
+			return uuid.NewString()
+		}
+	}
+
+	return incoming
+}
+
+// LoggingMiddleware присваивает запросу X-Request-ID (или берет его у клиента),
+// кладет в контекст логгер с request_id и после ответа пишет одну строку
+// access log
+func LoggingMiddleware(base *zap.SugaredLogger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := RequestID(r.Header.Get(RequestIDHeader))
+			w.Header().Set(RequestIDHeader, requestID)
+
+			ctx, user := WithRequestUser(r.Context())
+			ctx = logger.WithContext(ctx, base.With("request_id", requestID))
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			logger.FromContext(ctx, base).Infow("request",
+				"method", r.Method,
+				"route", routeTemplate(r, r.URL.Path),
+				"status", rec.status,
+				"latency", time.Since(start),
+				"user_id", user.Id,
+				"role", user.Role,
+			)
+		})
+	}
+}
@@ -18,17 +18,23 @@ func (r *statusRecorder) WriteHeader(code int) {
 	r.ResponseWriter.WriteHeader(code)
 }
 
+// MetricsMiddleware считает запросы по шаблону маршрута. Запросы, не попавшие
+// ни в один маршрут, собираются под одной меткой UnmatchedRoute, чтобы
+// произвольные пути не порождали новые временные ряды
 func MetricsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
 		rec := &statusRecorder{ResponseWriter: w, status: 200}
 		start := time.Now()
 
 		next.ServeHTTP(rec, r)
 
 		duration := time.Since(start).Seconds()
-		path := r.URL.Path
+		route := routeTemplate(r, UnmatchedRoute)
 
-		metrics.HTTPRequestCount.WithLabelValues(r.Method, path, strconv.Itoa(rec.status)).Inc()
-		metrics.HTTPResponseDuration.WithLabelValues(r.Method, path).Observe(duration)
+		metrics.HTTPRequestCount.WithLabelValues(r.Method, route, strconv.Itoa(rec.status)).Inc()
+		metrics.HTTPResponseDuration.WithLabelValues(r.Method, route).Observe(duration)
 	})
 }
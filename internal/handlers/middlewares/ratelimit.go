@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/ratelimit"
+)
+
+const (
+	RetryAfterHeader    = "Retry-After"
+	tooManyRequestsText = "Слишком много запросов"
+)
+
+// RateLimitMiddleware ограничивает частоту вызовов метода fullMethod.
+// Для методов с токеном ставится после AuthMiddleware, чтобы лимит считался
+// по пользователю, а не по IP адресу из ClientIPMiddleware
+func RateLimitMiddleware(limiter *ratelimit.Limiter, fullMethod string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var userId string
+			if claims, ok := r.Context().Value(Key("props")).(jwt.MapClaims); ok {
+				userId, _ = claims["user_id"].(string)
+			}
+			principal := ratelimit.Principal(userId, ClientIPFromContext(r.Context()))
+
+			res := limiter.Allow(r.Context(), fullMethod, principal)
+			if res.Allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set(RetryAfterHeader, strconv.Itoa(ratelimit.RetryAfterSeconds(res.RetryAfter)))
+			w.WriteHeader(http.StatusTooManyRequests)
+			errorDto := &dto.ErrorDto{
+				Message: tooManyRequestsText,
+			}
+			err := json.NewEncoder(w).Encode(errorDto)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// UnmatchedRoute — метка маршрута для запросов, не попавших ни в один маршрут
+const UnmatchedRoute = "unmatched"
+
+// routeTemplate возвращает шаблон маршрута gorilla/mux, например
+// /pvz/{pvzId}/close_last_reception, а для запросов без маршрута — fallback
+func routeTemplate(r *http.Request, fallback string) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if tmpl, err := current.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+
+	return fallback
+}
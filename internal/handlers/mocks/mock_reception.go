@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,17 +35,31 @@ func (m *MockReceptionService) EXPECT() *MockReceptionServiceMockRecorder {
 	return m.recorder
 }
 
-// CreateReception mocks base method.
-func (m *MockReceptionService) CreateReception(pvzId string) (models.Reception, error) {
+// ExportReceptions mocks base method.
+func (m *MockReceptionService) ExportReceptions(ctx context.Context, filter models.PVZFilter, fn func(models.ReceptionExportRow) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateReception", pvzId)
-	ret0, _ := ret[0].(models.Reception)
+	ret := m.ctrl.Call(m, "ExportReceptions", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportReceptions indicates an expected call of ExportReceptions.
+func (mr *MockReceptionServiceMockRecorder) ExportReceptions(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReceptions", reflect.TypeOf((*MockReceptionService)(nil).ExportReceptions), ctx, filter, fn)
+}
+
+// GetPVZReceptions mocks base method.
+func (m *MockReceptionService) GetPVZReceptions(ctx context.Context, pvzId string, filter models.ReceptionListFilter, page, limit int) ([]models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZReceptions", ctx, pvzId, filter, page, limit)
+	ret0, _ := ret[0].([]models.Reception)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateReception indicates an expected call of CreateReception.
-func (mr *MockReceptionServiceMockRecorder) CreateReception(pvzId interface{}) *gomock.Call {
+// GetPVZReceptions indicates an expected call of GetPVZReceptions.
+func (mr *MockReceptionServiceMockRecorder) GetPVZReceptions(ctx, pvzId, filter, page, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockReceptionService)(nil).CreateReception), pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZReceptions", reflect.TypeOf((*MockReceptionService)(nil).GetPVZReceptions), ctx, pvzId, filter, page, limit)
 }
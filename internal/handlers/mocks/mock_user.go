@@ -1,65 +0,0 @@
-// Code generated by MockGen. DO NOT EDIT.
-// Source: user.go
-
-// Package mocks is a generated GoMock package.
-package mocks
-
-import (
-	reflect "reflect"
-
-	gomock "github.com/golang/mock/gomock"
-	models "github.com/hamillka/avitoTechSpring25/internal/models"
-)
-
-// MockUserService is a mock of UserService interface.
-type MockUserService struct {
-	ctrl     *gomock.Controller
-	recorder *MockUserServiceMockRecorder
-}
-
-// MockUserServiceMockRecorder is the mock recorder for MockUserService.
-type MockUserServiceMockRecorder struct {
-	mock *MockUserService
-}
-
-// NewMockUserService creates a new mock instance.
-func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
-	mock := &MockUserService{ctrl: ctrl}
-	mock.recorder = &MockUserServiceMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
-	return m.recorder
-}
-
-// UserLogin mocks base method.
-func (m *MockUserService) UserLogin(email, password string) (models.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserLogin", email, password)
-	ret0, _ := ret[0].(models.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// UserLogin indicates an expected call of UserLogin.
-func (mr *MockUserServiceMockRecorder) UserLogin(email, password interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogin", reflect.TypeOf((*MockUserService)(nil).UserLogin), email, password)
-}
-
-// UserRegister mocks base method.
-func (m *MockUserService) UserRegister(email, password, role string) (models.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserRegister", email, password, role)
-	ret0, _ := ret[0].(models.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// UserRegister indicates an expected call of UserRegister.
-func (mr *MockUserServiceMockRecorder) UserRegister(email, password, role interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRegister", reflect.TypeOf((*MockUserService)(nil).UserRegister), email, password, role)
-}
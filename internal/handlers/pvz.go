@@ -2,26 +2,26 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/gorilla/mux"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
-	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
-	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"go.uber.org/zap"
 )
 
 type PVZService interface {
-	CreatePVZ(city string) (models.PVZ, error)
-	GetPVZWithPagination(startDate, endDate *time.Time, page, limit int) ([]models.PVZWithReceptions, error)
-	CloseLastReception(pvzId string) (models.Reception, error)
-	DeleteLastProduct(pvzId string) error
+	GetPVZWithPagination(ctx context.Context, filter models.PVZFilter, expand string, page, limit int) ([]models.PVZWithReceptions, error)
+	CountPVZs(ctx context.Context, filter models.PVZFilter) (int, error)
 }
 
 type PVZHandler struct {
@@ -36,49 +36,30 @@ func NewPVZHandler(s PVZService, logger *zap.SugaredLogger) *PVZHandler {
 	}
 }
 
-// CreatePVZ godoc
-//
-//	@Summary		Завести ПВЗ
-//	@Description	Создает новый пункт выдачи заказов в указанном городе
-//	@ID				create-pvz
-//	@Tags			pvz
-//	@Accept			json
-//	@Produce		json
-//	@Param			body	body	dto.CreatePVZRequestDto	true	"Информация о создаваемом ПВЗ"
-//
-//	@Success		201	{object}	dto.CreatePVZResponseDto	"ПВЗ успешно создан"
-//	@Failure		400	{object}	dto.ErrorDto				"Некорректные данные / Неверный запрос"
-//	@Failure		403	{object}	dto.ErrorDto				"Доступ запрещен"
-//	@Failure		500	{object}	dto.ErrorDto				"Внутренняя ошибка сервера"
-//	@Security		ApiKeyAuth
-//	@Router			/pvz [post]
-func (pvzh *PVZHandler) CreatePVZ(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	key := middlewares.Key("props")
-	claims := ctx.Value(key).(jwt.MapClaims)
-	role := claims["role"].(string)
-	if role != dto.RoleModerator {
-		pvzh.logger.Errorf("forbidden action : invalid role: %v", role)
-		w.WriteHeader(http.StatusForbidden)
+// GetPVZWithPagination обслуживает GET /pvz (PVZService.ListPVZ в pvz.proto) в обход
+// REST шлюза: форма ответа зависит от expand и envelope, а в заголовке Link
+// передаются ссылки на соседние страницы
+func (pvzh *PVZHandler) GetPVZWithPagination(w http.ResponseWriter, r *http.Request) {
+	page, err := GetQueryParam(r, "page", 1)
+	if err != nil || page < 1 {
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("error in extracting page from query: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Доступ запрещен",
+			Message: "Невалидный параметр page",
 		}
-		err := json.NewEncoder(w).Encode(errorDto)
+		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 		return
 	}
 
-	var createPVZRequestDto dto.CreatePVZRequestDto
-
-	w.Header().Add("Content-Type", "application/json")
-	err := json.NewDecoder(r.Body).Decode(&createPVZRequestDto)
-	if err != nil {
-		pvzh.logger.Errorf("failed to decode request body: %v", err)
+	limit, err := GetQueryParam(r, "limit", 10)
+	if err != nil || limit < 1 || limit > 30 {
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("error in extracting limit from query: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Некорректные данные",
+			Message: "Невалидный параметр limit",
 		}
 		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
@@ -87,11 +68,12 @@ func (pvzh *PVZHandler) CreatePVZ(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if createPVZRequestDto.City != dto.Moscow && createPVZRequestDto.City != dto.SaintP && createPVZRequestDto.City != dto.Kazan {
-		pvzh.logger.Errorf("invalid city: %v", createPVZRequestDto.City)
+	startDate, endDate, err := parseDateRange(r)
+	if err != nil {
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("invalid date range: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Неверный запрос",
+			Message: dateRangeErrorMessage(err),
 		}
 		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
@@ -100,13 +82,12 @@ func (pvzh *PVZHandler) CreatePVZ(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pvz, err := pvzh.service.CreatePVZ(createPVZRequestDto.City)
+	includeArchived, err := GetQueryParam(r, "includeArchived", false)
 	if err != nil {
-		pvzh.logger.Errorf("failed to create pvz: %v", err)
-
-		w.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("error in extracting includeArchived from query: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Внутренняя ошибка сервера",
+			Message: "Невалидный параметр includeArchived",
 		}
 		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
@@ -115,50 +96,18 @@ func (pvzh *PVZHandler) CreatePVZ(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	createPVZResponseDto := dto.CreatePVZResponseDto{
-		Id:               pvz.Id,
-		RegistrationDate: pvz.RegistrationDate,
-		City:             pvz.City,
+	filter := models.PVZFilter{
+		StartDate:       startDate,
+		EndDate:         endDate,
+		IncludeArchived: includeArchived,
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	err = json.NewEncoder(w).Encode(createPVZResponseDto)
+	envelope, err := wantsPageEnvelope(r)
 	if err != nil {
-		pvzh.logger.Errorf("failed to encode response: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-	}
-
-	metrics.PVZCreated.Inc()
-}
-
-// GetPVZWithPagination godoc
-//
-//	@Summary		Получить список ПВЗ с пагинацией
-//	@Description	Возвращает список ПВЗ с их приемками и товарами с возможностью фильтрации по дате и пагинацией
-//	@ID				get-pvz-with-pagination
-//	@Tags			pvz
-//	@Accept			json
-//	@Produce		json
-//	@Param			startDate	query	string	false	"Начальная дата (RFC3339)"
-//	@Param			endDate		query	string	false	"Конечная дата (RFC3339)"
-//	@Param			page		query	integer	false	"Номер страницы (по умолчанию 1)"
-//	@Param			limit		query	integer	false	"Количество элементов на странице (по умолчанию 10, максимум 30)"
-//
-//	@Success		200	{array}		dto.PVZWithReceptionsDto	"Список ПВЗ с приемками и товарами"
-//	@Failure		400	{object}	dto.ErrorDto				"Невалидные параметры запроса"
-//	@Failure		500	{object}	dto.ErrorDto				"Внутренняя ошибка сервера"
-//	@Security		ApiKeyAuth
-//	@Router			/pvz [get]
-func (pvzh *PVZHandler) GetPVZWithPagination(w http.ResponseWriter, r *http.Request) {
-	startDateStr, _ := GetQueryParam(r, "startDate", "")
-	endDateStr, _ := GetQueryParam(r, "endDate", "")
-
-	page, err := GetQueryParam(r, "page", 1)
-	if err != nil || page < 1 {
-		pvzh.logger.Errorf("error in extracting page from query: %v", err)
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("error in extracting envelope from query: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Невалидный параметр page",
+			Message: "Невалидный параметр envelope",
 		}
 		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
@@ -167,12 +116,12 @@ func (pvzh *PVZHandler) GetPVZWithPagination(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	limit, err := GetQueryParam(r, "limit", 10)
-	if err != nil || limit < 1 || limit > 30 {
-		pvzh.logger.Errorf("error in extracting limit from query: %v", err)
+	expand, _ := GetQueryParam(r, "expand", models.ExpandProducts)
+	if !slices.Contains(dto.ExpandLevels, expand) {
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("invalid expand: %v", expand)
 		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Невалидный параметр limit",
+			Message: "Невалидный параметр expand",
 		}
 		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
@@ -181,47 +130,12 @@ func (pvzh *PVZHandler) GetPVZWithPagination(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	var startDate, endDate *time.Time
-	var tStart, tEnd time.Time
-	if startDateStr != "" {
-		tStart, err = time.Parse(time.RFC3339, startDateStr)
-		if err != nil {
-			pvzh.logger.Errorf("startDate invalid format: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			errorDto := &dto.ErrorDto{
-				Message: "Неверный формат даты. Используйте формат RFC3339: 2025-04-11T18:57:00+03:00",
-			}
-			err = json.NewEncoder(w).Encode(errorDto)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-			return
-		}
-		startDate = &tStart
-	}
-
-	if endDateStr != "" {
-		tEnd, err = time.Parse(time.RFC3339, endDateStr)
-		if err != nil {
-			pvzh.logger.Errorf("endDate invalid format: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			errorDto := &dto.ErrorDto{
-				Message: "Неверный формат даты. Используйте формат RFC3339: 2025-04-11T18:57:00+03:00",
-			}
-			err = json.NewEncoder(w).Encode(errorDto)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-			return
-		}
-		endDate = &tEnd
-	}
-
-	if startDate != nil && endDate != nil && !startDate.Before(*endDate) {
-		pvzh.logger.Errorf("startDate should be before endDate")
+	message, err := parsePVZSearchParams(r, &filter)
+	if err != nil {
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("invalid search params: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Некорректные данные",
+			Message: message,
 		}
 		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
@@ -230,9 +144,9 @@ func (pvzh *PVZHandler) GetPVZWithPagination(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	pvzs, err := pvzh.service.GetPVZWithPagination(startDate, endDate, page, limit)
+	pvzs, err := pvzh.service.GetPVZWithPagination(r.Context(), filter, expand, page, limit)
 	if err != nil {
-		pvzh.logger.Errorf("failed to get pvzs: %v", err)
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("failed to get pvzs: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		errorDto := &dto.ErrorDto{
 			Message: "Внутренняя ошибка сервера",
@@ -244,190 +158,178 @@ func (pvzh *PVZHandler) GetPVZWithPagination(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	pvzsWithReceptionsDto := dto.PVZConvertBLtoDto(pvzs)
+	pvzsWithReceptionsDto := dto.PVZListToDto(pvzs, expand)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(pvzsWithReceptionsDto)
-	if err != nil {
-		pvzh.logger.Errorf("failed to encode response: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-}
+	if !envelope {
+		setPaginationLinks(w, r, page, len(pvzs) == limit)
 
-// CloseLastReception godoc
-//
-//	@Summary		Закрыть последнюю приемку
-//	@Description	Закрывает последнюю активную приемку для указанного ПВЗ
-//	@ID				close-last-reception
-//	@Tags			pvz
-//	@Accept			json
-//	@Produce		json
-//	@Param			pvzId	path	string	true	"Идентификатор ПВЗ"
-//
-//	@Success		200	{object}	dto.CloseReceptionResponseDto	"Приемка успешно закрыта"
-//	@Failure		400	{object}	dto.ErrorDto					"Некорректные данные / ПВЗ не найден / Приемка уже закрыта"
-//	@Failure		403	{object}	dto.ErrorDto					"Доступ запрещен"
-//	@Failure		500	{object}	dto.ErrorDto					"Внутренняя ошибка сервера"
-//	@Security		ApiKeyAuth
-//	@Router			/pvz/{pvzId}/close_last_reception [post]
-func (pvzh *PVZHandler) CloseLastReception(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	key := middlewares.Key("props")
-	claims := ctx.Value(key).(jwt.MapClaims)
-	role := claims["role"].(string)
-	if role != dto.RoleEmployee {
-		pvzh.logger.Errorf("forbidden action : invalid role: %v", role)
-		w.WriteHeader(http.StatusForbidden)
-		errorDto := &dto.ErrorDto{
-			Message: "Доступ запрещен",
-		}
-		err := json.NewEncoder(w).Encode(errorDto)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(w).Encode(pvzsWithReceptionsDto)
 		if err != nil {
+			logger.FromContext(r.Context(), pvzh.logger).Errorf("failed to encode response: %v", err)
 			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
 		return
 	}
 
-	pvzId, ok := mux.Vars(r)["pvzId"]
-	if !ok {
-		pvzh.logger.Errorf("failed to extract pvzId")
-		w.WriteHeader(http.StatusBadRequest)
+	total, err := pvzh.service.CountPVZs(r.Context(), filter)
+	if err != nil {
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("failed to count pvzs: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
 		errorDto := &dto.ErrorDto{
-			Message: "Некорректные данные",
+			Message: "Внутренняя ошибка сервера",
 		}
-		err := json.NewEncoder(w).Encode(errorDto)
+		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 		return
 	}
 
-	reception, err := pvzh.service.CloseLastReception(pvzId)
+	hasNext := page*limit < total
+	setPaginationLinks(w, r, page, hasNext)
+
+	w.Header().Set("Content-Type", dto.PageContentType)
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(dto.PVZPageDto{
+		Items:   pvzsWithReceptionsDto,
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+		HasNext: hasNext,
+	})
 	if err != nil {
-		pvzh.logger.Errorf("failed to close last reception: %v", err)
-		var errorDto *dto.ErrorDto
-		if errors.Is(err, dto.ErrPVZNotFound) {
-			pvzh.logger.Errorf("pvz not found: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			errorDto = &dto.ErrorDto{
-				Message: "ПВЗ не найден",
-			}
-		} else if errors.Is(err, dto.ErrNoActiveReception) {
-			pvzh.logger.Errorf("all receptions are closed: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			errorDto = &dto.ErrorDto{
-				Message: "Приемка уже закрыта",
-			}
-		} else {
-			pvzh.logger.Errorf("internal error: %v", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			errorDto = &dto.ErrorDto{
-				Message: "Внутренняя ошибка сервера",
+		logger.FromContext(r.Context(), pvzh.logger).Errorf("failed to encode response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// parsePVZSearchParams заполняет фильтр параметрами поиска и сортировки списка ПВЗ.
+// При ошибке возвращает сообщение для клиента
+func parsePVZSearchParams(r *http.Request, filter *models.PVZFilter) (string, error) {
+	query := r.URL.Query()
+
+	lists := []struct {
+		key     string
+		allowed []string
+		dest    *[]string
+	}{
+		{"city", dto.Cities, &filter.Cities},
+		{"pvzId", nil, &filter.PVZIds},
+		{"receptionStatus", dto.ReceptionStatuses, &filter.ReceptionStatuses},
+		{"productType", dto.ProductTypes, &filter.ProductTypes},
+	}
+
+	for _, list := range lists {
+		values := query[list.key]
+		for _, value := range values {
+			if list.allowed != nil && !slices.Contains(list.allowed, value) {
+				return "Невалидный параметр " + list.key, fmt.Errorf("invalid %s: %q", list.key, value)
 			}
 		}
-		err = json.NewEncoder(w).Encode(errorDto)
+		*list.dest = values
+	}
+
+	if query.Has("hasOpenReception") {
+		hasOpen, err := GetQueryParam(r, "hasOpenReception", false)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+			return "Невалидный параметр hasOpenReception", err
 		}
-		return
+		filter.HasOpenReception = &hasOpen
 	}
 
-	closeReceptionResponseDto := dto.CloseReceptionResponseDto{
-		Id:       reception.Id,
-		DateTime: reception.DateTime,
-		PVZId:    reception.PVZId,
-		Status:   reception.Status,
+	filter.SortBy, _ = GetQueryParam(r, "sortBy", models.PVZSortRegistrationDate)
+	if !slices.Contains(dto.PVZSortFields, filter.SortBy) {
+		return "Невалидный параметр sortBy", fmt.Errorf("invalid sortBy: %q", filter.SortBy)
 	}
 
-	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(closeReceptionResponseDto)
-	if err != nil {
-		pvzh.logger.Errorf("failed to encode response: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
+	filter.SortOrder, _ = GetQueryParam(r, "sortOrder", models.SortDesc)
+	if !slices.Contains(dto.SortOrders, filter.SortOrder) {
+		return "Невалидный параметр sortOrder", fmt.Errorf("invalid sortOrder: %q", filter.SortOrder)
 	}
+
+	return "", nil
 }
 
-// DeleteLastProduct godoc
-//
-//	@Summary		Удалить последний товар
-//	@Description	Удаляет последний добавленный товар из последней активной приемки для указанного ПВЗ
-//	@ID				delete-last-product
-//	@Tags			pvz
-//	@Accept			json
-//	@Produce		json
-//	@Param			pvzId	path	string	true	"Идентификатор ПВЗ"
-//
-//	@Success		200	{object}	nil							"Товар успешно удален"
-//	@Failure		400	{object}	dto.ErrorDto				"Некорректные данные / ПВЗ не найден / Нет активной приемки / Нет товаров для удаления"
-//	@Failure		403	{object}	dto.ErrorDto				"Доступ запрещен"
-//	@Failure		500	{object}	dto.ErrorDto				"Внутренняя ошибка сервера"
-//	@Security		ApiKeyAuth
-//	@Router			/pvz/{pvzId}/delete_last_product [post]
-func (pvzh *PVZHandler) DeleteLastProduct(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	key := middlewares.Key("props")
-	claims := ctx.Value(key).(jwt.MapClaims)
-	role := claims["role"].(string)
-	if role != dto.RoleEmployee {
-		pvzh.logger.Errorf("forbidden action : invalid role: %v", role)
-		w.WriteHeader(http.StatusForbidden)
-		errorDto := &dto.ErrorDto{
-			Message: "Доступ запрещен",
-		}
-		err := json.NewEncoder(w).Encode(errorDto)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
+// wantsPageEnvelope определяет, запросил ли клиент ответ-конверт: флагом envelope
+// или профилем в заголовке Accept
+func wantsPageEnvelope(r *http.Request) (bool, error) {
+	envelope, err := GetQueryParam(r, "envelope", false)
+	if err != nil || envelope {
+		return envelope, err
 	}
 
-	pvzId, ok := mux.Vars(r)["pvzId"]
-	if !ok {
-		pvzh.logger.Errorf("failed to extract pvzId")
-		w.WriteHeader(http.StatusBadRequest)
-		errorDto := &dto.ErrorDto{
-			Message: "Некорректные данные",
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && mediaType == "application/json" && params["profile"] == dto.PageProfile {
+			return true, nil
 		}
-		err := json.NewEncoder(w).Encode(errorDto)
+	}
+
+	return false, nil
+}
+
+// setPaginationLinks выставляет заголовок Link со ссылками на соседние страницы,
+// сохраняя остальные параметры запроса
+func setPaginationLinks(w http.ResponseWriter, r *http.Request, page int, hasNext bool) {
+	link := func(page int, rel string) string {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, query.Encode(), rel)
+	}
+
+	links := make([]string, 0, 2)
+	if page > 1 {
+		links = append(links, link(page-1, "prev"))
+	}
+	if hasNext {
+		links = append(links, link(page+1, "next"))
+	}
+
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+var errInvalidDateRange = errors.New("startDate should be before endDate")
+
+func parseDateRange(r *http.Request) (*time.Time, *time.Time, error) {
+	startDateStr, _ := GetQueryParam(r, "startDate", "")
+	endDateStr, _ := GetQueryParam(r, "endDate", "")
+
+	var startDate, endDate *time.Time
+	if startDateStr != "" {
+		tStart, err := time.Parse(time.RFC3339, startDateStr)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+			return nil, nil, err
 		}
-		return
+		startDate = &tStart
 	}
 
-	err := pvzh.service.DeleteLastProduct(pvzId)
-	if err != nil {
-		pvzh.logger.Errorf("failed to delete last product: %v", err)
-		var errorDto *dto.ErrorDto
-		if errors.Is(err, dto.ErrPVZNotFound) {
-			pvzh.logger.Errorf("pvz not found: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			errorDto = &dto.ErrorDto{
-				Message: "ПВЗ не найден",
-			}
-		} else if errors.Is(err, dto.ErrNoActiveReception) {
-			pvzh.logger.Errorf("no active receptions: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			errorDto = &dto.ErrorDto{
-				Message: "Нет активной приемки",
-			}
-		} else if errors.Is(err, dto.ErrNoProductsInReception) {
-			pvzh.logger.Errorf("no products in receptions: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			errorDto = &dto.ErrorDto{
-				Message: "Нет товаров для удаления",
-			}
-		}
-		err = json.NewEncoder(w).Encode(errorDto)
+	if endDateStr != "" {
+		tEnd, err := time.Parse(time.RFC3339, endDateStr)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+			return nil, nil, err
 		}
-		return
+		endDate = &tEnd
 	}
 
-	w.WriteHeader(http.StatusOK)
+	if startDate != nil && endDate != nil && !startDate.Before(*endDate) {
+		return nil, nil, errInvalidDateRange
+	}
+
+	return startDate, endDate, nil
+}
+
+// dateRangeErrorMessage отличает для клиента перепутанные границы периода
+// от неверного формата даты
+func dateRangeErrorMessage(err error) string {
+	if errors.Is(err, errInvalidDateRange) {
+		return "Дата начала периода должна быть раньше даты окончания"
+	}
+	return "Неверный формат даты. Используйте формат RFC3339: 2025-04-11T18:57:00+03:00"
 }
 
 func GetQueryParam[T any](r *http.Request, key string, defaultValue T) (T, error) {
@@ -448,6 +350,20 @@ func GetQueryParam[T any](r *http.Request, key string, defaultValue T) (T, error
 		}
 		return any(num).(T), nil
 
+	case bool:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return defaultValue, err
+		}
+		return any(flag).(T), nil
+
+	case float64:
+		num, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return defaultValue, err
+		}
+		return any(num).(T), nil
+
 	default:
 		return defaultValue, nil
 	}
@@ -1,90 +1,21 @@
 package handlers
 
 import (
-	"bytes"
-	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
-	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/golang/mock/gomock"
-	"github.com/gorilla/mux"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
-	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/mocks"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zaptest"
 )
 
-func withRole(role string, r *http.Request) *http.Request {
-	ctx := context.WithValue(r.Context(), middlewares.Key("props"), jwt.MapClaims{"role": role})
-	return r.WithContext(ctx)
-}
-
-func TestCreatePVZ_Forbidden(t *testing.T) {
-	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
-	req := httptest.NewRequest(http.MethodPost, "/pvz", nil)
-	req = withRole("employee", req)
-	w := httptest.NewRecorder()
-	handler.CreatePVZ(w, req)
-	assert.Equal(t, http.StatusForbidden, w.Code)
-}
-
-func TestCreatePVZ_InvalidJSON(t *testing.T) {
-	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
-	req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewBufferString("{bad json"))
-	req = withRole("moderator", req)
-	w := httptest.NewRecorder()
-	handler.CreatePVZ(w, req)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-}
-
-func TestCreatePVZ_InvalidCity(t *testing.T) {
-	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
-	body := dto.CreatePVZRequestDto{City: "Berlin"}
-	data, _ := json.Marshal(body)
-	req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewReader(data))
-	req = withRole("moderator", req)
-	w := httptest.NewRecorder()
-	handler.CreatePVZ(w, req)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-}
-
-func TestCreatePVZ_ServiceError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	service := mocks.NewMockPVZService(ctrl)
-	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
-	service.EXPECT().CreatePVZ(dto.Moscow).Return(models.PVZ{}, errors.New("db error"))
-	body := dto.CreatePVZRequestDto{City: dto.Moscow}
-	data, _ := json.Marshal(body)
-	req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewReader(data))
-	req = withRole("moderator", req)
-	w := httptest.NewRecorder()
-	handler.CreatePVZ(w, req)
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-}
-
-func TestCreatePVZ_Success(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	service := mocks.NewMockPVZService(ctrl)
-	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
-	pvz := models.PVZ{Id: "123", City: dto.Kazan, RegistrationDate: time.Now().String()}
-	service.EXPECT().CreatePVZ(dto.Kazan).Return(pvz, nil)
-	body := dto.CreatePVZRequestDto{City: dto.Kazan}
-	data, _ := json.Marshal(body)
-	req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewReader(data))
-	req = withRole("moderator", req)
-	w := httptest.NewRecorder()
-	handler.CreatePVZ(w, req)
-	assert.Equal(t, http.StatusCreated, w.Code)
-}
+var defaultPVZFilter = models.PVZFilter{SortBy: models.PVZSortRegistrationDate, SortOrder: models.SortDesc}
 
 func TestGetPVZWithPagination_InvalidPage(t *testing.T) {
 	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
@@ -110,12 +41,25 @@ func TestGetPVZWithPagination_InvalidDates(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestGetPVZWithPagination_InvalidDateRange(t *testing.T) {
+	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
+	req := httptest.NewRequest(http.MethodGet,
+		"/pvz?startDate=2025-04-12T00:00:00Z&endDate=2025-04-11T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+	handler.GetPVZWithPagination(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp dto.ErrorDto
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "Дата начала периода должна быть раньше даты окончания", resp.Message)
+}
+
 func TestGetPVZWithPagination_ServiceError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	service := mocks.NewMockPVZService(ctrl)
 	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
-	service.EXPECT().GetPVZWithPagination(nil, nil, 1, 10).Return(nil, errors.New("fail"))
+	service.EXPECT().GetPVZWithPagination(gomock.Any(), defaultPVZFilter, models.ExpandProducts, 1, 10).Return(nil, errors.New("fail"))
 	req := httptest.NewRequest(http.MethodGet, "/pvz", nil)
 	w := httptest.NewRecorder()
 	handler.GetPVZWithPagination(w, req)
@@ -127,118 +71,140 @@ func TestGetPVZWithPagination_Success(t *testing.T) {
 	defer ctrl.Finish()
 	service := mocks.NewMockPVZService(ctrl)
 	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
-	service.EXPECT().GetPVZWithPagination(nil, nil, 1, 10).Return([]models.PVZWithReceptions{}, nil)
+	service.EXPECT().GetPVZWithPagination(gomock.Any(), defaultPVZFilter, models.ExpandProducts, 1, 10).Return([]models.PVZWithReceptions{}, nil)
 	req := httptest.NewRequest(http.MethodGet, "/pvz", nil)
 	w := httptest.NewRecorder()
 	handler.GetPVZWithPagination(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
-func TestCloseLastReception_Forbidden(t *testing.T) {
-	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
-	req := httptest.NewRequest(http.MethodPost, "/pvz/pvz1/close_last_reception", nil)
-	req = withRole("moderator", req)
-	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz1"})
-	w := httptest.NewRecorder()
-	handler.CloseLastReception(w, req)
-	assert.Equal(t, http.StatusForbidden, w.Code)
-}
-
-func TestCloseLastReception_BadRequest_NoPVZId(t *testing.T) {
-	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
-	req := httptest.NewRequest(http.MethodPost, "/pvz/close_last_reception", nil)
-	req = withRole(dto.RoleEmployee, req)
+func TestGetPVZWithPagination_IncludeArchived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockPVZService(ctrl)
+	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
+	service.EXPECT().GetPVZWithPagination(gomock.Any(), models.PVZFilter{IncludeArchived: true, SortBy: models.PVZSortRegistrationDate, SortOrder: models.SortDesc}, models.ExpandProducts, 1, 10).Return([]models.PVZWithReceptions{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/pvz?includeArchived=true", nil)
 	w := httptest.NewRecorder()
-	handler.CloseLastReception(w, req)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	handler.GetPVZWithPagination(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
 }
 
-func TestCloseLastReception_ErrNoActiveReception(t *testing.T) {
+func TestGetPVZWithPagination_SearchParams(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	service := mocks.NewMockPVZService(ctrl)
 	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
+	hasOpen := false
 
-	service.EXPECT().CloseLastReception("pvz1").Return(models.Reception{}, dto.ErrNoActiveReception)
+	service.EXPECT().GetPVZWithPagination(gomock.Any(), models.PVZFilter{
+		Cities:            []string{"Москва", "Казань"},
+		PVZIds:            []string{"pvz1"},
+		ReceptionStatuses: []string{"close"},
+		ProductTypes:      []string{"обувь"},
+		HasOpenReception:  &hasOpen,
+		SortBy:            models.PVZSortLastActivity,
+		SortOrder:         models.SortAsc,
+	}, models.ExpandProducts, 1, 10).Return([]models.PVZWithReceptions{}, nil)
 
-	req := httptest.NewRequest(http.MethodPost, "/pvz/pvz1/close_last_reception", nil)
-	req = withRole(dto.RoleEmployee, req)
-	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz1"})
+	req := httptest.NewRequest(http.MethodGet, "/pvz?city=%D0%9C%D0%BE%D1%81%D0%BA%D0%B2%D0%B0&city=%D0%9A%D0%B0%D0%B7%D0%B0%D0%BD%D1%8C"+
+		"&pvzId=pvz1&receptionStatus=close&productType=%D0%BE%D0%B1%D1%83%D0%B2%D1%8C&hasOpenReception=false&sortBy=lastActivity&sortOrder=asc", nil)
 	w := httptest.NewRecorder()
-
-	handler.CloseLastReception(w, req)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	handler.GetPVZWithPagination(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
 }
 
-func TestCloseLastReception_Success(t *testing.T) {
+func TestGetPVZWithPagination_InvalidSearchParams(t *testing.T) {
+	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
+
+	for _, query := range []string{
+		"city=Unknown",
+		"receptionStatus=unknown",
+		"productType=unknown",
+		"hasOpenReception=maybe",
+		"sortBy=name",
+		"sortOrder=up",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/pvz?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.GetPVZWithPagination(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetPVZWithPagination_EnvelopeByAcceptProfile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	service := mocks.NewMockPVZService(ctrl)
 	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
 
-	rec := models.Reception{Id: "rec1", PVZId: "pvz1", Status: "close", DateTime: time.Now().String()}
-	service.EXPECT().CloseLastReception("pvz1").Return(rec, nil)
+	service.EXPECT().GetPVZWithPagination(gomock.Any(), defaultPVZFilter, models.ExpandProducts, 2, 1).
+		Return([]models.PVZWithReceptions{{PVZ: models.PVZ{Id: "pvz2"}}}, nil)
+	service.EXPECT().CountPVZs(gomock.Any(), defaultPVZFilter).Return(3, nil)
 
-	req := httptest.NewRequest(http.MethodPost, "/pvz/pvz1/close_last_reception", nil)
-	req = withRole(dto.RoleEmployee, req)
-	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz1"})
+	req := httptest.NewRequest(http.MethodGet, "/pvz?page=2&limit=1", nil)
+	req.Header.Set("Accept", `application/json; profile="paginated"`)
 	w := httptest.NewRecorder()
-
-	handler.CloseLastReception(w, req)
+	handler.GetPVZWithPagination(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
-}
+	assert.Equal(t, dto.PageContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, `</pvz?limit=1&page=1>; rel="prev", </pvz?limit=1&page=3>; rel="next"`, w.Header().Get("Link"))
 
-func TestDeleteLastProduct_Forbidden(t *testing.T) {
-	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
-	req := httptest.NewRequest(http.MethodPost, "/pvz/pvz1/delete_last_product", nil)
-	req = withRole("moderator", req)
-	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz1"})
-	w := httptest.NewRecorder()
-	handler.DeleteLastProduct(w, req)
-	assert.Equal(t, http.StatusForbidden, w.Code)
+	var resp dto.PVZPageDto
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, 2, resp.Page)
+	assert.Equal(t, 1, resp.Limit)
+	assert.Equal(t, 3, resp.Total)
+	assert.True(t, resp.HasNext)
 }
 
-func TestDeleteLastProduct_BadRequest_NoPVZId(t *testing.T) {
-	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
-	req := httptest.NewRequest(http.MethodPost, "/pvz/delete_last_product", nil)
-	req = withRole(dto.RoleEmployee, req)
-	w := httptest.NewRecorder()
-	handler.DeleteLastProduct(w, req)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-}
-
-func TestDeleteLastProduct_ErrNoProducts(t *testing.T) {
+func TestGetPVZWithPagination_EnvelopeLastPage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-
 	service := mocks.NewMockPVZService(ctrl)
 	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
 
-	service.EXPECT().DeleteLastProduct("pvz1").Return(dto.ErrNoProductsInReception)
+	service.EXPECT().GetPVZWithPagination(gomock.Any(), defaultPVZFilter, models.ExpandProducts, 1, 10).Return([]models.PVZWithReceptions{}, nil)
+	service.EXPECT().CountPVZs(gomock.Any(), defaultPVZFilter).Return(0, nil)
 
-	req := httptest.NewRequest(http.MethodPost, "/pvz/pvz1/delete_last_product", nil)
-	req = withRole(dto.RoleEmployee, req)
-	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz1"})
+	req := httptest.NewRequest(http.MethodGet, "/pvz?envelope=true", nil)
 	w := httptest.NewRecorder()
+	handler.GetPVZWithPagination(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Link"))
 
-	handler.DeleteLastProduct(w, req)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var resp dto.PVZPageDto
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, 0, resp.Total)
+	assert.False(t, resp.HasNext)
+	assert.NotNil(t, resp.Items)
 }
 
-func TestDeleteLastProduct_Success(t *testing.T) {
+func TestGetPVZWithPagination_ExpandPVZ(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-
 	service := mocks.NewMockPVZService(ctrl)
 	handler := NewPVZHandler(service, zaptest.NewLogger(t).Sugar())
 
-	service.EXPECT().DeleteLastProduct("pvz1").Return(nil)
+	service.EXPECT().GetPVZWithPagination(gomock.Any(), defaultPVZFilter, models.ExpandPVZ, 1, 10).
+		Return([]models.PVZWithReceptions{{PVZ: models.PVZ{Id: "pvz1"}}}, nil)
 
-	req := httptest.NewRequest(http.MethodPost, "/pvz/pvz1/delete_last_product", nil)
-	req = withRole(dto.RoleEmployee, req)
-	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz1"})
+	req := httptest.NewRequest(http.MethodGet, "/pvz?expand=pvz", nil)
 	w := httptest.NewRecorder()
-
-	handler.DeleteLastProduct(w, req)
+	handler.GetPVZWithPagination(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp []dto.PVZDto
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, "pvz1", resp[0].Id)
+}
+
+func TestGetPVZWithPagination_InvalidExpand(t *testing.T) {
+	handler := NewPVZHandler(nil, zaptest.NewLogger(t).Sugar())
+	req := httptest.NewRequest(http.MethodGet, "/pvz?expand=everything", nil)
+	w := httptest.NewRecorder()
+	handler.GetPVZWithPagination(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
@@ -2,20 +2,24 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
+	"time"
 
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/hamillka/avitoTechSpring25/internal/export"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
-	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
-	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"go.uber.org/zap"
 )
 
 type ReceptionService interface {
-	CreateReception(pvzId string) (models.Reception, error)
+	GetPVZReceptions(ctx context.Context, pvzId string, filter models.ReceptionListFilter, page, limit int) ([]models.Reception, error)
+	ExportReceptions(ctx context.Context, filter models.PVZFilter, fn func(row models.ReceptionExportRow) error) error
 }
 
 type ReceptionHandler struct {
@@ -30,49 +34,63 @@ func NewReceptionHandler(service ReceptionService, logger *zap.SugaredLogger) *R
 	}
 }
 
-// CreateReception godoc
-//
-//	@Summary		Создать приемку
-//	@Description	Создает новую приемку товаров для указанного ПВЗ
-//	@ID				create-reception
-//	@Tags			receptions
-//	@Accept			json
-//	@Produce		json
-//	@Param			body	body	dto.CreateReceptionRequestDto	true	"Информация о создаваемой приемке"
-//
-//	@Success		201	{object}	dto.CreateReceptionResponseDto	"Приемка успешно создана"
-//	@Failure		400	{object}	dto.ErrorDto					"Некорректные данные / ПВЗ не найден / ПВЗ уже имеет незакрытую приемку"
-//	@Failure		403	{object}	dto.ErrorDto					"Доступ запрещен"
-//	@Failure		500	{object}	dto.ErrorDto					"Внутренняя ошибка сервера"
-//	@Security		ApiKeyAuth
-//	@Router			/receptions [post]
-func (rh *ReceptionHandler) CreateReception(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	key := middlewares.Key("props")
-	claims := ctx.Value(key).(jwt.MapClaims)
-	role := claims["role"].(string)
-	if role != dto.RoleEmployee {
-		rh.logger.Errorf("forbidden action : invalid role: %v", role)
-		w.WriteHeader(http.StatusForbidden)
+// GetPVZReceptions обслуживает GET /pvz/{pvzId}/receptions (ReceptionService.ListPVZReceptions
+// в pvz.proto) в обход REST шлюза, чтобы отдавать ссылки на соседние страницы в заголовке Link
+func (rh *ReceptionHandler) GetPVZReceptions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+
+	pvzId := mux.Vars(r)["pvzId"]
+
+	statuses := r.URL.Query()["status"]
+	for _, status := range statuses {
+		if !slices.Contains(dto.ReceptionStatuses, status) {
+			logger.FromContext(r.Context(), rh.logger).Errorf("invalid reception status: %v", status)
+			w.WriteHeader(http.StatusBadRequest)
+			errorDto := &dto.ErrorDto{
+				Message: "Невалидный параметр status",
+			}
+			err := json.NewEncoder(w).Encode(errorDto)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	startDate, endDate, err := parseDateRange(r)
+	if err != nil {
+		logger.FromContext(r.Context(), rh.logger).Errorf("invalid date range: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Доступ запрещен",
+			Message: dateRangeErrorMessage(err),
 		}
-		err := json.NewEncoder(w).Encode(errorDto)
+		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 		return
 	}
 
-	var createReceptionRequestDto dto.CreateReceptionRequestDto
+	page, err := GetQueryParam(r, "page", 1)
+	if err != nil || page < 1 {
+		logger.FromContext(r.Context(), rh.logger).Errorf("error in extracting page from query: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		errorDto := &dto.ErrorDto{
+			Message: "Невалидный параметр page",
+		}
+		err = json.NewEncoder(w).Encode(errorDto)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
 
-	w.Header().Add("Content-Type", "application/json")
-	err := json.NewDecoder(r.Body).Decode(&createReceptionRequestDto)
-	if err != nil {
-		rh.logger.Errorf("failed to decode request body: %v", err)
+	limit, err := GetQueryParam(r, "limit", 10)
+	if err != nil || limit < 1 || limit > 30 {
+		logger.FromContext(r.Context(), rh.logger).Errorf("error in extracting limit from query: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		errorDto := &dto.ErrorDto{
-			Message: "Некорректные данные",
+			Message: "Невалидный параметр limit",
 		}
 		err = json.NewEncoder(w).Encode(errorDto)
 		if err != nil {
@@ -81,23 +99,22 @@ func (rh *ReceptionHandler) CreateReception(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	reception, err := rh.service.CreateReception(createReceptionRequestDto.PVZId)
+	filter := models.ReceptionListFilter{
+		Statuses:  statuses,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	receptions, err := rh.service.GetPVZReceptions(r.Context(), pvzId, filter, page, limit)
 	if err != nil {
+		logger.FromContext(r.Context(), rh.logger).Errorf("failed to get pvz receptions: %v", err)
 		var errorDto *dto.ErrorDto
 		if errors.Is(err, dto.ErrPVZNotFound) {
-			rh.logger.Errorf("failed to create reception: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(http.StatusNotFound)
 			errorDto = &dto.ErrorDto{
 				Message: "ПВЗ не найден",
 			}
-		} else if errors.Is(err, dto.ErrPVZAlreadyHasReception) {
-			rh.logger.Errorf("failed to create reception: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			errorDto = &dto.ErrorDto{
-				Message: "ПВЗ уже имеет незакрытую приемку",
-			}
 		} else {
-			rh.logger.Errorf("failed to create reception: %v", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			errorDto = &dto.ErrorDto{
 				Message: "Внутренняя ошибка сервера",
@@ -110,19 +127,166 @@ func (rh *ReceptionHandler) CreateReception(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	createReceptionResponseDto := &dto.CreateReceptionResponseDto{
-		Id:       reception.Id,
-		DateTime: reception.DateTime,
-		PVZId:    reception.PVZId,
-		Status:   reception.Status,
+	receptionsDto := make([]dto.ReceptionDto, 0, len(receptions))
+	for _, reception := range receptions {
+		receptionsDto = append(receptionsDto, dto.ReceptionDto{
+			Id:       reception.Id,
+			DateTime: reception.DateTime,
+			PVZId:    reception.PVZId,
+			Status:   reception.Status,
+		})
+	}
+
+	setPaginationLinks(w, r, page, len(receptions) == limit)
+
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(receptionsDto)
+	if err != nil {
+		logger.FromContext(r.Context(), rh.logger).Errorf("failed to encode response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+const exportFlushEvery = 500
+
+// ExportReceptions обслуживает GET /export/receptions (ReceptionService.ExportReceptions
+// в pvz.proto) в обход REST шлюза: файл потоково пишется в ответ с заголовком Content-Disposition
+func (rh *ReceptionHandler) ExportReceptions(w http.ResponseWriter, r *http.Request) {
+	format, _ := GetQueryParam(r, "format", export.FormatCSV)
+	if format != export.FormatCSV && format != export.FormatXLSX {
+		logger.FromContext(r.Context(), rh.logger).Errorf("invalid export format: %v", format)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		errorDto := &dto.ErrorDto{
+			Message: "Невалидный параметр format",
+		}
+		err := json.NewEncoder(w).Encode(errorDto)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	startDate, endDate, err := parseDateRange(r)
+	if err != nil {
+		logger.FromContext(r.Context(), rh.logger).Errorf("invalid date range: %v", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		errorDto := &dto.ErrorDto{
+			Message: dateRangeErrorMessage(err),
+		}
+		err = json.NewEncoder(w).Encode(errorDto)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	cities := r.URL.Query()["city"]
+	for _, city := range cities {
+		if !slices.Contains(dto.Cities, city) {
+			logger.FromContext(r.Context(), rh.logger).Errorf("invalid city: %v", city)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			errorDto := &dto.ErrorDto{
+				Message: "Невалидный параметр city",
+			}
+			err = json.NewEncoder(w).Encode(errorDto)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	filter := models.PVZFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Cities:    cities,
+		PVZIds:    r.URL.Query()["pvzId"],
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	err = json.NewEncoder(w).Encode(createReceptionResponseDto)
+	stream := &exportStream{w: w, format: format}
+	err = rh.service.ExportReceptions(r.Context(), filter, stream.write)
 	if err != nil {
-		rh.logger.Errorf("failed to encode response: %v", err)
+		logger.FromContext(r.Context(), rh.logger).Errorf("failed to export receptions: %v", err)
+		if stream.writer != nil {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
+		errorDto := &dto.ErrorDto{
+			Message: "Внутренняя ошибка сервера",
+		}
+		err = json.NewEncoder(w).Encode(errorDto)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	err = stream.close()
+	if err != nil {
+		logger.FromContext(r.Context(), rh.logger).Errorf("failed to finish export: %v", err)
+	}
+}
+
+// exportStream откладывает запись заголовков ответа до первой строки выгрузки,
+// чтобы ошибку запроса к БД еще можно было вернуть как 500
+type exportStream struct {
+	w      http.ResponseWriter
+	format string
+	writer export.Writer
+	rows   int
+}
+
+func (es *exportStream) start() error {
+	filename := "receptions_" + time.Now().Format("20060102_150405") + "." + es.format
+
+	es.w.Header().Set("Content-Type", export.ContentType(es.format))
+	es.w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	es.w.WriteHeader(http.StatusOK)
+
+	writer, err := export.NewWriter(es.format, es.w)
+	if err != nil {
+		return err
+	}
+	es.writer = writer
+
+	return es.writer.Write(export.Header)
+}
+
+func (es *exportStream) write(row models.ReceptionExportRow) error {
+	if es.writer == nil {
+		if err := es.start(); err != nil {
+			return err
+		}
+	}
+
+	err := es.writer.Write(export.Record(row))
+	if err != nil {
+		return err
+	}
+
+	es.rows++
+	if es.rows%exportFlushEvery == 0 {
+		if err = es.writer.Flush(); err != nil {
+			return err
+		}
+		if flusher, ok := es.w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	return nil
+}
+
+func (es *exportStream) close() error {
+	if es.writer == nil {
+		if err := es.start(); err != nil {
+			return err
+		}
 	}
 
-	metrics.ReceptionsCreated.Inc()
+	return es.writer.Close()
 }
@@ -1,15 +1,18 @@
 package handlers
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
-	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/mocks"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
@@ -17,93 +20,135 @@ import (
 	"go.uber.org/zap/zaptest"
 )
 
-func TestCreateReception_Forbidden(t *testing.T) {
+func TestExportReceptions_InvalidFormat(t *testing.T) {
 	handler := NewReceptionHandler(nil, zaptest.NewLogger(t).Sugar())
-	req := httptest.NewRequest(http.MethodPost, "/receptions", nil)
-	req = withRole("moderator", req)
+	req := httptest.NewRequest(http.MethodGet, "/export/receptions?format=pdf", nil)
 	w := httptest.NewRecorder()
-	handler.CreateReception(w, req)
-	assert.Equal(t, http.StatusForbidden, w.Code)
+	handler.ExportReceptions(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
-func TestCreateReception_InvalidJSON(t *testing.T) {
+func TestExportReceptions_InvalidCity(t *testing.T) {
 	handler := NewReceptionHandler(nil, zaptest.NewLogger(t).Sugar())
-	req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewBufferString("{bad json"))
-	req = withRole(dto.RoleEmployee, req)
+	req := httptest.NewRequest(http.MethodGet, "/export/receptions?city=Berlin", nil)
 	w := httptest.NewRecorder()
-	handler.CreateReception(w, req)
+	handler.ExportReceptions(w, req)
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
-func TestCreateReception_ErrPVZNotFound(t *testing.T) {
+func TestExportReceptions_ServiceError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	service := mocks.NewMockReceptionService(ctrl)
 	handler := NewReceptionHandler(service, zaptest.NewLogger(t).Sugar())
 
-	reqDto := dto.CreateReceptionRequestDto{PVZId: "pvz404"}
-	jsonBody, _ := json.Marshal(reqDto)
-	req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(jsonBody))
-	req = withRole(dto.RoleEmployee, req)
-
-	service.EXPECT().CreateReception(reqDto.PVZId).Return(models.Reception{}, dto.ErrPVZNotFound)
+	service.EXPECT().ExportReceptions(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
 
+	req := httptest.NewRequest(http.MethodGet, "/export/receptions", nil)
 	w := httptest.NewRecorder()
-	handler.CreateReception(w, req)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	handler.ExportReceptions(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
-func TestCreateReception_ErrAlreadyHasReception(t *testing.T) {
+func TestExportReceptions_CSVSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	service := mocks.NewMockReceptionService(ctrl)
 	handler := NewReceptionHandler(service, zaptest.NewLogger(t).Sugar())
 
-	reqDto := dto.CreateReceptionRequestDto{PVZId: "pvz1"}
-	jsonBody, _ := json.Marshal(reqDto)
-	req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(jsonBody))
-	req = withRole(dto.RoleEmployee, req)
+	filter := models.PVZFilter{Cities: []string{dto.Kazan}, PVZIds: []string{"pvz1"}}
+	service.EXPECT().ExportReceptions(gomock.Any(), filter, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.PVZFilter, fn func(models.ReceptionExportRow) error) error {
+			return fn(models.ReceptionExportRow{
+				PVZId:           "pvz1",
+				City:            dto.Kazan,
+				ReceptionId:     "rec1",
+				ReceptionStatus: models.CLOSE,
+				ProductId:       "prod1",
+				ProductType:     dto.ProductTypeShoes,
+			})
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/export/receptions?city=Казань&pvzId=pvz1", nil)
+	w := httptest.NewRecorder()
+	handler.ExportReceptions(w, req)
 
-	service.EXPECT().CreateReception(reqDto.PVZId).Return(models.Reception{}, dto.ErrPVZAlreadyHasReception)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
 
-	w := httptest.NewRecorder()
-	handler.CreateReception(w, req)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	records, err := csv.NewReader(w.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "rec1", records[1][2])
+	assert.Equal(t, dto.ProductTypeShoes, records[1][7])
 }
 
-func TestCreateReception_InternalError(t *testing.T) {
+func TestExportReceptions_XLSXSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	service := mocks.NewMockReceptionService(ctrl)
 	handler := NewReceptionHandler(service, zaptest.NewLogger(t).Sugar())
 
-	reqDto := dto.CreateReceptionRequestDto{PVZId: "pvz1"}
-	jsonBody, _ := json.Marshal(reqDto)
-	req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(jsonBody))
-	req = withRole(dto.RoleEmployee, req)
+	service.EXPECT().ExportReceptions(gomock.Any(), models.PVZFilter{}, gomock.Any()).Return(nil)
 
-	service.EXPECT().CreateReception(reqDto.PVZId).Return(models.Reception{}, errors.New("db failure"))
+	req := httptest.NewRequest(http.MethodGet, "/export/receptions?format=xlsx", nil)
+	w := httptest.NewRecorder()
+	handler.ExportReceptions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, archive.File, 5)
+}
 
+func TestGetPVZReceptions_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockReceptionService(ctrl)
+	handler := NewReceptionHandler(service, zaptest.NewLogger(t).Sugar())
+
+	filter := models.ReceptionListFilter{Statuses: []string{models.CLOSE}}
+	service.EXPECT().GetPVZReceptions(gomock.Any(), "pvz1", filter, 1, 1).
+		Return([]models.Reception{{Id: "rec1", PVZId: "pvz1", Status: models.CLOSE}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pvz/pvz1/receptions?status=close&limit=1", nil)
+	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz1"})
 	w := httptest.NewRecorder()
-	handler.CreateReception(w, req)
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	handler.GetPVZReceptions(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Link"), `rel="next"`)
+
+	var receptions []dto.ReceptionDto
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&receptions))
+	assert.Len(t, receptions, 1)
 }
 
-func TestCreateReception_Success(t *testing.T) {
+func TestGetPVZReceptions_InvalidStatus(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	service := mocks.NewMockReceptionService(ctrl)
 	handler := NewReceptionHandler(service, zaptest.NewLogger(t).Sugar())
 
-	reception := models.Reception{Id: "rec123", PVZId: "pvz1", Status: "in_progress", DateTime: time.Now().String()}
-	reqDto := dto.CreateReceptionRequestDto{PVZId: "pvz1"}
-	jsonBody, _ := json.Marshal(reqDto)
-	req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(jsonBody))
-	req = withRole(dto.RoleEmployee, req)
+	req := httptest.NewRequest(http.MethodGet, "/pvz/pvz1/receptions?status=unknown", nil)
+	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz1"})
+	w := httptest.NewRecorder()
+	handler.GetPVZReceptions(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetPVZReceptions_PVZNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mocks.NewMockReceptionService(ctrl)
+	handler := NewReceptionHandler(service, zaptest.NewLogger(t).Sugar())
 
-	service.EXPECT().CreateReception(reqDto.PVZId).Return(reception, nil)
+	service.EXPECT().GetPVZReceptions(gomock.Any(), "pvz404", models.ReceptionListFilter{}, 1, 10).
+		Return(nil, dto.ErrPVZNotFound)
 
+	req := httptest.NewRequest(http.MethodGet, "/pvz/pvz404/receptions", nil)
+	req = mux.SetURLVars(req, map[string]string{"pvzId": "pvz404"})
 	w := httptest.NewRecorder()
-	handler.CreateReception(w, req)
-	assert.Equal(t, http.StatusCreated, w.Code)
+	handler.GetPVZReceptions(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
 }
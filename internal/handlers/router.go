@@ -1,46 +1,90 @@
 package handlers
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
 	_ "github.com/hamillka/avitoTechSpring25/api"
+	"github.com/hamillka/avitoTechSpring25/internal/gateway"
+	"github.com/hamillka/avitoTechSpring25/internal/grpc/pvz_v1"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/ratelimit"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"go.uber.org/zap"
 )
 
+// Router публикует маршруты API, описанные в pvz.proto. Запросы уходят в REST
+// шлюз gw, кроме методов, чьи HTTP ответы не выражаются через proto (заголовки
+// Link, выгрузка файлов): их обслуживают обработчики этого пакета
 func Router(
-	ps ProductService,
 	pvzs PVZService,
 	rs ReceptionService,
-	us UserService,
+	gw http.Handler,
+	routes []gateway.Route,
+	hc HealthChecker,
+	users middlewares.UserChecker,
+	limiter *ratelimit.Limiter,
 	logger *zap.SugaredLogger,
 ) *mux.Router {
 	router := mux.NewRouter()
-	router.Use(middlewares.MetricsMiddleware)
+	common := []mux.MiddlewareFunc{
+		middlewares.MetricsMiddleware,
+		middlewares.TracingMiddleware,
+		middlewares.LoggingMiddleware(logger),
+		middlewares.ClientIPMiddleware(limiter.ClientIP),
+	}
+	router.Use(common...)
+
+	// Middleware роутера не вызываются для запросов без маршрута, поэтому
+	// ответы 404 и 405 оборачиваются в ту же цепочку явно
+	router.NotFoundHandler = wrap(http.NotFoundHandler(), common)
+	router.MethodNotAllowedHandler = wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}), common)
 
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
+	hh := NewHealthHandler(hc, logger)
+	router.HandleFunc("/healthz", hh.Healthz).Methods("GET")
+	router.HandleFunc("/readyz", hh.Readyz).Methods("GET")
+
 	auth := router.PathPrefix("").Subrouter()
 	fun := router.PathPrefix("").Subrouter()
 
-	fun.Use(middlewares.AuthMiddleware)
+	fun.Use(middlewares.AuthMiddleware(users))
 
-	ph := NewProductHandler(ps, logger)
 	pvzh := NewPVZHandler(pvzs, logger)
 	rh := NewReceptionHandler(rs, logger)
-	uh := NewUserHandler(us, logger)
 
-	auth.HandleFunc("/login", uh.Login).Methods("POST")
-	auth.HandleFunc("/register", uh.Register).Methods("POST")
-	auth.HandleFunc("/dummyLogin", uh.DummyLogin).Methods("POST")
+	custom := map[string]http.HandlerFunc{
+		pvz_v1.PVZService_ListPVZ_FullMethodName:                 pvzh.GetPVZWithPagination,
+		pvz_v1.ReceptionService_ListPVZReceptions_FullMethodName: rh.GetPVZReceptions,
+		pvz_v1.ReceptionService_ExportReceptions_FullMethodName:  rh.ExportReceptions,
+	}
 
-	fun.HandleFunc("/pvz", pvzh.CreatePVZ).Methods("POST")
-	fun.HandleFunc("/pvz", pvzh.GetPVZWithPagination).Methods("GET")
-	fun.HandleFunc("/pvz/{pvzId}/close_last_reception", pvzh.CloseLastReception).Methods("POST")
-	fun.HandleFunc("/pvz/{pvzId}/delete_last_product", pvzh.DeleteLastProduct).Methods("POST")
+	for _, route := range routes {
+		handler := gw
+		if h, ok := custom[route.FullMethod]; ok {
+			handler = h
+		}
 
-	fun.HandleFunc("/receptions", rh.CreateReception).Methods("POST")
-	fun.HandleFunc("/products", ph.AddProductToReception).Methods("POST")
+		subrouter := fun
+		if route.Public {
+			subrouter = auth
+		}
+
+		// Лимит подключается к маршруту, а не к подроутеру: так он знает метод
+		// API и срабатывает после AuthMiddleware
+		limited := middlewares.RateLimitMiddleware(limiter, route.FullMethod)(handler)
+		subrouter.Handle(route.Path, limited).Methods(route.Method)
+	}
 
 	return router
 }
+
+func wrap(h http.Handler, mws []mux.MiddlewareFunc) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
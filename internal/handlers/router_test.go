@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/gateway"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/middlewares"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/mocks"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+	"github.com/hamillka/avitoTechSpring25/internal/ratelimit"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRouter_MetricsByRouteTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gw := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router := Router(
+		mocks.NewMockPVZService(ctrl),
+		mocks.NewMockReceptionService(ctrl),
+		gw,
+		gateway.Routes(),
+		mocks.NewMockHealthChecker(ctrl),
+		nil,
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+	)
+
+	const route = "/pvz/{pvzId}/close_last_reception"
+	before := testutil.ToFloat64(metrics.HTTPRequestCount.WithLabelValues(http.MethodPost, route, "401"))
+	unmatchedBefore := testutil.ToFloat64(metrics.HTTPRequestCount.WithLabelValues(http.MethodGet, middlewares.UnmatchedRoute, "404"))
+
+	for _, id := range []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pvz/"+id+"/close_last_reception", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.NotEmpty(t, w.Header().Get(middlewares.RequestIDHeader))
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/no/such/path", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	assert.Equal(t, before+2, testutil.ToFloat64(metrics.HTTPRequestCount.WithLabelValues(http.MethodPost, route, "401")))
+	assert.Equal(t, unmatchedBefore+1, testutil.ToFloat64(metrics.HTTPRequestCount.WithLabelValues(http.MethodGet, middlewares.UnmatchedRoute, "404")))
+}
+
+func TestRouter_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gw := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	limiter := ratelimit.NewLimiter(ratelimit.Config{
+		Rules: ratelimit.Rules{"Login": {Rate: 1.0 / 60, Burst: 1}},
+	}, ratelimit.NewMemory())
+	router := Router(
+		mocks.NewMockPVZService(ctrl),
+		mocks.NewMockReceptionService(ctrl),
+		gw,
+		gateway.Routes(),
+		mocks.NewMockHealthChecker(ctrl),
+		nil,
+		limiter,
+		zaptest.NewLogger(t).Sugar(),
+	)
+
+	login := func(remoteAddr string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/login", nil)
+		r.RemoteAddr = remoteAddr
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, login("10.0.0.1:1000").Code)
+
+	w := login("10.0.0.1:2000")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get(middlewares.RetryAfterHeader))
+
+	assert.Equal(t, http.StatusOK, login("10.0.0.2:1000").Code)
+}
+
+type disabledUsers struct{}
+
+func (disabledUsers) ActiveUserRole(context.Context, string) (string, error) {
+	return "", dto.ErrUserDisabled
+}
+
+func TestRouter_DisabledUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gw := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router := Router(
+		mocks.NewMockPVZService(ctrl),
+		mocks.NewMockReceptionService(ctrl),
+		gw,
+		gateway.Routes(),
+		mocks.NewMockHealthChecker(ctrl),
+		disabledUsers{},
+		nil,
+		zaptest.NewLogger(t).Sugar(),
+	)
+
+	request := func(userId string) *httptest.ResponseRecorder {
+		token, err := middlewares.CreateToken(userId, dto.RoleModerator)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/pvz", nil)
+		r.Header.Set("auth-x", "Bearer "+token)
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := request("11111111-1111-1111-1111-111111111111")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var resp dto.ErrorDto
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "Учетная запись отключена", resp.Message)
+
+	// токены dummyLogin не привязаны к пользователю и не проверяются
+	assert.Equal(t, http.StatusOK, request("").Code)
+}
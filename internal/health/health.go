@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Config struct {
+	Timeout  time.Duration `default:"2s" envconfig:"TIMEOUT"`
+	Interval time.Duration `default:"5s" envconfig:"INTERVAL"`
+}
+
+type Check func(ctx context.Context) error
+
+// Checker выполняет проверки зависимостей сервиса (база, версия схемы)
+// и хранит признак остановки: после начала остановки сервис перестает
+// считаться готовым, даже если все зависимости доступны
+type Checker struct {
+	timeout      time.Duration
+	names        []string
+	checks       map[string]Check
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  make(map[string]Check),
+	}
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.names = append(c.names, name)
+	c.checks[name] = check
+}
+
+// Check запускает все проверки параллельно и возвращает ошибку по каждой из них
+// (nil для успешных)
+func (c *Checker) Check(ctx context.Context) map[string]error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]error, len(c.names))
+	)
+
+	for _, name := range c.names {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			err := check(ctx)
+
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}(name, c.checks[name])
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (c *Checker) Healthy(ctx context.Context) bool {
+	for _, err := range c.Check(ctx) {
+		if err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+func (c *Checker) ShuttingDown() bool {
+	return c.shuttingDown.Load()
+}
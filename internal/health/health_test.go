@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("database", func(context.Context) error { return nil })
+	checker.Add("schema", func(context.Context) error { return errors.New("outdated") })
+
+	results := checker.Check(context.Background())
+
+	assert.NoError(t, results["database"])
+	assert.EqualError(t, results["schema"], "outdated")
+	assert.False(t, checker.Healthy(context.Background()))
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Add("database", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	results := checker.Check(context.Background())
+
+	assert.ErrorIs(t, results["database"], context.DeadlineExceeded)
+}
+
+func TestChecker_Shutdown(t *testing.T) {
+	checker := NewChecker(time.Second)
+	assert.False(t, checker.ShuttingDown())
+
+	checker.Shutdown()
+	assert.True(t, checker.ShuttingDown())
+}
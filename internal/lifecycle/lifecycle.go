@@ -0,0 +1,162 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Component — долгоживущая часть сервиса: сервер или фоновый воркер.
+// Start блокируется до остановки и возвращает nil, если остановка штатная.
+// Stop должен уложиться в переданный контекст
+type Component struct {
+	Name  string
+	Start func() error
+	Stop  func(ctx context.Context) error
+}
+
+// Runner запускает компоненты и останавливает их все вместе: по отмене
+// контекста (сигнал) или при падении любого из них. Перед остановкой
+// вызываются хуки OnShutdown, чтобы снять готовность до закрытия листенеров
+type Runner struct {
+	logger     *zap.SugaredLogger
+	timeout    time.Duration
+	components []Component
+	hooks      []func()
+}
+
+func New(logger *zap.SugaredLogger, timeout time.Duration) *Runner {
+	return &Runner{
+		logger:  logger,
+		timeout: timeout,
+	}
+}
+
+func (r *Runner) Add(components ...Component) {
+	r.components = append(r.components, components...)
+}
+
+func (r *Runner) OnShutdown(hook func()) {
+	r.hooks = append(r.hooks, hook)
+}
+
+func (r *Runner) Run(ctx context.Context) error {
+	errs := make(chan error, len(r.components))
+
+	var wg sync.WaitGroup
+	for _, c := range r.components {
+		wg.Add(1)
+		go func(c Component) {
+			defer wg.Done()
+			r.logger.Infof("starting %s", c.Name)
+			if err := c.Start(); err != nil {
+				r.logger.Errorf("%s failed: %v", c.Name, err)
+				errs <- err
+			}
+		}(c)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		r.logger.Info("shutdown signal received")
+	case runErr = <-errs:
+	}
+
+	for _, hook := range r.hooks {
+		hook()
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var stopWg sync.WaitGroup
+	stopErrs := make([]error, len(r.components))
+	for i, c := range r.components {
+		stopWg.Add(1)
+		go func(i int, c Component) {
+			defer stopWg.Done()
+			if err := c.Stop(stopCtx); err != nil {
+				r.logger.Errorf("failed to stop %s: %v", c.Name, err)
+				stopErrs[i] = err
+			}
+		}(i, c)
+	}
+	stopWg.Wait()
+	wg.Wait()
+
+	r.logger.Info("all components stopped")
+
+	return errors.Join(append([]error{runErr}, stopErrs...)...)
+}
+
+func HTTPServer(name string, srv *http.Server) Component {
+	return Component{
+		Name: name,
+		Start: func() error {
+			err := srv.ListenAndServe()
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return err
+		},
+		Stop: srv.Shutdown,
+	}
+}
+
+// GRPCServer дожидается завершения активных RPC через GracefulStop, а если
+// они не уложились в таймаут, обрывает их через Stop
+func GRPCServer(name string, srv *grpc.Server, lis net.Listener) Component {
+	return Component{
+		Name: name,
+		Start: func() error {
+			return srv.Serve(lis)
+		},
+		Stop: func(ctx context.Context) error {
+			done := make(chan struct{})
+			go func() {
+				srv.GracefulStop()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				srv.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// Worker запускает фоновую задачу, которая работает до отмены своего контекста
+func Worker(name string, run func(ctx context.Context)) Component {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	return Component{
+		Name: name,
+		Start: func() error {
+			defer close(done)
+			run(ctx)
+			return nil
+		},
+		Stop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	}
+}
@@ -0,0 +1,135 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+)
+
+func TestRunner_StopsOnContextCancel(t *testing.T) {
+	runner := New(zaptest.NewLogger(t).Sugar(), time.Second)
+
+	var stopped, hookCalled bool
+	runner.OnShutdown(func() { hookCalled = true })
+	runner.Add(Worker("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		stopped = true
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	assert.NoError(t, runner.Run(ctx))
+	assert.True(t, stopped)
+	assert.True(t, hookCalled)
+}
+
+func TestRunner_StopsOthersWhenComponentFails(t *testing.T) {
+	runner := New(zaptest.NewLogger(t).Sugar(), time.Second)
+
+	failure := errors.New("listen failed")
+	var stopped bool
+	runner.Add(
+		Component{
+			Name:  "failing",
+			Start: func() error { return failure },
+			Stop:  func(context.Context) error { return nil },
+		},
+		Worker("worker", func(ctx context.Context) {
+			<-ctx.Done()
+			stopped = true
+		}),
+	)
+
+	assert.ErrorIs(t, runner.Run(context.Background()), failure)
+	assert.True(t, stopped)
+}
+
+func TestRunner_WorkerExceedsTimeout(t *testing.T) {
+	runner := New(zaptest.NewLogger(t).Sugar(), 10*time.Millisecond)
+
+	release := make(chan struct{})
+	defer close(release)
+	runner.Add(Worker("stuck", func(context.Context) { <-release }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	release <- struct{}{}
+	assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+}
+
+func TestHTTPServer_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	runner := New(zaptest.NewLogger(t).Sugar(), time.Second)
+	runner.Add(HTTPServer("http", &http.Server{Addr: addr, Handler: handler}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr)
+		if err != nil {
+			status <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+
+	<-started
+	cancel()
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, http.StatusOK, <-status)
+}
+
+func TestGRPCServer_GracefulStop(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	runner := New(zaptest.NewLogger(t).Sugar(), time.Second)
+	runner.Add(GRPCServer("grpc", grpc.NewServer(), lis))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, runner.Run(ctx))
+}
@@ -1,39 +1,156 @@
 package logger
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// Куда пишутся логи
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
+
+// Форматы строк лога
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+var (
+	ErrUnknownOutput = errors.New("unknown log output")
+	ErrUnknownFormat = errors.New("unknown log format")
+)
+
 type LogConfig struct {
-	Level zapcore.Level `default:"debug" envconfig:"LEVEL"`
+	Level    zapcore.Level  `default:"debug"  envconfig:"LEVEL"`
+	Output   string         `default:"stdout" envconfig:"OUTPUT"`
+	Format   string         `default:"json"   envconfig:"FORMAT"`
+	File     FileConfig     `envconfig:"FILE"`
+	Sampling SamplingConfig `envconfig:"SAMPLING"`
+}
+
+// FileConfig описывает файл лога и его ротацию: по размеру (MaxSizeMB)
+// и по времени (RotateEvery, 0 — выключена)
+type FileConfig struct {
+	Path        string        `default:"./file.log" envconfig:"PATH"`
+	MaxSizeMB   int           `default:"100"        envconfig:"MAX_SIZE_MB"`
+	MaxBackups  int           `default:"5"          envconfig:"MAX_BACKUPS"`
+	MaxAgeDays  int           `default:"7"          envconfig:"MAX_AGE_DAYS"`
+	Compress    bool          `default:"false"      envconfig:"COMPRESS"`
+	RotateEvery time.Duration `default:"0"          envconfig:"ROTATE_EVERY"`
+}
+
+// SamplingConfig ограничивает поток одинаковых сообщений: за каждый Tick
+// пишутся первые Initial строк, а дальше только каждая Thereafter-я
+type SamplingConfig struct {
+	Enabled    bool          `default:"false" envconfig:"ENABLED"`
+	Tick       time.Duration `default:"1s"    envconfig:"TICK"`
+	Initial    int           `default:"100"   envconfig:"INITIAL"`
+	Thereafter int           `default:"100"   envconfig:"THEREAFTER"`
 }
 
-func CreateLogger(cfg LogConfig) *zap.SugaredLogger {
+// Logger — логгер сервиса вместе с уровнем, который можно менять без
+// перезапуска через Level (zap.AtomicLevel отдает и принимает его по HTTP)
+type Logger struct {
+	*zap.SugaredLogger
+	Level zap.AtomicLevel
+
+	closeOutput func() error
+}
+
+func CreateLogger(cfg LogConfig) (*Logger, error) {
+	encoder, err := newEncoder(cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	output, closeOutput, err := newOutput(cfg.Output, cfg.File)
+	if err != nil {
+		return nil, err
+	}
+
+	level := zap.NewAtomicLevelAt(cfg.Level)
+
+	core := zapcore.NewCore(encoder, output, level)
+	if cfg.Sampling.Enabled {
+		core = zapcore.NewSamplerWithOptions(core, cfg.Sampling.Tick, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+	}
+
+	l := zap.New(core,
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+		zap.Fields(zap.Int("pid", os.Getpid())),
+	)
+
+	return &Logger{
+		SugaredLogger: l.Sugar(),
+		Level:         level,
+		closeOutput:   closeOutput,
+	}, nil
+}
+
+// Close сбрасывает буферы и закрывает файл лога
+func (l *Logger) Close() error {
+	// Sync для stdout и stderr на части систем возвращает EINVAL, это не ошибка
+	_ = l.Sync()
+	return l.closeOutput()
+}
+
+func newEncoder(format string) (zapcore.Encoder, error) {
 	encoderCfg := zap.NewProductionEncoderConfig()
 	encoderCfg.TimeKey = "timestamp"
 	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	config := zap.Config{
-		Level:             zap.NewAtomicLevelAt(cfg.Level),
-		Development:       false,
-		DisableCaller:     true,
-		DisableStacktrace: false,
-		Sampling:          nil,
-		Encoding:          "json",
-		EncoderConfig:     encoderCfg,
-		OutputPaths: []string{
-			"./file.log",
-		},
-		ErrorOutputPaths: []string{
-			"./file.log",
-		},
-		InitialFields: map[string]interface{}{
-			"pid": os.Getpid(),
-		},
+	switch format {
+	case FormatJSON, "":
+		return zapcore.NewJSONEncoder(encoderCfg), nil
+	case FormatConsole:
+		encoderCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		return zapcore.NewConsoleEncoder(encoderCfg), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+type ctxKey struct{}
+
+// WithContext кладет логгер запроса в контекст
+func WithContext(ctx context.Context, l *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext возвращает логгер запроса с request_id и полями текущего спана.
+// Если запрос пришел не через middleware (фоновые задачи, тесты), используется
+// fallback
+func FromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	l, ok := ctx.Value(ctxKey{}).(*zap.SugaredLogger)
+	if !ok {
+		l = fallback
+	}
+
+	return WithTrace(ctx, l)
+}
+
+// WithTrace добавляет к логгеру trace_id и span_id текущего спана, чтобы
+// строку лога можно было найти по трассе. Без активного спана логгер
+// возвращается без изменений
+func WithTrace(ctx context.Context, l *zap.SugaredLogger) *zap.SugaredLogger {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return l
 	}
 
-	return zap.Must(config.Build()).Sugar()
+	return l.With(
+		"trace_id", spanCtx.TraceID().String(),
+		"span_id", spanCtx.SpanID().String(),
+	)
 }
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func fileConfig(t *testing.T, format string) (LogConfig, string) {
+	path := filepath.Join(t.TempDir(), "service.log")
+	return LogConfig{
+		Level:  zapcore.DebugLevel,
+		Output: OutputFile,
+		Format: format,
+		File:   FileConfig{Path: path, MaxSizeMB: 1},
+	}, path
+}
+
+func TestCreateLoggerFileJSON(t *testing.T) {
+	cfg, path := fileConfig(t, FormatJSON)
+
+	l, err := CreateLogger(cfg)
+	require.NoError(t, err)
+
+	l.Infow("hello", "key", "value")
+	require.NoError(t, l.Close())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(raw, &line))
+	assert.Equal(t, "hello", line["msg"])
+	assert.Equal(t, "value", line["key"])
+	assert.Contains(t, line, "timestamp")
+	assert.Contains(t, line, "pid")
+}
+
+func TestCreateLoggerAtomicLevel(t *testing.T) {
+	cfg, path := fileConfig(t, FormatConsole)
+
+	l, err := CreateLogger(cfg)
+	require.NoError(t, err)
+
+	l.Debug("first")
+	l.Level.SetLevel(zapcore.InfoLevel)
+	l.Debug("second")
+	require.NoError(t, l.Close())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "DEBUG\tfirst")
+	assert.NotContains(t, string(raw), "second")
+	assert.Equal(t, 1, strings.Count(string(raw), "\n"))
+}
+
+func TestCreateLoggerInvalidConfig(t *testing.T) {
+	_, err := CreateLogger(LogConfig{Output: "syslog"})
+	assert.ErrorIs(t, err, ErrUnknownOutput)
+
+	_, err = CreateLogger(LogConfig{Format: "xml"})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	fallback := zap.New(core).Sugar()
+
+	FromContext(context.Background(), fallback).Info("fallback")
+
+	ctx := WithContext(context.Background(), fallback.With("request_id", "req-1"))
+	FromContext(ctx, zap.NewNop().Sugar()).Info("scoped")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.NotContains(t, entries[0].ContextMap(), "request_id")
+	assert.Equal(t, "req-1", entries[1].ContextMap()["request_id"])
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+func newOutput(output string, cfg FileConfig) (zapcore.WriteSyncer, func() error, error) {
+	noop := func() error { return nil }
+
+	switch output {
+	case OutputStdout, "":
+		return zapcore.Lock(os.Stdout), noop, nil
+	case OutputStderr:
+		return zapcore.Lock(os.Stderr), noop, nil
+	case OutputFile:
+		file := &lumberjack.Logger{
+			Filename:   cfg.Path,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   cfg.Compress,
+		}
+		stop := rotateEvery(file, cfg.RotateEvery)
+
+		return zapcore.AddSync(file), func() error {
+			stop()
+			return file.Close()
+		}, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownOutput, output)
+	}
+}
+
+// rotateEvery дополняет ротацию по размеру ротацией по времени: lumberjack
+// умеет только первую, поэтому файл принудительно ротируется по таймеру
+func rotateEvery(file *lumberjack.Logger, interval time.Duration) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := file.Rotate(); err != nil {
+					fmt.Fprintf(os.Stderr, "failed to rotate log file: %v\n", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
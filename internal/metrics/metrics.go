@@ -1,7 +1,13 @@
 package metrics
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 var (
@@ -11,7 +17,7 @@ var (
 			Name: "http_requests_total",
 			Help: "Общее количество HTTP-запросов",
 		},
-		[]string{"method", "path", "status"},
+		[]string{"method", "route", "status"},
 	)
 
 	HTTPResponseDuration = prometheus.NewHistogramVec(
@@ -20,29 +26,142 @@ var (
 			Help:    "Время ответа сервера",
 			Buckets: prometheus.DefBuckets,
 		},
-		[]string{"method", "path"},
+		[]string{"method", "route"},
+	)
+
+	HTTPRequestsInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Количество HTTP-запросов в обработке",
+		},
+	)
+
+	GRPCRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Общее количество завершенных вызовов gRPC",
+		},
+		[]string{"method", "code"},
+	)
+
+	GRPCResponseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Время обработки вызова gRPC",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+
+	GRPCRequestsInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "grpc_server_in_flight_requests",
+			Help: "Количество вызовов gRPC в обработке",
+		},
+	)
+
+	CacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_hits_total",
+			Help: "Количество попаданий в кеш",
+		},
+		[]string{"backend"},
+	)
+
+	CacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_misses_total",
+			Help: "Количество промахов кеша",
+		},
+		[]string{"backend"},
+	)
+
+	CacheErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_errors_total",
+			Help: "Количество ошибок при обращении к кешу",
+		},
+		[]string{"backend", "op"},
+	)
+
+	RateLimited = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limited_requests_total",
+			Help: "Количество запросов, отклоненных ограничением частоты",
+		},
+		[]string{"method"},
+	)
+
+	RateLimitErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "rate_limit_errors_total",
+			Help: "Количество ошибок хранилища лимитов, при которых запрос был пропущен",
+		},
 	)
 
 	// Бизнесовые метрики
-	PVZCreated = prometheus.NewCounter(
+	PVZCreated = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "pvz_created_total",
 			Help: "Количество созданных ПВЗ",
 		},
+		[]string{"city"},
 	)
 
-	ReceptionsCreated = prometheus.NewCounter(
+	ReceptionsCreated = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "receptions_created_total",
 			Help: "Количество созданных приёмок",
 		},
+		[]string{"city"},
+	)
+
+	ReceptionsClosed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "receptions_closed_total",
+			Help: "Количество закрытых и отмененных приёмок",
+		},
+		[]string{"status"},
+	)
+
+	ReceptionDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "reception_duration_seconds",
+			Help:    "Время от начала приёмки до ее закрытия",
+			Buckets: []float64{60, 300, 900, 1800, 3600, 2 * 3600, 4 * 3600, 8 * 3600, 24 * 3600, 48 * 3600},
+		},
 	)
 
-	ProductsAdded = prometheus.NewCounter(
+	ProductsPerReception = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "reception_products",
+			Help:    "Количество товаров в закрытой приёмке",
+			Buckets: []float64{0, 1, 5, 10, 25, 50, 100, 250, 500},
+		},
+	)
+
+	ProductsAdded = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "products_added_total",
 			Help: "Количество добавленных товаров",
 		},
+		[]string{"city", "type"},
+	)
+
+	ProductsDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "products_deleted_total",
+			Help: "Количество удаленных товаров",
+		},
+		[]string{"city", "type"},
+	)
+
+	ReceptionsAutoClosed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "receptions_auto_closed_total",
+			Help: "Количество автоматически закрытых приёмок",
+		},
+		[]string{"reason"},
 	)
 )
 
@@ -50,8 +169,80 @@ func Register() {
 	prometheus.MustRegister(
 		HTTPRequestCount,
 		HTTPResponseDuration,
+		HTTPRequestsInFlight,
+		GRPCRequestCount,
+		GRPCResponseDuration,
+		GRPCRequestsInFlight,
+		CacheHits,
+		CacheMisses,
+		CacheErrors,
+		RateLimited,
+		RateLimitErrors,
 		PVZCreated,
 		ReceptionsCreated,
+		ReceptionsClosed,
+		ReceptionDuration,
+		ProductsPerReception,
 		ProductsAdded,
+		ProductsDeleted,
+		ReceptionsAutoClosed,
 	)
 }
+
+// RegisterDBStats экспортирует sql.DBStats пула соединений: число открытых,
+// занятых и простаивающих соединений, ожидания свободного соединения и закрытия
+// по лимитам. Метрики разных пулов различаются меткой db_name
+func RegisterDBStats(name string, db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+var (
+	openReceptionsDesc = prometheus.NewDesc(
+		"open_receptions",
+		"Количество открытых приёмок",
+		[]string{"city"}, nil,
+	)
+	oldestOpenReceptionDesc = prometheus.NewDesc(
+		"open_reception_oldest_age_seconds",
+		"Возраст самой старой открытой приёмки",
+		[]string{"city"}, nil,
+	)
+)
+
+// OpenReceptionsFunc возвращает открытые приёмки, сгруппированные по городам
+type OpenReceptionsFunc func(ctx context.Context) ([]models.OpenReceptionStats, error)
+
+// openReceptionsCollector считает открытые приёмки в момент сбора метрик:
+// так значения не расходятся с базой при нескольких экземплярах сервиса
+// и после автозакрытия
+type openReceptionsCollector struct {
+	load    OpenReceptionsFunc
+	timeout time.Duration
+}
+
+func (c *openReceptionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- openReceptionsDesc
+	ch <- oldestOpenReceptionDesc
+}
+
+func (c *openReceptionsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	stats, err := c.load(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(openReceptionsDesc, err)
+		return
+	}
+
+	for _, s := range stats {
+		ch <- prometheus.MustNewConstMetric(openReceptionsDesc, prometheus.GaugeValue, float64(s.Count), s.City)
+		ch <- prometheus.MustNewConstMetric(oldestOpenReceptionDesc, prometheus.GaugeValue, s.OldestAge.Seconds(), s.City)
+	}
+}
+
+// RegisterOpenReceptions экспортирует число открытых приёмок и возраст самой
+// старой из них. load вызывается на каждый сбор метрик с таймаутом timeout
+func RegisterOpenReceptions(load OpenReceptionsFunc, timeout time.Duration) {
+	prometheus.MustRegister(&openReceptionsCollector{load: load, timeout: timeout})
+}
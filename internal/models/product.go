@@ -1,8 +1,23 @@
 package models
 
+import "time"
+
 type Product struct {
 	Id          string
 	DateTime    string
 	Type        string
 	ReceptionId string
 }
+
+// ProductCursor указывает на последний отданный товар: следующая страница
+// начинается строго после него в порядке (date_time, id)
+type ProductCursor struct {
+	DateTime time.Time
+	Id       string
+}
+
+type ProductListFilter struct {
+	Types     []string
+	SortOrder string
+	After     *ProductCursor
+}
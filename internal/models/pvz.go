@@ -1,12 +1,71 @@
 package models
 
+import "time"
+
 type PVZ struct {
-	Id               string `db:"id"`
-	RegistrationDate string `db:"registration_date"`
-	City             string `db:"city"`
+	Id               string   `db:"id"`
+	RegistrationDate string   `db:"registration_date"`
+	City             string   `db:"city"`
+	Name             string   `db:"name"`
+	Address          string   `db:"address"`
+	WorkingHours     string   `db:"working_hours"`
+	Status           string   `db:"status"`
+	Latitude         *float64 `db:"latitude"`
+	Longitude        *float64 `db:"longitude"`
+	Timezone         string   `db:"timezone"`
+}
+
+type PVZUpdate struct {
+	Name         *string
+	Address      *string
+	WorkingHours *string
+	Latitude     *float64
+	Longitude    *float64
+	Timezone     *string
+}
+
+type PVZWithDistance struct {
+	PVZ      PVZ
+	Distance float64
 }
 
 type PVZWithReceptions struct {
 	PVZ        PVZ
 	Receptions []ReceptionWithProducts
 }
+
+type PVZFilter struct {
+	StartDate         *time.Time
+	EndDate           *time.Time
+	Cities            []string
+	PVZIds            []string
+	ReceptionStatuses []string
+	ProductTypes      []string
+	HasOpenReception  *bool
+	IncludeArchived   bool
+	SortBy            string
+	SortOrder         string
+}
+
+const (
+	PVZSortRegistrationDate = "registrationDate"
+	PVZSortLastActivity     = "lastActivity"
+	PVZSortProductCount     = "productCount"
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// Уровни детализации списка ПВЗ: только ПВЗ, ПВЗ со сводкой по приемкам
+// (без товаров, с их количеством) или полное дерево с товарами
+const (
+	ExpandPVZ        = "pvz"
+	ExpandReceptions = "receptions"
+	ExpandProducts   = "products"
+)
+
+const (
+	PVZActive   = "active"
+	PVZInactive = "inactive"
+	PVZArchived = "archived"
+)
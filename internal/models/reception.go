@@ -1,18 +1,63 @@
 package models
 
+import "time"
+
 type Reception struct {
-	Id       string
-	DateTime string
-	PVZId    string
-	Status   string
+	Id          string
+	DateTime    string
+	PVZId       string
+	Status      string
+	CloseReason string
 }
 
 type ReceptionWithProducts struct {
-	Reception Reception
-	Products  []Product
+	Reception    Reception
+	Products     []Product
+	ProductCount int
+}
+
+type ReceptionListFilter struct {
+	Statuses  []string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+type ReceptionStatusChange struct {
+	ReceptionId   string
+	FromStatus    string
+	ToStatus      string
+	ChangedAt     string
+	ChangedByRole string
+	Reason        string
 }
 
 const (
 	CLOSE      = "close"
 	INPROGRESS = "in_progress"
+	PAUSED     = "paused"
+	CANCELLED  = "cancelled"
+	REOPENED   = "reopened_for_correction"
+)
+
+const (
+	CloseReasonMaxAge = "auto_max_age"
+	CloseReasonIdle   = "auto_idle"
 )
+
+type ReceptionExportRow struct {
+	PVZId             string
+	City              string
+	ReceptionId       string
+	ReceptionDateTime string
+	ReceptionStatus   string
+	ProductId         string
+	ProductDateTime   string
+	ProductType       string
+}
+
+// OpenReceptionStats — открытые приёмки одного города
+type OpenReceptionStats struct {
+	City      string
+	Count     int
+	OldestAge time.Duration
+}
@@ -1,8 +1,32 @@
 package models
 
+import "time"
+
 type User struct {
-	Id       string
-	Email    string
-	Password string
+	Id         string
+	Email      string
+	Password   string
+	Role       string
+	CreatedAt  time.Time
+	DisabledAt *time.Time
+}
+
+// Disabled сообщает, отключена ли учетная запись модератором
+func (u User) Disabled() bool {
+	return u.DisabledAt != nil
+}
+
+// UserListFilter — фильтр списка пользователей, пустые поля не ограничивают выборку
+type UserListFilter struct {
 	Role     string
+	Disabled *bool
+}
+
+// LoginFailure — счетчик неудачных попыток входа по ключу учетной записи
+// или IP адреса. До BlockedUntil попытки входа по этому ключу отклоняются
+type LoginFailure struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	BlockedUntil  time.Time
 }
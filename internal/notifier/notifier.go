@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"go.uber.org/zap"
+)
+
+const BackendLog = "log"
+
+// Config выбирает способ доставки писем пользователям. Пока есть только log:
+// письмо пишется в лог сервиса, чего достаточно для локального запуска и
+// тестов. Почтовый или другой транспорт добавляется новым бэкендом в New
+type Config struct {
+	Backend string `default:"log" envconfig:"BACKEND"`
+}
+
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error
+}
+
+func New(cfg Config, logger *zap.SugaredLogger) (Notifier, error) {
+	switch cfg.Backend {
+	case BackendLog:
+		return NewLog(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier backend %q", cfg.Backend)
+	}
+}
+
+// Log пишет уведомления в лог вместо отправки. Токен сброса попадает в лог
+// целиком, поэтому в production нужен настоящий бэкенд
+type Log struct {
+	logger *zap.SugaredLogger
+}
+
+func NewLog(logger *zap.SugaredLogger) *Log {
+	return &Log{
+		logger: logger,
+	}
+}
+
+func (l *Log) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	logger.FromContext(ctx, l.logger).Named("notifier").Infow("password reset requested",
+		"email", email,
+		"token", token,
+		"expires_at", expiresAt,
+	)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNew(t *testing.T) {
+	n, err := New(Config{Backend: BackendLog}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	assert.IsType(t, &Log{}, n)
+
+	_, err = New(Config{Backend: "smtp"}, zap.NewNop().Sugar())
+	assert.Error(t, err)
+}
+
+func TestLog_SendPasswordReset(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	n := NewLog(zap.New(core).Sugar())
+
+	expiresAt := time.Date(2025, 4, 1, 12, 30, 0, 0, time.UTC)
+	err := n.SendPasswordReset(context.Background(), "test@example.com", "token", expiresAt)
+	require.NoError(t, err)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "notifier", entries[0].LoggerName)
+
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "test@example.com", fields["email"])
+	assert.Equal(t, "token", fields["token"])
+	assert.Equal(t, expiresAt, fields["expires_at"])
+}
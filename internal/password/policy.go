@@ -0,0 +1,141 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+)
+
+// Config описывает требования к паролю. MaxLength по умолчанию равен 72:
+// bcrypt учитывает только первые 72 байта, остаток пароля ни на что не влияет.
+// DenylistFile — файл со скомпрометированными паролями, по одному в строке;
+// пустые строки и строки, начинающиеся с #, пропускаются
+type Config struct {
+	MinLength      int    `default:"8"     envconfig:"MIN_LENGTH"`
+	MaxLength      int    `default:"72"    envconfig:"MAX_LENGTH"`
+	RequireUpper   bool   `default:"true"  envconfig:"REQUIRE_UPPER"`
+	RequireLower   bool   `default:"true"  envconfig:"REQUIRE_LOWER"`
+	RequireDigit   bool   `default:"true"  envconfig:"REQUIRE_DIGIT"`
+	RequireSpecial bool   `default:"false" envconfig:"REQUIRE_SPECIAL"`
+	DenylistFile   string `default:""      envconfig:"DENYLIST_FILE"`
+}
+
+// Policy проверяет пароли при регистрации, смене и сбросе пароля
+type Policy struct {
+	cfg      Config
+	denylist map[string]struct{}
+}
+
+// New загружает список скомпрометированных паролей один раз при старте,
+// поэтому ошибка чтения файла не дает сервису запуститься
+func New(cfg Config) (*Policy, error) {
+	policy := NewPolicy(cfg, nil)
+	if cfg.DenylistFile == "" {
+		return policy, nil
+	}
+
+	denylist, err := loadDenylist(cfg.DenylistFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load password denylist: %w", err)
+	}
+	policy.denylist = denylist
+
+	return policy, nil
+}
+
+// NewPolicy создает политику с уже загруженным списком паролей
+func NewPolicy(cfg Config, denylist []string) *Policy {
+	policy := &Policy{
+		cfg:      cfg,
+		denylist: make(map[string]struct{}, len(denylist)),
+	}
+	for _, password := range denylist {
+		policy.denylist[normalize(password)] = struct{}{}
+	}
+
+	return policy
+}
+
+func loadDenylist(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	denylist := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		denylist[normalize(line)] = struct{}{}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return denylist, nil
+}
+
+// normalize приводит пароль к нижнему регистру: Qwerty123 так же предсказуем,
+// как qwerty123
+func normalize(password string) string {
+	return strings.ToLower(strings.TrimSpace(password))
+}
+
+// Validate возвращает *dto.WeakPasswordError со списком всех нарушенных
+// требований, чтобы клиент мог исправить пароль за одну попытку
+func (p *Policy) Validate(password string) error {
+	var violations []string
+
+	length := utf8.RuneCountInString(password)
+	if minLength := max(p.cfg.MinLength, 1); length < minLength {
+		violations = append(violations, fmt.Sprintf("не короче %d символов", minLength))
+	}
+	if p.cfg.MaxLength > 0 && len(password) > p.cfg.MaxLength {
+		violations = append(violations, fmt.Sprintf("не длиннее %d байт", p.cfg.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
+	}
+
+	if p.cfg.RequireUpper && !hasUpper {
+		violations = append(violations, "хотя бы одна заглавная буква")
+	}
+	if p.cfg.RequireLower && !hasLower {
+		violations = append(violations, "хотя бы одна строчная буква")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		violations = append(violations, "хотя бы одна цифра")
+	}
+	if p.cfg.RequireSpecial && !hasSpecial {
+		violations = append(violations, "хотя бы один специальный символ")
+	}
+
+	if _, ok := p.denylist[normalize(password)]; ok {
+		violations = append(violations, "не входит в список скомпрометированных паролей")
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &dto.WeakPasswordError{Violations: violations}
+}
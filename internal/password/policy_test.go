@@ -0,0 +1,86 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCfg = Config{
+	MinLength:    8,
+	MaxLength:    72,
+	RequireUpper: true,
+	RequireLower: true,
+	RequireDigit: true,
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	policy := NewPolicy(testCfg, []string{"Password123"})
+
+	tests := []struct {
+		name       string
+		password   string
+		violations []string
+	}{
+		{name: "valid", password: "Str0ngPassword"},
+		{name: "empty", password: "", violations: []string{
+			"не короче 8 символов",
+			"хотя бы одна заглавная буква",
+			"хотя бы одна строчная буква",
+			"хотя бы одна цифра",
+		}},
+		{name: "short", password: "Ab1", violations: []string{"не короче 8 символов"}},
+		{name: "too long", password: "Ab1" + strings.Repeat("x", 70), violations: []string{"не длиннее 72 байт"}},
+		{name: "no digit", password: "StrongPassword", violations: []string{"хотя бы одна цифра"}},
+		{name: "cyrillic", password: "Пароль2025"},
+		{name: "denylisted ignoring case", password: "PASSWORD123", violations: []string{
+			"хотя бы одна строчная буква",
+			"не входит в список скомпрометированных паролей",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password)
+			if tt.violations == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var weak *dto.WeakPasswordError
+			require.ErrorAs(t, err, &weak)
+			assert.ErrorIs(t, err, dto.ErrWeakPassword)
+			assert.Equal(t, tt.violations, weak.Violations)
+		})
+	}
+}
+
+func TestPolicy_RequireSpecial(t *testing.T) {
+	policy := NewPolicy(Config{MinLength: 4, RequireSpecial: true}, nil)
+
+	assert.Error(t, policy.Validate("abcdef"))
+	assert.NoError(t, policy.Validate("abc-def"))
+}
+
+func TestNew_Denylist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# top passwords\n\nQwerty123\n  Welcome1  \n"), 0o600))
+
+	cfg := testCfg
+	cfg.DenylistFile = path
+	policy, err := New(cfg)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, policy.Validate("Qwerty123"), dto.ErrWeakPassword)
+	assert.NoError(t, policy.Validate("Welcome1x"))
+	assert.ErrorIs(t, policy.Validate("wElcome1"), dto.ErrWeakPassword)
+
+	cfg.DenylistFile = filepath.Join(t.TempDir(), "missing.txt")
+	_, err = New(cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+var ErrInvalidLimit = errors.New("invalid rate limit")
+
+// Config описывает ограничение частоты запросов. Лимиты задаются по имени
+// метода API из pvz.proto (AddProduct, Login), поэтому одинаково действуют
+// на HTTP и gRPC. In-memory бакеты живут внутри процесса: при нескольких
+// репликах каждая считает запросы отдельно, общий лимит дает только redis
+type Config struct {
+	Enabled           bool          `default:"false"          envconfig:"ENABLED"`
+	Backend           string        `default:"memory"         envconfig:"BACKEND"`
+	Default           Limit         `default:""               envconfig:"DEFAULT"`
+	Rules             Rules         `default:""               envconfig:"RULES"`
+	TrustForwardedFor bool          `default:"false"          envconfig:"TRUST_FORWARDED_FOR"`
+	Timeout           time.Duration `default:"50ms"           envconfig:"TIMEOUT"`
+	RedisAddr         string        `default:"localhost:6379" envconfig:"REDIS_ADDR"`
+	RedisPassword     string        `default:""               envconfig:"REDIS_PASSWORD"`
+	RedisDB           int           `default:"0"              envconfig:"REDIS_DB"`
+	Prefix            string        `default:"pvz:ratelimit:" envconfig:"PREFIX"`
+}
+
+// Limit — параметры token bucket: Rate токенов в секунду и емкость Burst.
+// Нулевой Limit означает отсутствие ограничения
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+var periods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// Decode разбирает лимит вида "10/s" или "5/m:10", где после двоеточия
+// указана емкость бакета. Без нее емкость равна числу запросов за период
+func (l *Limit) Decode(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		*l = Limit{}
+		return nil
+	}
+
+	spec, burstValue, hasBurst := strings.Cut(value, ":")
+	countValue, unit, ok := strings.Cut(spec, "/")
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidLimit, value)
+	}
+
+	count, err := strconv.Atoi(countValue)
+	if err != nil || count <= 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidLimit, value)
+	}
+
+	period, ok := periods[unit]
+	if !ok {
+		return fmt.Errorf("%w: unknown period in %q", ErrInvalidLimit, value)
+	}
+
+	burst := count
+	if hasBurst {
+		burst, err = strconv.Atoi(burstValue)
+		if err != nil || burst <= 0 {
+			return fmt.Errorf("%w: %q", ErrInvalidLimit, value)
+		}
+	}
+
+	*l = Limit{Rate: float64(count) / period.Seconds(), Burst: burst}
+	return nil
+}
+
+func (l Limit) IsZero() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// Rules — лимиты отдельных методов, например "AddProduct=20/s:40,Login=5/m"
+type Rules map[string]Limit
+
+func (r *Rules) Decode(value string) error {
+	rules := Rules{}
+	for _, rule := range strings.Split(value, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		method, spec, ok := strings.Cut(rule, "=")
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrInvalidLimit, rule)
+		}
+
+		var limit Limit
+		if err := limit.Decode(spec); err != nil {
+			return err
+		}
+		rules[strings.TrimSpace(method)] = limit
+	}
+
+	*r = rules
+	return nil
+}
+
+// Result — исход попытки взять токен. RetryAfter заполнен только для
+// отклоненных запросов и показывает, когда в бакете появится токен
+type Result struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+// Store хранит состояние бакетов
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+	Close() error
+}
+
+// Limiter выбирает лимит метода и списывает токен из бакета пары
+// "метод + субъект" (пользователь или IP адрес)
+type Limiter struct {
+	cfg   Config
+	store Store
+}
+
+func New(cfg Config) (*Limiter, error) {
+	if !cfg.Enabled {
+		return &Limiter{cfg: cfg}, nil
+	}
+
+	var store Store
+	switch cfg.Backend {
+	case BackendMemory:
+		store = NewMemory()
+	case BackendRedis:
+		redisStore, err := NewRedis(cfg)
+		if err != nil {
+			return nil, err
+		}
+		store = redisStore
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", cfg.Backend)
+	}
+
+	return NewLimiter(cfg, store), nil
+}
+
+func NewLimiter(cfg Config, store Store) *Limiter {
+	return &Limiter{cfg: cfg, store: store}
+}
+
+// Allow списывает токен для вызова fullMethod ("/pvz.v1.ProductService/AddProduct")
+// от principal. Если хранилище недоступно, запрос пропускается: отказ redis
+// не должен останавливать API
+func (l *Limiter) Allow(ctx context.Context, fullMethod, principal string) Result {
+	if l == nil || l.store == nil {
+		return Result{Allowed: true}
+	}
+
+	method := path.Base(fullMethod)
+	limit, ok := l.cfg.Rules[method]
+	if !ok {
+		limit = l.cfg.Default
+	}
+	if limit.IsZero() {
+		return Result{Allowed: true}
+	}
+
+	if l.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.cfg.Timeout)
+		defer cancel()
+	}
+
+	res, err := l.store.Take(ctx, method+":"+principal, limit)
+	if err != nil {
+		metrics.RateLimitErrors.Inc()
+		logger.FromContext(ctx, zap.S()).Warnf("failed to check rate limit for %s: %v", method, err)
+		return Result{Allowed: true}
+	}
+
+	if !res.Allowed {
+		metrics.RateLimited.WithLabelValues(method).Inc()
+	}
+
+	return res
+}
+
+// ClientIP возвращает адрес клиента. X-Forwarded-For учитывается только
+// за доверенным прокси, иначе клиент может подставить любой адрес. Берется
+// последний адрес списка: его дописал сам прокси, а все, что левее, клиент
+// мог прислать в своем заголовке
+func (l *Limiter) ClientIP(remoteAddr, forwardedFor string) string {
+	if l != nil && l.cfg.TrustForwardedFor && forwardedFor != "" {
+		last := forwardedFor[strings.LastIndex(forwardedFor, ",")+1:]
+		if ip := strings.TrimSpace(last); ip != "" {
+			return ip
+		}
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+func (l *Limiter) Close() error {
+	if l == nil || l.store == nil {
+		return nil
+	}
+	return l.store.Close()
+}
+
+// Principal — субъект лимита: пользователь из токена, а для публичных
+// методов и токенов dummyLogin без идентификатора — IP адрес
+func Principal(userId, ip string) string {
+	if userId != "" {
+		return "user:" + userId
+	}
+	return "ip:" + ip
+}
+
+// RetryAfterSeconds округляет время ожидания вверх до целых секунд для
+// заголовка Retry-After
+func RetryAfterSeconds(d time.Duration) int {
+	seconds := int((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitDecode(t *testing.T) {
+	var l Limit
+
+	require.NoError(t, l.Decode("10/s"))
+	assert.Equal(t, Limit{Rate: 10, Burst: 10}, l)
+
+	require.NoError(t, l.Decode("6/m:12"))
+	assert.Equal(t, Limit{Rate: 0.1, Burst: 12}, l)
+
+	for _, value := range []string{"10", "0/s", "10/d", "10/s:0", "x/s"} {
+		assert.ErrorIs(t, l.Decode(value), ErrInvalidLimit, value)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("RATE_LIMIT_ENABLED", "true")
+	t.Setenv("RATE_LIMIT_DEFAULT", "100/s:200")
+	t.Setenv("RATE_LIMIT_RULES", "AddProduct=20/s:40, Login=5/m")
+
+	var cfg Config
+	require.NoError(t, envconfig.Process("RATE_LIMIT", &cfg))
+
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, Limit{Rate: 100, Burst: 200}, cfg.Default)
+	assert.Equal(t, Rules{
+		"AddProduct": {Rate: 20, Burst: 40},
+		"Login":      {Rate: 5.0 / 60, Burst: 5},
+	}, cfg.Rules)
+}
+
+func newTestMemory() (*Memory, *time.Time) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	m.lastSweep = now
+	return m, &now
+}
+
+func TestMemory_TokenBucket(t *testing.T) {
+	m, now := newTestMemory()
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		res, err := m.Take(ctx, "a", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	}
+
+	res, _ := m.Take(ctx, "a", limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Second, res.RetryAfter)
+
+	// бакеты разных ключей независимы
+	res, _ = m.Take(ctx, "b", limit)
+	assert.True(t, res.Allowed)
+
+	*now = now.Add(500 * time.Millisecond)
+	res, _ = m.Take(ctx, "a", limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+
+	*now = now.Add(500 * time.Millisecond)
+	res, _ = m.Take(ctx, "a", limit)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemory_SweepRemovesFullBuckets(t *testing.T) {
+	m, now := newTestMemory()
+	ctx := context.Background()
+
+	_, _ = m.Take(ctx, "slow", Limit{Rate: 1.0 / 3600, Burst: 1})
+	_, _ = m.Take(ctx, "fast", Limit{Rate: 10, Burst: 1})
+
+	*now = now.Add(sweepInterval)
+	_, _ = m.Take(ctx, "other", Limit{Rate: 10, Burst: 1})
+
+	assert.Contains(t, m.buckets, "slow")
+	assert.NotContains(t, m.buckets, "fast")
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("store is down")
+}
+
+func (failingStore) Close() error {
+	return nil
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	cfg := Config{Rules: Rules{"Login": {Rate: 1, Burst: 1}}}
+	ctx := context.Background()
+
+	l := NewLimiter(cfg, NewMemory())
+	assert.True(t, l.Allow(ctx, "/pvz.v1.UserService/Login", "ip:1.1.1.1").Allowed)
+	assert.False(t, l.Allow(ctx, "/pvz.v1.UserService/Login", "ip:1.1.1.1").Allowed)
+	assert.True(t, l.Allow(ctx, "/pvz.v1.UserService/Login", "ip:2.2.2.2").Allowed)
+
+	// без правила и лимита по умолчанию метод не ограничен
+	for i := 0; i < 5; i++ {
+		assert.True(t, l.Allow(ctx, "/pvz.v1.PVZService/GetPVZ", "ip:1.1.1.1").Allowed)
+	}
+
+	failing := NewLimiter(cfg, failingStore{})
+	assert.True(t, failing.Allow(ctx, "/pvz.v1.UserService/Login", "ip:1.1.1.1").Allowed)
+
+	var disabled *Limiter
+	assert.True(t, disabled.Allow(ctx, "/pvz.v1.UserService/Login", "ip:1.1.1.1").Allowed)
+}
+
+func TestLimiter_ClientIP(t *testing.T) {
+	direct := NewLimiter(Config{}, nil)
+	assert.Equal(t, "10.0.0.1", direct.ClientIP("10.0.0.1:5555", "1.2.3.4"))
+
+	proxied := NewLimiter(Config{TrustForwardedFor: true}, nil)
+	assert.Equal(t, "1.2.3.4", proxied.ClientIP("10.0.0.1:5555", "1.2.3.4"))
+	// левые адреса клиент присылает сам, прокси дописывает адрес справа
+	assert.Equal(t, "1.2.3.4", proxied.ClientIP("10.0.0.1:5555", "6.6.6.6, 1.2.3.4"))
+	assert.Equal(t, "10.0.0.1", proxied.ClientIP("10.0.0.1:5555", ""))
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	assert.Equal(t, 1, RetryAfterSeconds(0))
+	assert.Equal(t, 1, RetryAfterSeconds(300*time.Millisecond))
+	assert.Equal(t, 2, RetryAfterSeconds(1100*time.Millisecond))
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript атомарно пополняет бакет и списывает токен. Состояние хранится
+// в хеше {tokens, ts}, а ключ истекает, когда бакет снова заполнится.
+// Время передается клиентом в миллисекундах, rate — токенов в миллисекунду
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+if now > ts then
+	tokens = math.min(burst, tokens + (now - ts) * rate)
+	ts = now
+end
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+
+return {allowed, wait}
+`)
+
+// Redis хранит бакеты в redis, поэтому все реплики сервиса делят один лимит
+type Redis struct {
+	client *redis.Client
+	prefix string
+	now    func() time.Time
+}
+
+func NewRedis(cfg Config) (*Redis, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisAddr,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+
+	err := client.Ping(context.Background()).Err()
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &Redis{client: client, prefix: cfg.Prefix, now: time.Now}, nil
+}
+
+func (s *Redis) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	rate := limit.Rate / float64(time.Second/time.Millisecond)
+
+	values, err := takeScript.Run(ctx, s.client, []string{s.prefix + key},
+		strconv.FormatFloat(rate, 'g', -1, 64),
+		limit.Burst,
+		s.now().UnixMilli(),
+	).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	if values[0] == 1 {
+		return Result{Allowed: true}, nil
+	}
+	return Result{RetryAfter: time.Duration(values[1]) * time.Millisecond}, nil
+}
+
+func (s *Redis) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedis_TokenBucket(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	s, err := NewRedis(Config{RedisAddr: server.Addr(), Prefix: "pvz:ratelimit:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		res, err := s.Take(ctx, "Login:ip:1.1.1.1", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	}
+
+	res, err := s.Take(ctx, "Login:ip:1.1.1.1", limit)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Second, res.RetryAfter)
+	assert.True(t, server.Exists("pvz:ratelimit:Login:ip:1.1.1.1"))
+	assert.Greater(t, server.TTL("pvz:ratelimit:Login:ip:1.1.1.1"), time.Duration(0))
+
+	now = now.Add(time.Second)
+	res, err = s.Take(ctx, "Login:ip:1.1.1.1", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestRedis_Unavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	s, err := NewRedis(Config{RedisAddr: server.Addr()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	server.Close()
+
+	_, err = s.Take(context.Background(), "a", Limit{Rate: 1, Burst: 1})
+	assert.Error(t, err)
+}
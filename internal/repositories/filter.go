@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/lib/pq"
+)
+
+// Условия фильтра строятся одинаково для ПВЗ, приемок и товаров, чтобы список ПВЗ
+// на странице и вложенные в него приемки и товары отбирались по одним правилам
+
+// appendProductConditions добавляет условия на товары с алиасом p
+func appendProductConditions(conditions []string, args []any, filter models.PVZFilter) ([]string, []any) {
+	if filter.StartDate != nil || filter.EndDate != nil {
+		start := time.Time{}
+		end := time.Now()
+
+		if filter.StartDate != nil {
+			start = *filter.StartDate
+		}
+
+		if filter.EndDate != nil {
+			end = *filter.EndDate
+		}
+
+		args = append(args, start, end)
+		conditions = append(conditions, fmt.Sprintf("p.date_time BETWEEN $%d AND $%d", len(args)-1, len(args)))
+	}
+
+	if len(filter.ProductTypes) > 0 {
+		args = append(args, pq.Array(filter.ProductTypes))
+		conditions = append(conditions, fmt.Sprintf("p.product_type = ANY($%d)", len(args)))
+	}
+
+	return conditions, args
+}
+
+// appendReceptionConditions добавляет условия на приемки с алиасом r: по статусу
+// и по наличию в приемке товаров, подходящих под фильтр
+func appendReceptionConditions(conditions []string, args []any, filter models.PVZFilter) ([]string, []any) {
+	if len(filter.ReceptionStatuses) > 0 {
+		args = append(args, pq.Array(filter.ReceptionStatuses))
+		conditions = append(conditions, fmt.Sprintf("r.status = ANY($%d)", len(args)))
+	}
+
+	productConditions, args := appendProductConditions(nil, args, filter)
+	if len(productConditions) > 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM products p WHERE p.reception_id = r.id AND "+
+			strings.Join(productConditions, " AND ")+")")
+	}
+
+	return conditions, args
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type LoginFailureRepository struct {
+	db *sqlx.DB
+}
+
+const (
+	getLoginFailures = "SELECT key, failures, last_failure_at, blocked_until FROM login_failures WHERE key = ANY($1)"
+	// Счетчик начинается заново, если прошлая неудача была раньше $3
+	recordLoginFailure = `INSERT INTO login_failures (key, failures, last_failure_at, blocked_until)
+		VALUES ($1, 1, $2, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_failures.last_failure_at < $3 THEN 1 ELSE login_failures.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING failures`
+	blockLogin               = "UPDATE login_failures SET blocked_until = GREATEST(blocked_until, $2) WHERE key = $1"
+	resetLoginFailures       = "DELETE FROM login_failures WHERE key = $1"
+	deleteStaleLoginFailures = "DELETE FROM login_failures WHERE last_failure_at < $1 AND blocked_until < $2"
+)
+
+func NewLoginFailureRepository(db *sqlx.DB) *LoginFailureRepository {
+	return &LoginFailureRepository{
+		db: db,
+	}
+}
+
+func (lr *LoginFailureRepository) GetLoginFailures(ctx context.Context, keys []string) ([]models.LoginFailure, error) {
+	ctx, span := startQuerySpan(ctx, "LoginFailureRepository.GetLoginFailures", getLoginFailures)
+	defer span.End()
+
+	rows, err := lr.db.QueryContext(ctx, getLoginFailures, pq.Array(keys))
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	failures := make([]models.LoginFailure, 0, len(keys))
+	for rows.Next() {
+		var failure models.LoginFailure
+		err = rows.Scan(
+			&failure.Key,
+			&failure.Failures,
+			&failure.LastFailureAt,
+			&failure.BlockedUntil,
+		)
+		if err != nil {
+			recordQueryError(span, err)
+			return nil, dto.ErrDBRead
+		}
+		failures = append(failures, failure)
+	}
+
+	if err = rows.Err(); err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBRead
+	}
+
+	return failures, nil
+}
+
+// RecordLoginFailure увеличивает счетчик неудач ключа и возвращает его новое
+// значение. Неудачи старше window не учитываются
+func (lr *LoginFailureRepository) RecordLoginFailure(
+	ctx context.Context,
+	key string,
+	now time.Time,
+	window time.Duration,
+) (int, error) {
+	ctx, span := startQuerySpan(ctx, "LoginFailureRepository.RecordLoginFailure", recordLoginFailure)
+	defer span.End()
+
+	var failures int
+	err := lr.db.QueryRowContext(ctx, recordLoginFailure, key, now, now.Add(-window)).Scan(&failures)
+	if err != nil {
+		recordQueryError(span, err)
+		return 0, dto.ErrDBInsert
+	}
+
+	return failures, nil
+}
+
+// BlockLogin запрещает попытки входа по ключу до until. Более поздняя
+// блокировка не сокращается
+func (lr *LoginFailureRepository) BlockLogin(ctx context.Context, key string, until time.Time) error {
+	ctx, span := startQuerySpan(ctx, "LoginFailureRepository.BlockLogin", blockLogin)
+	defer span.End()
+
+	_, err := lr.db.ExecContext(ctx, blockLogin, key, until)
+	if err != nil {
+		recordQueryError(span, err)
+		return dto.ErrDBUpdate
+	}
+
+	return nil
+}
+
+func (lr *LoginFailureRepository) ResetLoginFailures(ctx context.Context, key string) error {
+	ctx, span := startQuerySpan(ctx, "LoginFailureRepository.ResetLoginFailures", resetLoginFailures)
+	defer span.End()
+
+	_, err := lr.db.ExecContext(ctx, resetLoginFailures, key)
+	if err != nil {
+		recordQueryError(span, err)
+		return dto.ErrDBUpdate
+	}
+
+	return nil
+}
+
+// DeleteStaleLoginFailures удаляет счетчики без неудач после before и без
+// действующей блокировки, чтобы таблица не росла от перебора по IP адресам
+func (lr *LoginFailureRepository) DeleteStaleLoginFailures(ctx context.Context, before, now time.Time) (int64, error) {
+	ctx, span := startQuerySpan(ctx, "LoginFailureRepository.DeleteStaleLoginFailures", deleteStaleLoginFailures)
+	defer span.End()
+
+	res, err := lr.db.ExecContext(ctx, deleteStaleLoginFailures, before, now)
+	if err != nil {
+		recordQueryError(span, err)
+		return 0, dto.ErrDBUpdate
+	}
+
+	return res.RowsAffected()
+}
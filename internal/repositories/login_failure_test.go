@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginFailureRepository_GetLoginFailures(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewLoginFailureRepository(sqlx.NewDb(db, "postgres"))
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	keys := []string{"account:test@example.com", "ip:10.0.0.1"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(getLoginFailures)).
+		WithArgs(pq.Array(keys)).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "failures", "last_failure_at", "blocked_until"}).
+			AddRow("ip:10.0.0.1", 3, now, now.Add(4*time.Second)))
+
+	failures, err := repo.GetLoginFailures(context.Background(), keys)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "ip:10.0.0.1", failures[0].Key)
+	assert.Equal(t, 3, failures[0].Failures)
+	assert.Equal(t, now.Add(4*time.Second), failures[0].BlockedUntil)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoginFailureRepository_RecordAndBlock(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewLoginFailureRepository(sqlx.NewDb(db, "postgres"))
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(recordLoginFailure)).
+		WithArgs("account:test@example.com", now, now.Add(-15*time.Minute)).
+		WillReturnRows(sqlmock.NewRows([]string{"failures"}).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta(blockLogin)).
+		WithArgs("account:test@example.com", now.Add(2*time.Second)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	failures, err := repo.RecordLoginFailure(context.Background(), "account:test@example.com", now, 15*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, failures)
+
+	require.NoError(t, repo.BlockLogin(context.Background(), "account:test@example.com", now.Add(2*time.Second)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoginFailureRepository_Errors(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewLoginFailureRepository(sqlx.NewDb(db, "postgres"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(recordLoginFailure)).WillReturnError(sql.ErrConnDone)
+	mock.ExpectExec(regexp.QuoteMeta(resetLoginFailures)).WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.RecordLoginFailure(context.Background(), "ip:10.0.0.1", time.Now(), time.Minute)
+	assert.ErrorIs(t, err, dto.ErrDBInsert)
+
+	err = repo.ResetLoginFailures(context.Background(), "ip:10.0.0.1")
+	assert.ErrorIs(t, err, dto.ErrDBUpdate)
+}
+
+func TestLoginFailureRepository_DeleteStale(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewLoginFailureRepository(sqlx.NewDb(db, "postgres"))
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteStaleLoginFailures)).
+		WithArgs(now.Add(-time.Hour), now).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+
+	deleted, err := repo.DeleteStaleLoginFailures(context.Background(), now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), deleted)
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type PasswordResetRepository struct {
+	db *sqlx.DB
+}
+
+const (
+	// Прежние токены пользователя удаляются, поэтому действует только
+	// последняя ссылка из письма
+	createPasswordResetToken = `WITH previous AS (DELETE FROM password_reset_tokens WHERE user_id = $1)
+		INSERT INTO password_reset_tokens (token_hash, user_id, expires_at) VALUES ($2, $1, $3)`
+	// Токен удаляется при использовании, поэтому одновременные запросы
+	// с одним токеном не сменят пароль дважды
+	consumePasswordResetToken = "DELETE FROM password_reset_tokens WHERE token_hash = $1 AND expires_at > $2 RETURNING user_id"
+	updatePasswordHash        = "UPDATE users SET password_hash = $2 WHERE id = $1 RETURNING " + userColumns
+)
+
+func NewPasswordResetRepository(db *sqlx.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{
+		db: db,
+	}
+}
+
+func (pr *PasswordResetRepository) CreatePasswordResetToken(
+	ctx context.Context,
+	userId, tokenHash string,
+	expiresAt time.Time,
+) error {
+	ctx, span := startQuerySpan(ctx, "PasswordResetRepository.CreatePasswordResetToken", createPasswordResetToken)
+	defer span.End()
+
+	_, err := pr.db.ExecContext(ctx, createPasswordResetToken, userId, tokenHash, expiresAt)
+	if err != nil {
+		recordQueryError(span, err)
+		return dto.ErrDBInsert
+	}
+
+	return nil
+}
+
+// ResetPassword в одной транзакции погашает токен и меняет хеш пароля его
+// владельца. Неизвестный или истекший токен дает dto.ErrInvalidResetToken
+func (pr *PasswordResetRepository) ResetPassword(
+	ctx context.Context,
+	tokenHash, passwordHash string,
+	now time.Time,
+) (models.User, error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return models.User{}, dto.ErrDBUpdate
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var userId string
+	consumeCtx, span := startQuerySpan(ctx, "PasswordResetRepository.ConsumePasswordResetToken", consumePasswordResetToken)
+	err = tx.QueryRowContext(consumeCtx, consumePasswordResetToken, tokenHash, now).Scan(&userId)
+	recordQueryError(span, err)
+	span.End()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, dto.ErrInvalidResetToken
+		}
+		return models.User{}, dto.ErrDBUpdate
+	}
+
+	var user models.User
+	updateCtx, span := startQuerySpan(ctx, "PasswordResetRepository.UpdatePassword", updatePasswordHash)
+	err = tx.QueryRowContext(updateCtx, updatePasswordHash, userId, passwordHash).
+		Scan(
+			&user.Id,
+			&user.Email,
+			&user.Password,
+			&user.Role,
+			&user.CreatedAt,
+			&user.DisabledAt,
+		)
+	recordQueryError(span, err)
+	span.End()
+	if err != nil {
+		return models.User{}, dto.ErrDBUpdate
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.User{}, dto.ErrDBUpdate
+	}
+
+	return user, nil
+}
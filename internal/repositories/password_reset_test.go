@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordResetRepository_CreatePasswordResetToken(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewPasswordResetRepository(sqlx.NewDb(db, "postgres"))
+
+	expiresAt := time.Date(2025, 4, 1, 12, 30, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(createPasswordResetToken)).
+		WithArgs("u1", "hash", expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(createPasswordResetToken)).
+		WithArgs("u1", "hash", expiresAt).
+		WillReturnError(sql.ErrConnDone)
+
+	require.NoError(t, repo.CreatePasswordResetToken(context.Background(), "u1", "hash", expiresAt))
+	assert.ErrorIs(t, repo.CreatePasswordResetToken(context.Background(), "u1", "hash", expiresAt), dto.ErrDBInsert)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetRepository_ResetPassword(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewPasswordResetRepository(sqlx.NewDb(db, "postgres"))
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(consumePasswordResetToken)).
+		WithArgs("hash", now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1"))
+	mock.ExpectQuery(regexp.QuoteMeta(updatePasswordHash)).
+		WithArgs("u1", "newhash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role", "created_at", "disabled_at"}).
+			AddRow("u1", "test@example.com", "newhash", "employee", now, nil))
+	mock.ExpectCommit()
+
+	user, err := repo.ResetPassword(context.Background(), "hash", "newhash", now)
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.Id)
+	assert.Equal(t, "test@example.com", user.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetRepository_ResetPassword_InvalidToken(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewPasswordResetRepository(sqlx.NewDb(db, "postgres"))
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(consumePasswordResetToken)).
+		WithArgs("expired", now).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := repo.ResetPassword(context.Background(), "expired", "newhash", now)
+	assert.ErrorIs(t, err, dto.ErrInvalidResetToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,8 +1,11 @@
 package repositories
 
 import (
-	"time"
+	"context"
+	"fmt"
+	"strings"
 
+	"github.com/hamillka/avitoTechSpring25/internal/db"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/jmoiron/sqlx"
@@ -10,30 +13,42 @@ import (
 )
 
 type ProductRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	cluster *db.Cluster
 }
 
 const (
-	addProduct                = "INSERT INTO products (product_type, reception_id) VALUES ($1, $2) RETURNING id, date_time, product_type, reception_id"
-	getLastProduct            = "SELECT * FROM products WHERE reception_id = $1 ORDER BY date_time DESC LIMIT 1"
-	deleteProduct             = "DELETE FROM products WHERE id = $1"
+	addProduct                  = "INSERT INTO products (product_type, reception_id) VALUES ($1, $2) RETURNING id, date_time, product_type, reception_id"
+	getLastProduct              = "SELECT * FROM products WHERE reception_id = $1 ORDER BY date_time DESC LIMIT 1"
+	deleteProduct               = "DELETE FROM products WHERE id = $1"
+	countProductsByReceptionIds = `
+	SELECT p.reception_id, COUNT(*)
+	FROM products p
+`
+	getProductsByReception = `
+	SELECT p.id, p.date_time, p.product_type, p.reception_id
+	FROM products p
+`
 	getProductsByReceptionIds = `
-	SELECT id, date_time, product_type, reception_id 
-	FROM products 
-	WHERE reception_id = ANY($1) AND date_time BETWEEN $2 AND $3
+	SELECT p.id, p.date_time, p.product_type, p.reception_id
+	FROM products p
 `
 )
 
-func NewProductRepository(db *sqlx.DB) *ProductRepository {
+func NewProductRepository(cluster *db.Cluster) *ProductRepository {
 	return &ProductRepository{
-		db: db,
+		db:      cluster.Primary(),
+		cluster: cluster,
 	}
 }
 
-func (pr *ProductRepository) AddProduct(productType, receptionId string) (models.Product, error) {
+func (pr *ProductRepository) AddProduct(ctx context.Context, productType, receptionId string) (models.Product, error) {
+	ctx, span := startQuerySpan(ctx, "ProductRepository.AddProduct", addProduct)
+	defer span.End()
+
 	var product models.Product
 
-	err := pr.db.QueryRow(addProduct, productType, receptionId).
+	err := pr.db.QueryRowContext(ctx, addProduct, productType, receptionId).
 		Scan(
 			&product.Id,
 			&product.DateTime,
@@ -41,15 +56,19 @@ func (pr *ProductRepository) AddProduct(productType, receptionId string) (models
 			&product.ReceptionId,
 		)
 	if err != nil {
+		recordQueryError(span, err)
 		return models.Product{}, dto.ErrDBInsert
 	}
 
 	return product, nil
 }
 
-func (pr *ProductRepository) GetLastProduct(recId string) (models.Product, error) {
+func (pr *ProductRepository) GetLastProduct(ctx context.Context, recId string) (models.Product, error) {
+	ctx, span := startQuerySpan(ctx, "ProductRepository.GetLastProduct", getLastProduct)
+	defer span.End()
+
 	var product models.Product
-	err := pr.db.QueryRow(getLastProduct, recId).
+	err := pr.db.QueryRowContext(ctx, getLastProduct, recId).
 		Scan(
 			&product.Id,
 			&product.DateTime,
@@ -57,37 +76,42 @@ func (pr *ProductRepository) GetLastProduct(recId string) (models.Product, error
 			&product.ReceptionId,
 		)
 	if err != nil {
+		recordQueryError(span, err)
 		return models.Product{}, dto.ErrNoProductsInReception
 	}
 
 	return product, nil
 }
 
-func (pr *ProductRepository) DeleteProduct(prodId string) error {
-	_, err := pr.db.Exec(deleteProduct, prodId)
+func (pr *ProductRepository) DeleteProduct(ctx context.Context, prodId string) error {
+	ctx, span := startQuerySpan(ctx, "ProductRepository.DeleteProduct", deleteProduct)
+	defer span.End()
+
+	_, err := pr.db.ExecContext(ctx, deleteProduct, prodId)
 	if err != nil {
+		recordQueryError(span, err)
 		return err
 	}
 
 	return nil
 }
 
-func (pr *ProductRepository) GetProductsByReceptionIds(recIds []string, startDate, endDate *time.Time) ([]models.Product, error) {
-	start := time.Time{}
-	end := time.Now()
+func (pr *ProductRepository) GetProductsByReceptionIds(ctx context.Context, recIds []string, filter models.PVZFilter) ([]models.Product, error) {
+	conditions := []string{"p.reception_id = ANY($1)"}
+	args := []any{pq.Array(recIds)}
 
-	if startDate != nil {
-		start = *startDate
-	}
+	conditions, args = appendProductConditions(conditions, args, filter)
 
-	if endDate != nil {
-		end = *endDate
-	}
+	query := getProductsByReceptionIds + "\tWHERE " + strings.Join(conditions, " AND ")
+
+	ctx, span := startQuerySpan(ctx, "ProductRepository.GetProductsByReceptionIds", query)
+	defer span.End()
 
 	var products []models.Product
 
-	rows, err := pr.db.Query(getProductsByReceptionIds, pq.Array(recIds), start, end)
+	rows, err := pr.cluster.Replica().QueryContext(ctx, query, args...)
 	if err != nil {
+		recordQueryError(span, err)
 		return products, nil
 	}
 	defer rows.Close()
@@ -111,3 +135,94 @@ func (pr *ProductRepository) GetProductsByReceptionIds(recIds []string, startDat
 
 	return products, nil
 }
+
+func (pr *ProductRepository) CountProductsByReceptionIds(ctx context.Context, recIds []string, filter models.PVZFilter) (map[string]int, error) {
+	conditions := []string{"p.reception_id = ANY($1)"}
+	args := []any{pq.Array(recIds)}
+
+	conditions, args = appendProductConditions(conditions, args, filter)
+
+	query := countProductsByReceptionIds + "\tWHERE " + strings.Join(conditions, " AND ") + " GROUP BY p.reception_id"
+
+	ctx, span := startQuerySpan(ctx, "ProductRepository.CountProductsByReceptionIds", query)
+	defer span.End()
+
+	rows, err := pr.cluster.Replica().QueryContext(ctx, query, args...)
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(recIds))
+
+	for rows.Next() {
+		var recId string
+		var count int
+		if err = rows.Scan(&recId, &count); err != nil {
+			return nil, dto.ErrDBRead
+		}
+		counts[recId] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dto.ErrDBRead
+	}
+
+	return counts, nil
+}
+
+func (pr *ProductRepository) GetProductsByReception(ctx context.Context, recId string, filter models.ProductListFilter, limit int) ([]models.Product, error) {
+	conditions := []string{"p.reception_id = $1"}
+	args := []any{recId}
+
+	if len(filter.Types) > 0 {
+		args = append(args, pq.Array(filter.Types))
+		conditions = append(conditions, fmt.Sprintf("p.product_type = ANY($%d)", len(args)))
+	}
+
+	direction, comparison := "ASC", ">"
+	if filter.SortOrder == models.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.DateTime, filter.After.Id)
+		conditions = append(conditions, fmt.Sprintf("(p.date_time, p.id) %s ($%d, $%d)", comparison, len(args)-1, len(args)))
+	}
+
+	args = append(args, limit)
+	query := getProductsByReception + "\tWHERE " + strings.Join(conditions, " AND ") +
+		fmt.Sprintf(" ORDER BY p.date_time %s, p.id %s LIMIT $%d", direction, direction, len(args))
+
+	ctx, span := startQuerySpan(ctx, "ProductRepository.GetProductsByReception", query)
+	defer span.End()
+
+	rows, err := pr.cluster.Replica().QueryContext(ctx, query, args...)
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	products := []models.Product{}
+
+	for rows.Next() {
+		var product models.Product
+		if err = rows.Scan(
+			&product.Id,
+			&product.DateTime,
+			&product.Type,
+			&product.ReceptionId,
+		); err != nil {
+			return nil, dto.ErrDBRead
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dto.ErrDBRead
+	}
+
+	return products, nil
+}
@@ -1,12 +1,14 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,7 +16,7 @@ import (
 func TestAddProduct_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewProductRepository(sqlxDB)
+	repo := NewProductRepository(newTestCluster(sqlxDB))
 
 	time := time.Now()
 	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO products (product_type, reception_id) VALUES ($1, $2) RETURNING id, date_time, product_type, reception_id")).
@@ -22,7 +24,7 @@ func TestAddProduct_Success(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "product_type", "reception_id"}).
 			AddRow("prod1", time, "одежда", "rec1"))
 
-	p, err := repo.AddProduct("одежда", "rec1")
+	p, err := repo.AddProduct(context.Background(), "одежда", "rec1")
 	assert.NoError(t, err)
 	assert.Equal(t, "prod1", p.Id)
 }
@@ -30,20 +32,20 @@ func TestAddProduct_Success(t *testing.T) {
 func TestAddProduct_Error(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewProductRepository(sqlxDB)
+	repo := NewProductRepository(newTestCluster(sqlxDB))
 
 	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO products (product_type, reception_id) VALUES ($1, $2) RETURNING id, date_time, product_type, reception_id")).
 		WithArgs("одежда", "rec1").
 		WillReturnError(sql.ErrConnDone)
 
-	_, err := repo.AddProduct("одежда", "rec1")
+	_, err := repo.AddProduct(context.Background(), "одежда", "rec1")
 	assert.Error(t, err)
 }
 
 func TestGetLastProduct_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewProductRepository(sqlxDB)
+	repo := NewProductRepository(newTestCluster(sqlxDB))
 
 	time := time.Now()
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products WHERE reception_id = $1 ORDER BY date_time DESC LIMIT 1")).
@@ -51,7 +53,7 @@ func TestGetLastProduct_Success(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "product_type", "reception_id"}).
 			AddRow("prod1", time, "одежда", "rec1"))
 
-	p, err := repo.GetLastProduct("rec1")
+	p, err := repo.GetLastProduct(context.Background(), "rec1")
 	assert.NoError(t, err)
 	assert.Equal(t, "prod1", p.Id)
 }
@@ -59,55 +61,55 @@ func TestGetLastProduct_Success(t *testing.T) {
 func TestGetLastProduct_Error(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewProductRepository(sqlxDB)
+	repo := NewProductRepository(newTestCluster(sqlxDB))
 
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products WHERE reception_id = $1 ORDER BY date_time DESC LIMIT 1")).
 		WithArgs("rec1").
 		WillReturnError(sql.ErrNoRows)
 
-	_, err := repo.GetLastProduct("rec1")
+	_, err := repo.GetLastProduct(context.Background(), "rec1")
 	assert.Error(t, err)
 }
 
 func TestDeleteProduct_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewProductRepository(sqlxDB)
+	repo := NewProductRepository(newTestCluster(sqlxDB))
 
 	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id = $1")).
 		WithArgs("prod1").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err := repo.DeleteProduct("prod1")
+	err := repo.DeleteProduct(context.Background(), "prod1")
 	assert.NoError(t, err)
 }
 
 func TestDeleteProduct_Error(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewProductRepository(sqlxDB)
+	repo := NewProductRepository(newTestCluster(sqlxDB))
 
 	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id = $1")).
 		WithArgs("prod1").
 		WillReturnError(sql.ErrConnDone)
 
-	err := repo.DeleteProduct("prod1")
+	err := repo.DeleteProduct(context.Background(), "prod1")
 	assert.Error(t, err)
 }
 
 func TestGetProductsByReceptionIds_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewProductRepository(sqlxDB)
+	repo := NewProductRepository(newTestCluster(sqlxDB))
 
 	time := time.Now()
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, date_time, product_type, reception_id FROM products WHERE reception_id = ANY($1) AND date_time BETWEEN $2 AND $3")).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, p.date_time, p.product_type, p.reception_id FROM products p WHERE p.reception_id = ANY($1)")).
+		WithArgs(sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "product_type", "reception_id"}).
 			AddRow("prod1", time, "обувь", "rec1").
 			AddRow("prod2", time, "одежда", "rec2"))
 
-	products, err := repo.GetProductsByReceptionIds([]string{"rec1", "rec2"}, nil, nil)
+	products, err := repo.GetProductsByReceptionIds(context.Background(), []string{"rec1", "rec2"}, models.PVZFilter{})
 	assert.NoError(t, err)
 	assert.Len(t, products, 2)
 	assert.Equal(t, "prod1", products[0].Id)
@@ -117,13 +119,102 @@ func TestGetProductsByReceptionIds_Success(t *testing.T) {
 func TestGetProductsByReceptionIds_ErrorInQuery(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewProductRepository(sqlxDB)
+	repo := NewProductRepository(newTestCluster(sqlxDB))
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, date_time, product_type, reception_id FROM products WHERE reception_id = ANY($1) AND date_time BETWEEN $2 AND $3")).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, p.date_time, p.product_type, p.reception_id FROM products p WHERE p.reception_id = ANY($1)")).
+		WithArgs(sqlmock.AnyArg()).
 		WillReturnError(sql.ErrConnDone)
 
-	products, err := repo.GetProductsByReceptionIds([]string{"rec1", "rec2"}, nil, nil)
+	products, err := repo.GetProductsByReceptionIds(context.Background(), []string{"rec1", "rec2"}, models.PVZFilter{})
 	assert.NoError(t, err)
 	assert.Len(t, products, 0)
 }
+
+func TestGetProductsByReceptionIds_WithFilter(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewProductRepository(newTestCluster(sqlxDB))
+	start := time.Now().Add(-24 * time.Hour)
+	end := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE p.reception_id = ANY($1) AND p.date_time BETWEEN $2 AND $3 AND p.product_type = ANY($4)")).
+		WithArgs(sqlmock.AnyArg(), start, end, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "product_type", "reception_id"}).
+			AddRow("prod1", start, "обувь", "rec1"))
+
+	products, err := repo.GetProductsByReceptionIds(context.Background(), []string{"rec1"}, models.PVZFilter{
+		StartDate:    &start,
+		EndDate:      &end,
+		ProductTypes: []string{"обувь"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountProductsByReceptionIds_Success(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewProductRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.reception_id, COUNT(*) FROM products p WHERE p.reception_id = ANY($1) GROUP BY p.reception_id")).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"reception_id", "count"}).
+			AddRow("rec1", 3).
+			AddRow("rec2", 1))
+
+	counts, err := repo.CountProductsByReceptionIds(context.Background(), []string{"rec1", "rec2"}, models.PVZFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"rec1": 3, "rec2": 1}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProductsByReception_FirstPage(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewProductRepository(newTestCluster(sqlxDB))
+	timeNow := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM products p WHERE p.reception_id = $1 ORDER BY p.date_time ASC, p.id ASC LIMIT $2")).
+		WithArgs("rec1", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "product_type", "reception_id"}).
+			AddRow("prod1", timeNow, "обувь", "rec1").
+			AddRow("prod2", timeNow, "одежда", "rec1"))
+
+	products, err := repo.GetProductsByReception(context.Background(), "rec1", models.ProductListFilter{}, 3)
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProductsByReception_CursorDesc(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewProductRepository(newTestCluster(sqlxDB))
+	after := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE p.reception_id = $1 AND p.product_type = ANY($2) AND (p.date_time, p.id) < ($3, $4) ORDER BY p.date_time DESC, p.id DESC LIMIT $5")).
+		WithArgs("rec1", sqlmock.AnyArg(), after, "prod9", 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "product_type", "reception_id"}))
+
+	products, err := repo.GetProductsByReception(context.Background(), "rec1", models.ProductListFilter{
+		Types:     []string{"обувь"},
+		SortOrder: models.SortDesc,
+		After:     &models.ProductCursor{DateTime: after, Id: "prod9"},
+	}, 11)
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProductsByReception_Error(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewProductRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM products p WHERE p.reception_id = $1")).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.GetProductsByReception(context.Background(), "rec1", models.ProductListFilter{}, 10)
+	assert.Error(t, err)
+}
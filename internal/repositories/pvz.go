@@ -2,75 +2,191 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 
+	"github.com/hamillka/avitoTechSpring25/internal/db"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type PVZRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	cluster *db.Cluster
 }
 
 const (
-	createPVZ             = "INSERT INTO pvzs (city) VALUES ($1) RETURNING id, registration_date, city"
-	getPVZById            = "SELECT id, registration_date, city FROM pvzs WHERE id = $1"
-	getPVZsWithPagination = "SELECT id, registration_date, city FROM pvzs ORDER BY registration_date DESC LIMIT $1 OFFSET $2"
+	pvzColumns            = "id, registration_date, city, name, address, working_hours, status, latitude, longitude, timezone"
+	createPVZ             = "INSERT INTO pvzs (city) VALUES ($1) RETURNING " + pvzColumns
+	getPVZById            = "SELECT " + pvzColumns + " FROM pvzs WHERE id = $1"
+	getPVZsWithPagination = "SELECT " + pvzColumns + " FROM pvzs pv"
+	countPVZs             = "SELECT COUNT(*) FROM pvzs pv"
+	updatePVZ             = "UPDATE pvzs SET name = COALESCE($2, name), address = COALESCE($3, address), working_hours = COALESCE($4, working_hours), latitude = COALESCE($5, latitude), longitude = COALESCE($6, longitude), timezone = COALESCE($7, timezone) WHERE id = $1 RETURNING " + pvzColumns
+	updatePVZStatus       = "UPDATE pvzs SET status = $2 WHERE id = $1 RETURNING " + pvzColumns
+	getAllPVZs            = "SELECT " + pvzColumns + " FROM pvzs WHERE status <> 'archived'"
+	getNearbyPVZs         = `
+	SELECT ` + pvzColumns + `, distance
+	FROM (
+		SELECT ` + pvzColumns + `,
+			2 * 6371000 * ASIN(LEAST(1, SQRT(
+				POWER(SIN(RADIANS(latitude - $1) / 2), 2) +
+				COS(RADIANS($1)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - $2) / 2), 2)
+			))) AS distance
+		FROM pvzs
+		WHERE status <> 'archived'
+		AND latitude BETWEEN $4 AND $5
+		AND longitude BETWEEN $6 AND $7
+	) nearby
+	WHERE distance <= $3
+	ORDER BY distance
+	LIMIT $8
+`
 )
 
-func NewPVZRepository(db *sqlx.DB) *PVZRepository {
+const earthRadiusMeters = 6371000
+
+var pvzSortExpressions = map[string]string{
+	models.PVZSortRegistrationDate: "pv.registration_date",
+	models.PVZSortLastActivity: `GREATEST(pv.registration_date,
+		(SELECT MAX(r.date_time) FROM receptions r WHERE r.pvz_id = pv.id),
+		(SELECT MAX(p.date_time) FROM products p JOIN receptions r ON r.id = p.reception_id WHERE r.pvz_id = pv.id))`,
+	models.PVZSortProductCount: "(SELECT COUNT(*) FROM products p JOIN receptions r ON r.id = p.reception_id WHERE r.pvz_id = pv.id)",
+}
+
+func NewPVZRepository(cluster *db.Cluster) *PVZRepository {
 	return &PVZRepository{
-		db: db,
+		db:      cluster.Primary(),
+		cluster: cluster,
 	}
 }
 
-func (pvzr *PVZRepository) CreatePVZ(city string) (models.PVZ, error) {
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPVZ(row rowScanner, extra ...any) (models.PVZ, error) {
 	var pvz models.PVZ
+	dest := []any{
+		&pvz.Id,
+		&pvz.RegistrationDate,
+		&pvz.City,
+		&pvz.Name,
+		&pvz.Address,
+		&pvz.WorkingHours,
+		&pvz.Status,
+		&pvz.Latitude,
+		&pvz.Longitude,
+		&pvz.Timezone,
+	}
+	err := row.Scan(append(dest, extra...)...)
 
-	err := pvzr.db.QueryRow(createPVZ, city).
-		Scan(
-			&pvz.Id,
-			&pvz.RegistrationDate,
-			&pvz.City,
-		)
+	return pvz, err
+}
+
+func (pvzr *PVZRepository) CreatePVZ(ctx context.Context, city string) (models.PVZ, error) {
+	ctx, span := startQuerySpan(ctx, "PVZRepository.CreatePVZ", createPVZ)
+	defer span.End()
+
+	pvz, err := scanPVZ(pvzr.db.QueryRowContext(ctx, createPVZ, city))
 	if err != nil {
+		recordQueryError(span, err)
 		return models.PVZ{}, dto.ErrDBInsert
 	}
 
 	return pvz, nil
 }
 
-func (pvzr *PVZRepository) GetPVZById(pvzId string) (models.PVZ, error) {
-	var pvz models.PVZ
-	err := pvzr.db.QueryRow(getPVZById, pvzId).
-		Scan(
-			&pvz.Id,
-			&pvz.RegistrationDate,
-			&pvz.City,
-		)
+func (pvzr *PVZRepository) GetPVZById(ctx context.Context, pvzId string) (models.PVZ, error) {
+	ctx, span := startQuerySpan(ctx, "PVZRepository.GetPVZById", getPVZById)
+	defer span.End()
+
+	pvz, err := scanPVZ(pvzr.db.QueryRowContext(ctx, getPVZById, pvzId))
 	if err != nil {
+		recordQueryError(span, err)
 		return models.PVZ{}, dto.ErrPVZNotFound
 	}
 
 	return pvz, nil
 }
 
-func (pvzr *PVZRepository) GetPVZsWithPagination(offset, limit int) ([]models.PVZ, error) {
+func pvzFilterConditions(filter models.PVZFilter) ([]string, []any) {
+	conditions := make([]string, 0, 5)
+	args := make([]any, 0, 6)
+
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "pv.status <> 'archived'")
+	}
+
+	if len(filter.Cities) > 0 {
+		args = append(args, pq.Array(filter.Cities))
+		conditions = append(conditions, fmt.Sprintf("pv.city = ANY($%d)", len(args)))
+	}
+
+	if len(filter.PVZIds) > 0 {
+		args = append(args, pq.Array(filter.PVZIds))
+		conditions = append(conditions, fmt.Sprintf("pv.id = ANY($%d)", len(args)))
+	}
+
+	if filter.HasOpenReception != nil {
+		exists := "EXISTS"
+		if !*filter.HasOpenReception {
+			exists = "NOT EXISTS"
+		}
+		conditions = append(conditions, exists+" (SELECT 1 FROM receptions r WHERE r.pvz_id = pv.id AND r.status NOT IN ('close', 'cancelled'))")
+	}
+
+	receptionConditions, args := appendReceptionConditions(nil, args, filter)
+	if len(receptionConditions) > 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM receptions r WHERE r.pvz_id = pv.id AND "+
+			strings.Join(receptionConditions, " AND ")+")")
+	}
+
+	return conditions, args
+}
+
+func pvzOrderBy(filter models.PVZFilter) string {
+	expression, ok := pvzSortExpressions[filter.SortBy]
+	if !ok {
+		expression = pvzSortExpressions[models.PVZSortRegistrationDate]
+	}
+
+	direction := "DESC"
+	if filter.SortOrder == models.SortAsc {
+		direction = "ASC"
+	}
+
+	return " ORDER BY " + expression + " " + direction + ", pv.id " + direction
+}
+
+func (pvzr *PVZRepository) GetPVZsWithPagination(ctx context.Context, filter models.PVZFilter, offset, limit int) ([]models.PVZ, error) {
 	pvzs := []models.PVZ{}
 
-	rows, err := pvzr.db.Query(getPVZsWithPagination, limit, offset)
+	conditions, args := pvzFilterConditions(filter)
+
+	query := getPVZsWithPagination
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit, offset)
+	query += pvzOrderBy(filter) + fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	ctx, span := startQuerySpan(ctx, "PVZRepository.GetPVZsWithPagination", query)
+	defer span.End()
+
+	rows, err := pvzr.cluster.Replica().QueryContext(ctx, query, args...)
 	if err != nil {
+		recordQueryError(span, err)
 		return nil, dto.ErrDBRead
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var pvz models.PVZ
-		err = rows.Scan(
-			&pvz.Id,
-			&pvz.RegistrationDate,
-			&pvz.City,
-		)
+		pvz, err := scanPVZ(rows)
 		if err != nil {
 			return nil, dto.ErrDBRead
 		}
@@ -84,8 +200,120 @@ func (pvzr *PVZRepository) GetPVZsWithPagination(offset, limit int) ([]models.PV
 	return pvzs, nil
 }
 
+func (pvzr *PVZRepository) CountPVZs(ctx context.Context, filter models.PVZFilter) (int, error) {
+	conditions, args := pvzFilterConditions(filter)
+
+	query := countPVZs
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	ctx, span := startQuerySpan(ctx, "PVZRepository.CountPVZs", query)
+	defer span.End()
+
+	var total int
+	err := pvzr.cluster.Replica().QueryRowContext(ctx, query, args...).Scan(&total)
+	if err != nil {
+		recordQueryError(span, err)
+		return 0, dto.ErrDBRead
+	}
+
+	return total, nil
+}
+
 func (pvzr *PVZRepository) GetAllPVZs(ctx context.Context) ([]models.PVZ, error) {
+	ctx, span := startQuerySpan(ctx, "PVZRepository.GetAllPVZs", getAllPVZs)
+	defer span.End()
+
 	var pvzs []models.PVZ
-	err := pvzr.db.SelectContext(ctx, &pvzs, "SELECT * FROM pvzs")
+	err := pvzr.cluster.Replica().SelectContext(ctx, &pvzs, getAllPVZs)
+	recordQueryError(span, err)
 	return pvzs, err
 }
+
+func (pvzr *PVZRepository) UpdatePVZ(ctx context.Context, pvzId string, upd models.PVZUpdate) (models.PVZ, error) {
+	ctx, span := startQuerySpan(ctx, "PVZRepository.UpdatePVZ", updatePVZ)
+	defer span.End()
+
+	pvz, err := scanPVZ(pvzr.db.QueryRowContext(ctx, updatePVZ, pvzId, upd.Name, upd.Address, upd.WorkingHours, upd.Latitude, upd.Longitude, upd.Timezone))
+	if err != nil {
+		recordQueryError(span, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PVZ{}, dto.ErrPVZNotFound
+		}
+		return models.PVZ{}, dto.ErrDBUpdate
+	}
+
+	return pvz, nil
+}
+
+func (pvzr *PVZRepository) UpdatePVZStatus(ctx context.Context, pvzId, status string) (models.PVZ, error) {
+	ctx, span := startQuerySpan(ctx, "PVZRepository.UpdatePVZStatus", updatePVZStatus)
+	defer span.End()
+
+	pvz, err := scanPVZ(pvzr.db.QueryRowContext(ctx, updatePVZStatus, pvzId, status))
+	if err != nil {
+		recordQueryError(span, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PVZ{}, dto.ErrPVZNotFound
+		}
+		return models.PVZ{}, dto.ErrDBUpdate
+	}
+
+	return pvz, nil
+}
+
+// boundingBox возвращает границы квадрата вокруг точки, в который гарантированно
+// попадает круг заданного радиуса. Он отсекает заведомо далекие ПВЗ по индексу
+// до вычисления расстояния. Если квадрат выходит за полюс или антимеридиан,
+// ограничение по долготе снимается
+func boundingBox(lat, lon, radius float64) (minLat, maxLat, minLon, maxLon float64) {
+	dLat := radius / earthRadiusMeters * 180 / math.Pi
+
+	minLat, maxLat = lat-dLat, lat+dLat
+	if minLat <= -90 || maxLat >= 90 {
+		return math.Max(minLat, -90), math.Min(maxLat, 90), -180, 180
+	}
+
+	dLon := dLat / math.Cos(lat*math.Pi/180)
+	minLon, maxLon = lon-dLon, lon+dLon
+	if minLon < -180 || maxLon > 180 {
+		return minLat, maxLat, -180, 180
+	}
+
+	return minLat, maxLat, minLon, maxLon
+}
+
+func (pvzr *PVZRepository) GetNearbyPVZs(ctx context.Context, lat, lon, radius float64, limit int) ([]models.PVZWithDistance, error) {
+	minLat, maxLat, minLon, maxLon := boundingBox(lat, lon, radius)
+
+	ctx, span := startQuerySpan(ctx, "PVZRepository.GetNearbyPVZs", getNearbyPVZs)
+	defer span.End()
+
+	rows, err := pvzr.cluster.Replica().QueryContext(ctx, getNearbyPVZs, lat, lon, radius, minLat, maxLat, minLon, maxLon, limit)
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	pvzs := []models.PVZWithDistance{}
+
+	for rows.Next() {
+		var distance float64
+		pvz, err := scanPVZ(rows, &distance)
+		if err != nil {
+			return nil, dto.ErrDBRead
+		}
+		pvzs = append(pvzs, models.PVZWithDistance{
+			PVZ:      pvz,
+			Distance: distance,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dto.ErrDBRead
+	}
+
+	return pvzs, nil
+}
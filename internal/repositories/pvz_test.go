@@ -1,28 +1,36 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hamillka/avitoTechSpring25/internal/db"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
 
+func newTestCluster(primary *sqlx.DB, replicas ...*sqlx.DB) *db.Cluster {
+	return db.NewCluster(primary, replicas...)
+}
+
 func TestPVZRepository_CreatePVZ_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewPVZRepository(sqlxDB)
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO pvzs (city) VALUES ($1) RETURNING id, registration_date, city`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO pvzs (city) VALUES ($1) RETURNING id, registration_date, city, name, address, working_hours, status, latitude, longitude, timezone`)).
 		WithArgs("Москва").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city"}).
-			AddRow("123", time.Now(), "Москва"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city", "name", "address", "working_hours", "status", "latitude", "longitude", "timezone"}).
+			AddRow("123", time.Now(), "Москва", "", "", "", "active", nil, nil, "Europe/Moscow"))
 
-	pvz, err := repo.CreatePVZ("Москва")
+	pvz, err := repo.CreatePVZ(context.Background(), "Москва")
 	assert.NoError(t, err)
 	assert.Equal(t, "123", pvz.Id)
 	assert.Equal(t, "Москва", pvz.City)
@@ -32,28 +40,28 @@ func TestPVZRepository_CreatePVZ_Success(t *testing.T) {
 func TestPVZRepository_CreatePVZ_Error(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewPVZRepository(sqlxDB)
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO pvzs (city) VALUES ($1) RETURNING id, registration_date, city`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO pvzs (city) VALUES ($1) RETURNING id, registration_date, city, name, address, working_hours, status, latitude, longitude, timezone`)).
 		WithArgs("Казань").
 		WillReturnError(sql.ErrConnDone)
 
-	_, err := repo.CreatePVZ("Казань")
+	_, err := repo.CreatePVZ(context.Background(), "Казань")
 	assert.Error(t, err)
 }
 
 func TestPVZRepository_GetPVZById_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewPVZRepository(sqlxDB)
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
 	timeNow := time.Now()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city FROM pvzs WHERE id = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city, name, address, working_hours, status, latitude, longitude, timezone FROM pvzs WHERE id = $1`)).
 		WithArgs("abc123").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city"}).
-			AddRow("abc123", timeNow, "Санкт-Петербург"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city", "name", "address", "working_hours", "status", "latitude", "longitude", "timezone"}).
+			AddRow("abc123", timeNow, "Санкт-Петербург", "", "", "", "active", nil, nil, "Europe/Moscow"))
 
-	pvz, err := repo.GetPVZById("abc123")
+	pvz, err := repo.GetPVZById(context.Background(), "abc123")
 	assert.NoError(t, err)
 	assert.Equal(t, "abc123", pvz.Id)
 	assert.Equal(t, "Санкт-Петербург", pvz.City)
@@ -62,29 +70,29 @@ func TestPVZRepository_GetPVZById_Success(t *testing.T) {
 func TestPVZRepository_GetPVZById_NotFound(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewPVZRepository(sqlxDB)
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city FROM pvzs WHERE id = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city, name, address, working_hours, status, latitude, longitude, timezone FROM pvzs WHERE id = $1`)).
 		WithArgs("notfound").
 		WillReturnError(sql.ErrNoRows)
 
-	_, err := repo.GetPVZById("notfound")
+	_, err := repo.GetPVZById(context.Background(), "notfound")
 	assert.Error(t, err)
 }
 
 func TestPVZRepository_GetPVZsWithPagination_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewPVZRepository(sqlxDB)
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
 	timeNow := time.Now()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city FROM pvzs ORDER BY registration_date DESC LIMIT $1 OFFSET $2`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city, name, address, working_hours, status, latitude, longitude, timezone FROM pvzs pv WHERE pv.status <> 'archived' ORDER BY pv.registration_date DESC, pv.id DESC LIMIT $1 OFFSET $2`)).
 		WithArgs(10, 0).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city"}).
-			AddRow("id1", timeNow, "Москва").
-			AddRow("id2", timeNow, "Казань"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city", "name", "address", "working_hours", "status", "latitude", "longitude", "timezone"}).
+			AddRow("id1", timeNow, "Москва", "", "", "", "active", nil, nil, "Europe/Moscow").
+			AddRow("id2", timeNow, "Казань", "", "", "", "inactive", 55.75, 37.62, "Europe/Moscow"))
 
-	pvzs, err := repo.GetPVZsWithPagination(0, 10)
+	pvzs, err := repo.GetPVZsWithPagination(context.Background(), models.PVZFilter{}, 0, 10)
 	assert.NoError(t, err)
 	assert.Len(t, pvzs, 2)
 	assert.Equal(t, "Москва", pvzs[0].City)
@@ -94,12 +102,162 @@ func TestPVZRepository_GetPVZsWithPagination_Success(t *testing.T) {
 func TestPVZRepository_GetPVZsWithPagination_DBError(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewPVZRepository(sqlxDB)
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city FROM pvzs ORDER BY registration_date DESC LIMIT $1 OFFSET $2`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city, name, address, working_hours, status, latitude, longitude, timezone FROM pvzs pv WHERE pv.status <> 'archived' ORDER BY pv.registration_date DESC, pv.id DESC LIMIT $1 OFFSET $2`)).
 		WithArgs(10, 0).
 		WillReturnError(sql.ErrConnDone)
 
-	_, err := repo.GetPVZsWithPagination(0, 10)
+	_, err := repo.GetPVZsWithPagination(context.Background(), models.PVZFilter{}, 0, 10)
 	assert.Error(t, err)
 }
+
+func TestPVZRepository_GetPVZsWithPagination_WithFilters(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
+	hasOpen := true
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM pvzs pv WHERE pv.city = ANY($1) `+
+		`AND EXISTS (SELECT 1 FROM receptions r WHERE r.pvz_id = pv.id AND r.status NOT IN ('close', 'cancelled')) `+
+		`AND EXISTS (SELECT 1 FROM receptions r WHERE r.pvz_id = pv.id AND r.status = ANY($2) `+
+		`AND EXISTS (SELECT 1 FROM products p WHERE p.reception_id = r.id AND p.product_type = ANY($3))) `+
+		`ORDER BY (SELECT COUNT(*) FROM products p JOIN receptions r ON r.id = p.reception_id WHERE r.pvz_id = pv.id) ASC, pv.id ASC `+
+		`LIMIT $4 OFFSET $5`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city", "name", "address", "working_hours", "status", "latitude", "longitude", "timezone"}).
+			AddRow("id1", time.Now(), "Москва", "", "", "", "inactive", nil, nil, "Europe/Moscow"))
+
+	pvzs, err := repo.GetPVZsWithPagination(context.Background(), models.PVZFilter{
+		Cities:            []string{"Москва"},
+		ReceptionStatuses: []string{"in_progress"},
+		ProductTypes:      []string{"обувь"},
+		HasOpenReception:  &hasOpen,
+		IncludeArchived:   true,
+		SortBy:            models.PVZSortProductCount,
+		SortOrder:         models.SortAsc,
+	}, 20, 10)
+	assert.NoError(t, err)
+	assert.Len(t, pvzs, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPVZRepository_GetAllPVZs_SkipsArchived(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, registration_date, city, name, address, working_hours, status, latitude, longitude, timezone FROM pvzs WHERE status <> 'archived'`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city", "name", "address", "working_hours", "status", "latitude", "longitude", "timezone"}).
+			AddRow("pvz1", time.Now(), "Москва", "", "", "", "active", nil, nil, "Europe/Moscow"))
+
+	pvzs, err := repo.GetAllPVZs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, pvzs, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPVZRepository_CountPVZs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM pvzs pv WHERE pv.status <> 'archived' AND pv.city = ANY($1)`)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	total, err := repo.CountPVZs(context.Background(), models.PVZFilter{Cities: []string{"Москва"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPVZRepository_UpdatePVZ_Success(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
+	name := "ПВЗ на Ленина"
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE pvzs SET name = COALESCE($2, name), address = COALESCE($3, address), working_hours = COALESCE($4, working_hours), latitude = COALESCE($5, latitude), longitude = COALESCE($6, longitude), timezone = COALESCE($7, timezone) WHERE id = $1`)).
+		WithArgs("pvz1", &name, nil, nil, nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city", "name", "address", "working_hours", "status", "latitude", "longitude", "timezone"}).
+			AddRow("pvz1", time.Now(), "Казань", name, "ул. Ленина, 1", "09:00-21:00", "active", nil, nil, "Europe/Moscow"))
+
+	pvz, err := repo.UpdatePVZ(context.Background(), "pvz1", models.PVZUpdate{Name: &name})
+	assert.NoError(t, err)
+	assert.Equal(t, name, pvz.Name)
+	assert.Equal(t, "09:00-21:00", pvz.WorkingHours)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPVZRepository_UpdatePVZStatus_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE pvzs SET status = $2 WHERE id = $1`)).
+		WithArgs("pvz404", "archived").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.UpdatePVZStatus(context.Background(), "pvz404", "archived")
+	assert.ErrorIs(t, err, dto.ErrPVZNotFound)
+}
+
+func TestPVZRepository_GetNearbyPVZs_Success(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewPVZRepository(newTestCluster(sqlxDB))
+	minLat, maxLat, minLon, maxLon := boundingBox(55.75, 37.62, 1000)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`AS distance`)).
+		WithArgs(55.75, 37.62, 1000.0, minLat, maxLat, minLon, maxLon, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city", "name", "address", "working_hours", "status", "latitude", "longitude", "timezone", "distance"}).
+			AddRow("pvz1", time.Now(), "Москва", "", "", "", "active", 55.751, 37.621, "Europe/Moscow", 130.5))
+
+	pvzs, err := repo.GetNearbyPVZs(context.Background(), 55.75, 37.62, 1000, 5)
+	assert.NoError(t, err)
+	assert.Len(t, pvzs, 1)
+	assert.Equal(t, "pvz1", pvzs[0].PVZ.Id)
+	assert.Equal(t, 130.5, pvzs[0].Distance)
+	assert.InDelta(t, 55.751, *pvzs[0].PVZ.Latitude, 1e-9)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBoundingBox(t *testing.T) {
+	minLat, maxLat, minLon, maxLon := boundingBox(55.75, 37.62, 10000)
+	assert.InDelta(t, 55.66, minLat, 0.01)
+	assert.InDelta(t, 55.84, maxLat, 0.01)
+	assert.Less(t, minLon, 37.62-0.09)
+	assert.Greater(t, maxLon, 37.62+0.09)
+
+	_, _, minLon, maxLon = boundingBox(89.99, 10, 10000)
+	assert.Equal(t, -180.0, minLon)
+	assert.Equal(t, 180.0, maxLon)
+
+	_, _, minLon, maxLon = boundingBox(0, 179.99, 10000)
+	assert.Equal(t, -180.0, minLon)
+	assert.Equal(t, 180.0, maxLon)
+}
+
+func TestPVZRepository_ReadsFromReplicaWritesToPrimary(t *testing.T) {
+	primaryDB, primaryMock, _ := sqlmock.New()
+	replicaDB, replicaMock, _ := sqlmock.New()
+	repo := NewPVZRepository(newTestCluster(sqlx.NewDb(primaryDB, "postgres"), sqlx.NewDb(replicaDB, "postgres")))
+
+	replicaMock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM pvzs pv`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	primaryMock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO pvzs`)).
+		WithArgs("Москва").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_date", "city", "name", "address", "working_hours", "status", "latitude", "longitude", "timezone"}).
+			AddRow("id1", time.Now(), "Москва", "", "", "", "active", nil, nil, "Europe/Moscow"))
+
+	total, err := repo.CountPVZs(context.Background(), models.PVZFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+
+	_, err = repo.CreatePVZ(context.Background(), "Москва")
+	assert.NoError(t, err)
+
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+}
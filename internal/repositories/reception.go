@@ -1,9 +1,14 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/hamillka/avitoTechSpring25/internal/db"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/jmoiron/sqlx"
@@ -11,39 +16,93 @@ import (
 )
 
 type ReceptionRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	cluster *db.Cluster
 }
 
 const (
-	getLastReception              = "SELECT id, date_time, pvz_id, status FROM receptions WHERE pvz_id = $1 ORDER BY date_time DESC LIMIT 1"
-	createReception               = "INSERT INTO receptions (pvz_id) VALUES ($1) RETURNING id, date_time, pvz_id, status"
-	updateReceptionStatus         = "UPDATE receptions SET status = $1 WHERE id = $2 RETURNING id, date_time, pvz_id, status"
-	getReceptionsByPVZIdsFiltered = `
-	SELECT r.id, r.date_time, r.pvz_id, r.status 
-	FROM receptions r
-	WHERE r.pvz_id = ANY($1) 
-	AND EXISTS (
-		SELECT 1 FROM products p 
-		WHERE p.reception_id = r.id 
-		AND p.date_time BETWEEN $2 AND $3
+	getLastReception      = "SELECT id, date_time, pvz_id, status FROM receptions WHERE pvz_id = $1 ORDER BY date_time DESC LIMIT 1"
+	hasOpenReception      = "SELECT EXISTS (SELECT 1 FROM receptions WHERE pvz_id = $1 AND status IN ('in_progress', 'paused', 'reopened_for_correction'))"
+	getReceptionById      = "SELECT id, date_time, pvz_id, status FROM receptions WHERE id = $1"
+	changeReceptionStatus = "UPDATE receptions SET status = $1, close_reason = NULL WHERE id = $2 AND status = $3 RETURNING id, date_time, pvz_id, status"
+	insertStatusChange    = "INSERT INTO reception_status_history (reception_id, from_status, to_status, changed_by_role, reason) VALUES ($1, $2, $3, $4, NULLIF($5, ''))"
+	getStatusHistory      = "SELECT reception_id, COALESCE(from_status, ''), to_status, changed_at, changed_by_role, COALESCE(reason, '') FROM reception_status_history WHERE reception_id = $1 ORDER BY changed_at"
+	// createReception сразу пишет в историю начальный статус приемки: один
+	// оператор выполняется атомарно, и у каждой приемки есть первая запись
+	createReception = `
+	WITH created AS (
+		INSERT INTO receptions (pvz_id) VALUES ($1)
+		RETURNING id, date_time, pvz_id, status
+	), history AS (
+		INSERT INTO reception_status_history (reception_id, to_status, changed_at, changed_by_role)
+		SELECT id, status, date_time, $2 FROM created
 	)
+	SELECT id, date_time, pvz_id, status FROM created
 `
 	getReceptionsByPVZIds = `
-	SELECT id, date_time, pvz_id, status 
-	FROM receptions 
-	WHERE pvz_id = ANY($1)
+	SELECT r.id, r.date_time, r.pvz_id, r.status
+	FROM receptions r
+`
+	getReceptionsByPVZ = `
+	SELECT id, date_time, pvz_id, status
+	FROM receptions
+`
+	exportReceptions = `
+	SELECT pv.id, pv.city, r.id, r.date_time, r.status, p.id, p.date_time, p.product_type
+	FROM receptions r
+	JOIN pvzs pv ON pv.id = r.pvz_id
+	LEFT JOIN products p ON p.reception_id = r.id
+`
+	getOpenReceptionStats = `
+	SELECT pv.city, COUNT(*), EXTRACT(EPOCH FROM NOW() - MIN(r.date_time))
+	FROM receptions r
+	JOIN pvzs pv ON pv.id = r.pvz_id
+	WHERE r.status IN ('in_progress', 'paused', 'reopened_for_correction')
+	GROUP BY pv.city
+`
+	closeStaleReceptions = `
+	WITH closed AS (
+		UPDATE receptions r
+		SET status = 'close',
+			close_reason = CASE
+				WHEN $1::float8 > 0 AND r.date_time < NOW() - make_interval(secs => $1::float8) THEN 'auto_max_age'
+				ELSE 'auto_idle'
+			END
+		FROM receptions prev
+		WHERE prev.id = r.id
+		AND r.status IN ('in_progress', 'paused')
+		AND (
+			($1::float8 > 0 AND r.date_time < NOW() - make_interval(secs => $1::float8))
+			OR ($2::float8 > 0 AND COALESCE(
+				(SELECT MAX(p.date_time) FROM products p WHERE p.reception_id = r.id),
+				r.date_time
+			) < NOW() - make_interval(secs => $2::float8))
+		)
+		RETURNING r.id, r.date_time, r.pvz_id, r.status, r.close_reason, prev.status AS prev_status
+	), history AS (
+		INSERT INTO reception_status_history (reception_id, from_status, to_status, changed_by_role, reason)
+		SELECT id, prev_status, status, 'system', close_reason FROM closed
+	)
+	SELECT id, date_time, pvz_id, status, close_reason FROM closed
+`
+	exportReceptionsOrder = `
+	ORDER BY pv.id, r.date_time, p.date_time
 `
 )
 
-func NewReceptionRepository(db *sqlx.DB) *ReceptionRepository {
+func NewReceptionRepository(cluster *db.Cluster) *ReceptionRepository {
 	return &ReceptionRepository{
-		db: db,
+		db:      cluster.Primary(),
+		cluster: cluster,
 	}
 }
 
-func (rr *ReceptionRepository) GetLastReception(pvzId string) (models.Reception, error) {
+func (rr *ReceptionRepository) GetLastReception(ctx context.Context, pvzId string) (models.Reception, error) {
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.GetLastReception", getLastReception)
+	defer span.End()
+
 	var reception models.Reception
-	err := rr.db.QueryRow(getLastReception, pvzId).
+	err := rr.db.QueryRowContext(ctx, getLastReception, pvzId).
 		Scan(
 			&reception.Id,
 			&reception.DateTime,
@@ -51,16 +110,20 @@ func (rr *ReceptionRepository) GetLastReception(pvzId string) (models.Reception,
 			&reception.Status,
 		)
 	if err != nil {
+		recordQueryError(span, err)
 		return models.Reception{}, dto.ErrDBRead
 	}
 
 	return reception, nil
 }
 
-func (rr *ReceptionRepository) CreateReception(pvzId string) (models.Reception, error) {
+func (rr *ReceptionRepository) CreateReception(ctx context.Context, pvzId, role string) (models.Reception, error) {
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.CreateReception", createReception)
+	defer span.End()
+
 	var reception models.Reception
 
-	err := rr.db.QueryRow(createReception, pvzId).
+	err := rr.db.QueryRowContext(ctx, createReception, pvzId, role).
 		Scan(
 			&reception.Id,
 			&reception.DateTime,
@@ -68,52 +131,209 @@ func (rr *ReceptionRepository) CreateReception(pvzId string) (models.Reception,
 			&reception.Status,
 		)
 	if err != nil {
+		recordQueryError(span, err)
 		return models.Reception{}, dto.ErrDBInsert
 	}
 
 	return reception, nil
 }
 
-func (rr *ReceptionRepository) UpdateReceptionStatus(recId, status string) (models.Reception, error) {
+// HasOpenReception проверяет, есть ли у ПВЗ незакрытая приемка
+func (rr *ReceptionRepository) HasOpenReception(ctx context.Context, pvzId string) (bool, error) {
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.HasOpenReception", hasOpenReception)
+	defer span.End()
+
+	var open bool
+	err := rr.db.QueryRowContext(ctx, hasOpenReception, pvzId).Scan(&open)
+	if err != nil {
+		recordQueryError(span, err)
+		return false, dto.ErrDBRead
+	}
+
+	return open, nil
+}
+
+func (rr *ReceptionRepository) GetReceptionById(ctx context.Context, recId string) (models.Reception, error) {
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.GetReceptionById", getReceptionById)
+	defer span.End()
+
 	var reception models.Reception
+	err := rr.db.QueryRowContext(ctx, getReceptionById, recId).
+		Scan(
+			&reception.Id,
+			&reception.DateTime,
+			&reception.PVZId,
+			&reception.Status,
+		)
+	if err != nil {
+		recordQueryError(span, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Reception{}, dto.ErrReceptionNotFound
+		}
+		return models.Reception{}, dto.ErrDBRead
+	}
+
+	return reception, nil
+}
 
-	err := rr.db.QueryRow(updateReceptionStatus,
-		status,
+func (rr *ReceptionRepository) ChangeReceptionStatus(
+	ctx context.Context,
+	recId, fromStatus, toStatus, role, reason string,
+) (models.Reception, error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return models.Reception{}, dto.ErrDBUpdate
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var reception models.Reception
+	updateCtx, span := startQuerySpan(ctx, "ReceptionRepository.ChangeReceptionStatus", changeReceptionStatus)
+	err = tx.QueryRowContext(updateCtx, changeReceptionStatus,
+		toStatus,
 		recId,
+		fromStatus,
 	).Scan(&reception.Id,
 		&reception.DateTime,
 		&reception.PVZId,
 		&reception.Status,
 	)
+	recordQueryError(span, err)
+	span.End()
 	if err != nil {
-		return reception, dto.ErrDBUpdate
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Reception{}, dto.ErrInvalidStatusChange
+		}
+		return models.Reception{}, dto.ErrDBUpdate
+	}
+
+	insertCtx, span := startQuerySpan(ctx, "ReceptionRepository.InsertStatusChange", insertStatusChange)
+	_, err = tx.ExecContext(insertCtx, insertStatusChange, recId, fromStatus, toStatus, role, reason)
+	recordQueryError(span, err)
+	span.End()
+	if err != nil {
+		return models.Reception{}, dto.ErrDBInsert
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.Reception{}, dto.ErrDBUpdate
 	}
 
 	return reception, nil
 }
 
-func (rr *ReceptionRepository) GetReceptionsByPVZIds(pvzIds []string, startDate, endDate *time.Time) ([]models.Reception, error) {
-	var rows *sql.Rows
-	var err error
+func (rr *ReceptionRepository) GetStatusHistory(ctx context.Context, recId string) ([]models.ReceptionStatusChange, error) {
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.GetStatusHistory", getStatusHistory)
+	defer span.End()
 
-	if startDate == nil && endDate == nil {
-		rows, err = rr.db.Query(getReceptionsByPVZIds, pq.Array(pvzIds))
-	} else {
-		start := time.Time{}
-		end := time.Now()
+	rows, err := rr.db.QueryContext(ctx, getStatusHistory, recId)
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	history := []models.ReceptionStatusChange{}
 
-		if startDate != nil {
-			start = *startDate
+	for rows.Next() {
+		var change models.ReceptionStatusChange
+		err = rows.Scan(
+			&change.ReceptionId,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.ChangedAt,
+			&change.ChangedByRole,
+			&change.Reason,
+		)
+		if err != nil {
+			return nil, dto.ErrDBRead
 		}
+		history = append(history, change)
+	}
 
-		if endDate != nil {
-			end = *endDate
+	if err = rows.Err(); err != nil {
+		return nil, dto.ErrDBRead
+	}
+
+	return history, nil
+}
+
+func (rr *ReceptionRepository) GetReceptionsByPVZIds(ctx context.Context, pvzIds []string, filter models.PVZFilter) ([]models.Reception, error) {
+	conditions := []string{"r.pvz_id = ANY($1)"}
+	args := []any{pq.Array(pvzIds)}
+
+	conditions, args = appendReceptionConditions(conditions, args, filter)
+
+	query := getReceptionsByPVZIds + "\tWHERE " + strings.Join(conditions, " AND ")
+
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.GetReceptionsByPVZIds", query)
+	defer span.End()
+
+	rows, err := rr.cluster.Replica().QueryContext(ctx, query, args...)
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	receptions := []models.Reception{}
+
+	for rows.Next() {
+		var reception models.Reception
+		err = rows.Scan(
+			&reception.Id,
+			&reception.DateTime,
+			&reception.PVZId,
+			&reception.Status,
+		)
+		if err != nil {
+			return nil, dto.ErrDBRead
 		}
+		receptions = append(receptions, reception)
+	}
 
-		rows, err = rr.db.Query(getReceptionsByPVZIdsFiltered, pq.Array(pvzIds), start, end)
+	if err = rows.Err(); err != nil {
+		return nil, dto.ErrDBRead
 	}
 
+	return receptions, nil
+}
+
+func (rr *ReceptionRepository) GetReceptionsByPVZ(
+	ctx context.Context,
+	pvzId string,
+	filter models.ReceptionListFilter,
+	offset, limit int,
+) ([]models.Reception, error) {
+	conditions := []string{"pvz_id = $1"}
+	args := []any{pvzId}
+
+	if len(filter.Statuses) > 0 {
+		args = append(args, pq.Array(filter.Statuses))
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+
+	if filter.StartDate != nil {
+		args = append(args, *filter.StartDate)
+		conditions = append(conditions, fmt.Sprintf("date_time >= $%d", len(args)))
+	}
+
+	if filter.EndDate != nil {
+		args = append(args, *filter.EndDate)
+		conditions = append(conditions, fmt.Sprintf("date_time <= $%d", len(args)))
+	}
+
+	args = append(args, limit, offset)
+	query := getReceptionsByPVZ + "\tWHERE " + strings.Join(conditions, " AND ") +
+		fmt.Sprintf(" ORDER BY date_time DESC, id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.GetReceptionsByPVZ", query)
+	defer span.End()
+
+	rows, err := rr.cluster.Replica().QueryContext(ctx, query, args...)
 	if err != nil {
+		recordQueryError(span, err)
 		return nil, dto.ErrDBRead
 	}
 	defer rows.Close()
@@ -140,3 +360,142 @@ func (rr *ReceptionRepository) GetReceptionsByPVZIds(pvzIds []string, startDate,
 
 	return receptions, nil
 }
+
+func (rr *ReceptionRepository) StreamReceptionsForExport(
+	ctx context.Context,
+	filter models.PVZFilter,
+	fn func(row models.ReceptionExportRow) error,
+) error {
+	conditions := make([]string, 0, 3)
+	args := make([]any, 0, 4)
+
+	conditions, args = appendProductConditions(conditions, args, filter)
+
+	if len(filter.Cities) > 0 {
+		args = append(args, pq.Array(filter.Cities))
+		conditions = append(conditions, fmt.Sprintf("pv.city = ANY($%d)", len(args)))
+	}
+
+	if len(filter.PVZIds) > 0 {
+		args = append(args, pq.Array(filter.PVZIds))
+		conditions = append(conditions, fmt.Sprintf("pv.id = ANY($%d)", len(args)))
+	}
+
+	query := exportReceptions
+	if len(conditions) > 0 {
+		query += "\tWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += exportReceptionsOrder
+
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.StreamReceptionsForExport", query)
+	defer span.End()
+
+	rows, err := rr.cluster.Replica().QueryContext(ctx, query, args...)
+	if err != nil {
+		recordQueryError(span, err)
+		return dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.ReceptionExportRow
+		var productId, productDateTime, productType sql.NullString
+
+		err = rows.Scan(
+			&row.PVZId,
+			&row.City,
+			&row.ReceptionId,
+			&row.ReceptionDateTime,
+			&row.ReceptionStatus,
+			&productId,
+			&productDateTime,
+			&productType,
+		)
+		if err != nil {
+			return dto.ErrDBRead
+		}
+
+		row.ProductId = productId.String
+		row.ProductDateTime = productDateTime.String
+		row.ProductType = productType.String
+
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return dto.ErrDBRead
+	}
+
+	return nil
+}
+
+func (rr *ReceptionRepository) CloseStaleReceptions(ctx context.Context, maxAge, maxIdle time.Duration) ([]models.Reception, error) {
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.CloseStaleReceptions", closeStaleReceptions)
+	defer span.End()
+
+	rows, err := rr.db.QueryContext(ctx, closeStaleReceptions, maxAge.Seconds(), maxIdle.Seconds())
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBUpdate
+	}
+	defer rows.Close()
+
+	receptions := []models.Reception{}
+
+	for rows.Next() {
+		var reception models.Reception
+		err = rows.Scan(
+			&reception.Id,
+			&reception.DateTime,
+			&reception.PVZId,
+			&reception.Status,
+			&reception.CloseReason,
+		)
+		if err != nil {
+			return nil, dto.ErrDBRead
+		}
+		receptions = append(receptions, reception)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dto.ErrDBUpdate
+	}
+
+	return receptions, nil
+}
+
+// GetOpenReceptionStats возвращает число открытых приёмок и возраст самой
+// старой из них по городам. Запрос идет в реплику: он нужен только метрикам
+func (rr *ReceptionRepository) GetOpenReceptionStats(ctx context.Context) ([]models.OpenReceptionStats, error) {
+	ctx, span := startQuerySpan(ctx, "ReceptionRepository.GetOpenReceptionStats", getOpenReceptionStats)
+	defer span.End()
+
+	rows, err := rr.cluster.Replica().QueryContext(ctx, getOpenReceptionStats)
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	stats := []models.OpenReceptionStats{}
+
+	for rows.Next() {
+		var (
+			stat       models.OpenReceptionStats
+			ageSeconds float64
+		)
+		if err = rows.Scan(&stat.City, &stat.Count, &ageSeconds); err != nil {
+			return nil, dto.ErrDBRead
+		}
+		stat.OldestAge = time.Duration(ageSeconds * float64(time.Second))
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dto.ErrDBRead
+	}
+
+	return stats, nil
+}
@@ -1,12 +1,15 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,7 +17,7 @@ import (
 func TestReceptionRepository_GetLastReception_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 	timeNow := time.Now()
 
 	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, date_time, pvz_id, status FROM receptions WHERE pvz_id = $1 ORDER BY date_time DESC LIMIT 1`)).
@@ -22,7 +25,7 @@ func TestReceptionRepository_GetLastReception_Success(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "pvz_id", "status"}).
 			AddRow("rec1", timeNow, "pvz123", "in_progress"))
 
-	r, err := repo.GetLastReception("pvz123")
+	r, err := repo.GetLastReception(context.Background(), "pvz123")
 	assert.NoError(t, err)
 	assert.Equal(t, "rec1", r.Id)
 	assert.Equal(t, "pvz123", r.PVZId)
@@ -32,28 +35,28 @@ func TestReceptionRepository_GetLastReception_Success(t *testing.T) {
 func TestReceptionRepository_GetLastReception_Error(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 
 	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, date_time, pvz_id, status FROM receptions WHERE pvz_id = $1 ORDER BY date_time DESC LIMIT 1`)).
 		WithArgs("pvz123").
 		WillReturnError(sql.ErrNoRows)
 
-	_, err := repo.GetLastReception("pvz123")
+	_, err := repo.GetLastReception(context.Background(), "pvz123")
 	assert.Error(t, err)
 }
 
 func TestReceptionRepository_CreateReception_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 	timeNow := time.Now()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO receptions (pvz_id) VALUES ($1) RETURNING id, date_time, pvz_id, status`)).
-		WithArgs("pvz1").
+	mock.ExpectQuery(regexp.QuoteMeta(createReception)).
+		WithArgs("pvz1", "employee").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "pvz_id", "status"}).
 			AddRow("rec1", timeNow, "pvz1", "in_progress"))
 
-	r, err := repo.CreateReception("pvz1")
+	r, err := repo.CreateReception(context.Background(), "pvz1", "employee")
 	assert.NoError(t, err)
 	assert.Equal(t, "rec1", r.Id)
 	assert.Equal(t, "pvz1", r.PVZId)
@@ -63,58 +66,127 @@ func TestReceptionRepository_CreateReception_Success(t *testing.T) {
 func TestReceptionRepository_CreateReception_Error(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO receptions (pvz_id) VALUES ($1) RETURNING id, date_time, pvz_id, status`)).
-		WithArgs("pvz1").
+	mock.ExpectQuery(regexp.QuoteMeta(createReception)).
+		WithArgs("pvz1", "employee").
 		WillReturnError(sql.ErrConnDone)
 
-	_, err := repo.CreateReception("pvz1")
+	_, err := repo.CreateReception(context.Background(), "pvz1", "employee")
 	assert.Error(t, err)
 }
 
-func TestReceptionRepository_UpdateReceptionStatus_Success(t *testing.T) {
+func TestReceptionRepository_HasOpenReception(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(hasOpenReception)).
+		WithArgs("pvz1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	open, err := repo.HasOpenReception(context.Background(), "pvz1")
+	assert.NoError(t, err)
+	assert.True(t, open)
+}
+
+func TestReceptionRepository_ChangeReceptionStatus_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 	timeNow := time.Now()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE receptions SET status = $1 WHERE id = $2 RETURNING id, date_time, pvz_id, status`)).
-		WithArgs("close", "rec1").
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE receptions SET status = $1, close_reason = NULL WHERE id = $2 AND status = $3 RETURNING id, date_time, pvz_id, status`)).
+		WithArgs("close", "rec1", "in_progress").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "pvz_id", "status"}).
 			AddRow("rec1", timeNow, "pvz1", "close"))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reception_status_history (reception_id, from_status, to_status, changed_by_role, reason) VALUES ($1, $2, $3, $4, NULLIF($5, ''))`)).
+		WithArgs("rec1", "in_progress", "close", "employee", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	r, err := repo.UpdateReceptionStatus("rec1", "close")
+	r, err := repo.ChangeReceptionStatus(context.Background(), "rec1", "in_progress", "close", "employee", "")
 	assert.NoError(t, err)
 	assert.Equal(t, "close", r.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceptionRepository_ChangeReceptionStatus_Conflict(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE receptions SET status = $1, close_reason = NULL WHERE id = $2 AND status = $3 RETURNING id, date_time, pvz_id, status`)).
+		WithArgs("close", "rec1", "in_progress").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := repo.ChangeReceptionStatus(context.Background(), "rec1", "in_progress", "close", "employee", "")
+	assert.ErrorIs(t, err, dto.ErrInvalidStatusChange)
 }
 
-func TestReceptionRepository_UpdateReceptionStatus_Error(t *testing.T) {
+func TestReceptionRepository_ChangeReceptionStatus_Error(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE receptions SET status = $1 WHERE id = $2 RETURNING id, date_time, pvz_id, status`)).
-		WithArgs("close", "rec1").
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE receptions SET status = $1, close_reason = NULL WHERE id = $2 AND status = $3 RETURNING id, date_time, pvz_id, status`)).
+		WithArgs("close", "rec1", "in_progress").
 		WillReturnError(sql.ErrTxDone)
+	mock.ExpectRollback()
 
-	_, err := repo.UpdateReceptionStatus("rec1", "close")
+	_, err := repo.ChangeReceptionStatus(context.Background(), "rec1", "in_progress", "close", "employee", "")
 	assert.Error(t, err)
 }
 
+func TestReceptionRepository_GetReceptionById_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, date_time, pvz_id, status FROM receptions WHERE id = $1`)).
+		WithArgs("rec404").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.GetReceptionById(context.Background(), "rec404")
+	assert.ErrorIs(t, err, dto.ErrReceptionNotFound)
+}
+
+func TestReceptionRepository_GetStatusHistory_Success(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+	timeNow := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM reception_status_history WHERE reception_id = $1 ORDER BY changed_at`)).
+		WithArgs("rec1").
+		WillReturnRows(sqlmock.NewRows([]string{"reception_id", "from_status", "to_status", "changed_at", "changed_by_role", "reason"}).
+			AddRow("rec1", "in_progress", "paused", timeNow, "employee", "").
+			AddRow("rec1", "paused", "close", timeNow, "system", "auto_idle"))
+
+	history, err := repo.GetStatusHistory(context.Background(), "rec1")
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "paused", history[0].ToStatus)
+	assert.Equal(t, "auto_idle", history[1].Reason)
+}
+
 func TestReceptionRepository_GetReceptionsByPVZIds_NoFilter(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 	timeNow := time.Now()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, date_time, pvz_id, status FROM receptions WHERE pvz_id = ANY($1)`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.id, r.date_time, r.pvz_id, r.status FROM receptions r WHERE r.pvz_id = ANY($1)`)).
 		WithArgs(sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "pvz_id", "status"}).
 			AddRow("r1", timeNow, "pvz123", "in_progress").
 			AddRow("r2", timeNow, "pvz456", "in_progress"))
 
-	rs, err := repo.GetReceptionsByPVZIds([]string{"pvz123", "pvz456"}, nil, nil)
+	rs, err := repo.GetReceptionsByPVZIds(context.Background(), []string{"pvz123", "pvz456"}, models.PVZFilter{})
 	assert.NoError(t, err)
 	assert.Len(t, rs, 2)
 	assert.Equal(t, "r1", rs[0].Id)
@@ -124,26 +196,17 @@ func TestReceptionRepository_GetReceptionsByPVZIds_NoFilter(t *testing.T) {
 func TestReceptionRepository_GetReceptionsByPVZIds_WithFilter(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 	start := time.Now().Add(-24 * time.Hour)
 	end := time.Now()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`
-	SELECT r.id, r.date_time, r.pvz_id, r.status 
-	FROM receptions r
-	WHERE r.pvz_id = ANY($1) 
-	AND EXISTS (
-		SELECT 1 FROM products p 
-		WHERE p.reception_id = r.id 
-		AND p.date_time BETWEEN $2 AND $3
-	)
-`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.id, r.date_time, r.pvz_id, r.status FROM receptions r WHERE r.pvz_id = ANY($1) AND EXISTS (SELECT 1 FROM products p WHERE p.reception_id = r.id AND p.date_time BETWEEN $2 AND $3)`)).
 		WithArgs(sqlmock.AnyArg(), start, end).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "pvz_id", "status"}).
 			AddRow("r1", start, "pvz123", "close").
 			AddRow("r2", start, "pvz456", "close"))
 
-	rs, err := repo.GetReceptionsByPVZIds([]string{"pvz123", "pvz456"}, &start, &end)
+	rs, err := repo.GetReceptionsByPVZIds(context.Background(), []string{"pvz123", "pvz456"}, models.PVZFilter{StartDate: &start, EndDate: &end})
 	assert.NoError(t, err)
 	assert.Len(t, rs, 2)
 	assert.Equal(t, "r1", rs[0].Id)
@@ -154,23 +217,150 @@ func TestReceptionRepository_GetReceptionsByPVZIds_WithFilter(t *testing.T) {
 func TestReceptionRepository_GetReceptionsByPVZIds_QueryError(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	sqlxDB := sqlx.NewDb(db, "postgres")
-	repo := NewReceptionRepository(sqlxDB)
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
 	start := time.Now()
 	end := time.Now()
 
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.id, r.date_time, r.pvz_id, r.status FROM receptions r WHERE r.pvz_id = ANY($1) AND EXISTS (SELECT 1 FROM products p WHERE p.reception_id = r.id AND p.date_time BETWEEN $2 AND $3)`)).
+		WithArgs(sqlmock.AnyArg(), start, end).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.GetReceptionsByPVZIds(context.Background(), []string{"pvz123", "pvz456"}, models.PVZFilter{StartDate: &start, EndDate: &end})
+	assert.Error(t, err)
+}
+
+func TestReceptionRepository_StreamReceptionsForExport_WithFilter(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+	start := time.Now().Add(-24 * time.Hour)
+	end := time.Now()
+
 	mock.ExpectQuery(regexp.QuoteMeta(`
-	SELECT r.id, r.date_time, r.pvz_id, r.status 
+	SELECT pv.id, pv.city, r.id, r.date_time, r.status, p.id, p.date_time, p.product_type
 	FROM receptions r
-	WHERE r.pvz_id = ANY($1) 
-	AND EXISTS (
-		SELECT 1 FROM products p 
-		WHERE p.reception_id = r.id 
-		AND p.date_time BETWEEN $2 AND $3
-	)
+	JOIN pvzs pv ON pv.id = r.pvz_id
+	LEFT JOIN products p ON p.reception_id = r.id
+	WHERE p.date_time BETWEEN $1 AND $2 AND pv.city = ANY($3)
+	ORDER BY pv.id, r.date_time, p.date_time
 `)).
-		WithArgs(sqlmock.AnyArg(), start, end).
+		WithArgs(start, end, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "city", "id", "date_time", "status", "id", "date_time", "product_type"}).
+			AddRow("pvz1", "Москва", "r1", start, "close", "p1", start, "обувь").
+			AddRow("pvz1", "Москва", "r2", start, "in_progress", nil, nil, nil))
+
+	var rows []models.ReceptionExportRow
+	err := repo.StreamReceptionsForExport(context.Background(), models.PVZFilter{
+		StartDate: &start,
+		EndDate:   &end,
+		Cities:    []string{"Москва"},
+	}, func(row models.ReceptionExportRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "p1", rows[0].ProductId)
+	assert.Equal(t, "", rows[1].ProductId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceptionRepository_StreamReceptionsForExport_QueryError(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM receptions r`)).
+		WillReturnError(sql.ErrConnDone)
+
+	err := repo.StreamReceptionsForExport(context.Background(), models.PVZFilter{}, func(models.ReceptionExportRow) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+func TestReceptionRepository_CloseStaleReceptions_Success(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+	timeNow := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WITH closed AS`)).
+		WithArgs(float64(86400), float64(3600)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "pvz_id", "status", "close_reason"}).
+			AddRow("r1", timeNow, "pvz1", "close", "auto_max_age").
+			AddRow("r2", timeNow, "pvz2", "close", "auto_idle"))
+
+	rs, err := repo.CloseStaleReceptions(context.Background(), 24*time.Hour, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, "auto_max_age", rs[0].CloseReason)
+	assert.Equal(t, "auto_idle", rs[1].CloseReason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceptionRepository_CloseStaleReceptions_Error(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WITH closed AS`)).
 		WillReturnError(sql.ErrConnDone)
 
-	_, err := repo.GetReceptionsByPVZIds([]string{"pvz123", "pvz456"}, &start, &end)
+	_, err := repo.CloseStaleReceptions(context.Background(), time.Hour, 0)
 	assert.Error(t, err)
 }
+
+func TestReceptionRepository_GetReceptionsByPVZ_WithFilter(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+	start := time.Now().Add(-24 * time.Hour)
+	end := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, date_time, pvz_id, status FROM receptions WHERE pvz_id = $1 AND status = ANY($2) AND date_time >= $3 AND date_time <= $4 ORDER BY date_time DESC, id DESC LIMIT $5 OFFSET $6`)).
+		WithArgs("pvz123", sqlmock.AnyArg(), start, end, 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "pvz_id", "status"}).
+			AddRow("r1", end, "pvz123", "close"))
+
+	rs, err := repo.GetReceptionsByPVZ(context.Background(), "pvz123", models.ReceptionListFilter{
+		Statuses:  []string{"close"},
+		StartDate: &start,
+		EndDate:   &end,
+	}, 20, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceptionRepository_GetReceptionsByPVZ_QueryError(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM receptions WHERE pvz_id = $1`)).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.GetReceptionsByPVZ(context.Background(), "pvz123", models.ReceptionListFilter{}, 0, 10)
+	assert.ErrorIs(t, err, dto.ErrDBRead)
+}
+
+func TestReceptionRepository_GetOpenReceptionStats(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewReceptionRepository(newTestCluster(sqlxDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT pv.city, COUNT(*)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"city", "count", "age"}).
+			AddRow("Москва", 3, 5400.5).
+			AddRow("Казань", 1, 60.0))
+
+	stats, err := repo.GetOpenReceptionStats(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.OpenReceptionStats{
+		{City: "Москва", Count: 3, OldestAge: 90*time.Minute + 500*time.Millisecond},
+		{City: "Казань", Count: 1, OldestAge: time.Minute},
+	}, stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
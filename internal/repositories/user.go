@@ -1,8 +1,10 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
@@ -14,8 +16,17 @@ type UserRepository struct {
 }
 
 const (
-	createUser     = "INSERT INTO users (email, password_hash, role) VALUES ($1, $2, $3) RETURNING id, email, password_hash, role"
-	getUserByEmail = "SELECT id, email, password_hash, role FROM users WHERE email = $1"
+	userColumns    = "id, email, password_hash, role, created_at, disabled_at"
+	createUser     = "INSERT INTO users (email, password_hash, role) VALUES ($1, $2, $3) RETURNING " + userColumns
+	getUserByEmail = "SELECT " + userColumns + " FROM users WHERE lower(email) = lower($1)"
+	getUserById    = "SELECT " + userColumns + " FROM users WHERE id = $1"
+	updatePassword = "UPDATE users SET password_hash = $2 WHERE id = $1"
+	// Пустая роль и NULL вместо признака отключения не ограничивают выборку
+	listUsers = "SELECT " + userColumns + ", COUNT(*) OVER() FROM users " +
+		"WHERE ($1 = '' OR role = $1) AND ($2::boolean IS NULL OR (disabled_at IS NOT NULL) = $2) " +
+		"ORDER BY created_at, id LIMIT $3 OFFSET $4"
+	setUserDisabledAt = "UPDATE users SET disabled_at = $2 WHERE id = $1 RETURNING " + userColumns
+	setUserRole       = "UPDATE users SET role = $2 WHERE id = $1 RETURNING " + userColumns
 )
 
 func NewUserRepository(db *sqlx.DB) *UserRepository {
@@ -24,33 +35,45 @@ func NewUserRepository(db *sqlx.DB) *UserRepository {
 	}
 }
 
-func (ur *UserRepository) UserRegister(email, password, role string) (models.User, error) {
+func (ur *UserRepository) UserRegister(ctx context.Context, email, password, role string) (models.User, error) {
+	ctx, span := startQuerySpan(ctx, "UserRepository.UserRegister", createUser)
+	defer span.End()
+
 	var user models.User
-	err := ur.db.QueryRow(createUser, email, password, role).
+	err := ur.db.QueryRowContext(ctx, createUser, email, password, role).
 		Scan(
 			&user.Id,
 			&user.Email,
 			&user.Password,
 			&user.Role,
+			&user.CreatedAt,
+			&user.DisabledAt,
 		)
 	if err != nil {
+		recordQueryError(span, err)
 		return models.User{}, dto.ErrDBInsert
 	}
 
 	return user, nil
 }
 
-func (ur *UserRepository) UserLogin(email, password string) (models.User, error) {
+func (ur *UserRepository) UserLogin(ctx context.Context, email, password string) (models.User, error) {
+	ctx, span := startQuerySpan(ctx, "UserRepository.UserLogin", getUserByEmail)
+	defer span.End()
+
 	var user models.User
 
-	err := ur.db.QueryRow(getUserByEmail, email).
+	err := ur.db.QueryRowContext(ctx, getUserByEmail, email).
 		Scan(
 			&user.Id,
 			&user.Email,
 			&user.Password,
 			&user.Role,
+			&user.CreatedAt,
+			&user.DisabledAt,
 		)
 	if err != nil {
+		recordQueryError(span, err)
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.User{}, dto.ErrInvalidCredentials
 		}
@@ -59,3 +82,129 @@ func (ur *UserRepository) UserLogin(email, password string) (models.User, error)
 
 	return user, nil
 }
+
+func (ur *UserRepository) GetUserById(ctx context.Context, userId string) (models.User, error) {
+	ctx, span := startQuerySpan(ctx, "UserRepository.GetUserById", getUserById)
+	defer span.End()
+
+	var user models.User
+
+	err := ur.db.QueryRowContext(ctx, getUserById, userId).
+		Scan(
+			&user.Id,
+			&user.Email,
+			&user.Password,
+			&user.Role,
+			&user.CreatedAt,
+			&user.DisabledAt,
+		)
+	if err != nil {
+		recordQueryError(span, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, dto.ErrUserNotFound
+		}
+		return models.User{}, dto.ErrDBRead
+	}
+
+	return user, nil
+}
+
+func (ur *UserRepository) UpdatePassword(ctx context.Context, userId, password string) error {
+	ctx, span := startQuerySpan(ctx, "UserRepository.UpdatePassword", updatePassword)
+	defer span.End()
+
+	res, err := ur.db.ExecContext(ctx, updatePassword, userId, password)
+	if err != nil {
+		recordQueryError(span, err)
+		return dto.ErrDBUpdate
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return dto.ErrDBUpdate
+	}
+	if updated == 0 {
+		return dto.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ListUsers возвращает страницу пользователей и общее число подходящих под фильтр
+func (ur *UserRepository) ListUsers(
+	ctx context.Context,
+	filter models.UserListFilter,
+	page, limit int,
+) ([]models.User, int, error) {
+	ctx, span := startQuerySpan(ctx, "UserRepository.ListUsers", listUsers)
+	defer span.End()
+
+	rows, err := ur.db.QueryContext(ctx, listUsers, filter.Role, filter.Disabled, limit, (page-1)*limit)
+	if err != nil {
+		recordQueryError(span, err)
+		return nil, 0, dto.ErrDBRead
+	}
+	defer rows.Close()
+
+	var total int
+	users := make([]models.User, 0, limit)
+	for rows.Next() {
+		var user models.User
+		err = rows.Scan(
+			&user.Id,
+			&user.Email,
+			&user.Password,
+			&user.Role,
+			&user.CreatedAt,
+			&user.DisabledAt,
+			&total,
+		)
+		if err != nil {
+			recordQueryError(span, err)
+			return nil, 0, dto.ErrDBRead
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		recordQueryError(span, err)
+		return nil, 0, dto.ErrDBRead
+	}
+
+	return users, total, nil
+}
+
+// SetUserDisabledAt отключает пользователя с момента disabledAt, а nil
+// включает его обратно
+func (ur *UserRepository) SetUserDisabledAt(ctx context.Context, userId string, disabledAt *time.Time) (models.User, error) {
+	return ur.updateUser(ctx, "UserRepository.SetUserDisabledAt", setUserDisabledAt, userId, disabledAt)
+}
+
+func (ur *UserRepository) SetUserRole(ctx context.Context, userId, role string) (models.User, error) {
+	return ur.updateUser(ctx, "UserRepository.SetUserRole", setUserRole, userId, role)
+}
+
+func (ur *UserRepository) updateUser(ctx context.Context, spanName, query, userId string, value any) (models.User, error) {
+	ctx, span := startQuerySpan(ctx, spanName, query)
+	defer span.End()
+
+	var user models.User
+	err := ur.db.QueryRowContext(ctx, query, userId, value).
+		Scan(
+			&user.Id,
+			&user.Email,
+			&user.Password,
+			&user.Role,
+			&user.CreatedAt,
+			&user.DisabledAt,
+		)
+	if err != nil {
+		recordQueryError(span, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, dto.ErrUserNotFound
+		}
+		return models.User{}, dto.ErrDBUpdate
+	}
+
+	return user, nil
+}
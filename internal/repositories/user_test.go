@@ -1,13 +1,23 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	userRowColumns = []string{"id", "email", "password_hash", "role", "created_at", "disabled_at"}
+	createdAt      = time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
 )
 
 func TestUserRepository_UserRegister_Success(t *testing.T) {
@@ -15,12 +25,12 @@ func TestUserRepository_UserRegister_Success(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "postgres")
 	repo := NewUserRepository(sqlxDB)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO users (email, password_hash, role) VALUES ($1, $2, $3) RETURNING id, email, password_hash, role`)).
+	mock.ExpectQuery(regexp.QuoteMeta(createUser)).
 		WithArgs("test@example.com", "hashedpass", "employee").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role"}).
-			AddRow("u123", "test@example.com", "hashedpass", "employee"))
+		WillReturnRows(sqlmock.NewRows(userRowColumns).
+			AddRow("u123", "test@example.com", "hashedpass", "employee", createdAt, nil))
 
-	user, err := repo.UserRegister("test@example.com", "hashedpass", "employee")
+	user, err := repo.UserRegister(context.Background(), "test@example.com", "hashedpass", "employee")
 	assert.NoError(t, err)
 	assert.Equal(t, "u123", user.Id)
 	assert.Equal(t, "test@example.com", user.Email)
@@ -32,11 +42,11 @@ func TestUserRepository_UserRegister_Error(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "postgres")
 	repo := NewUserRepository(sqlxDB)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO users (email, password_hash, role) VALUES ($1, $2, $3) RETURNING id, email, password_hash, role`)).
+	mock.ExpectQuery(regexp.QuoteMeta(createUser)).
 		WithArgs("test@example.com", "pass", "employee").
 		WillReturnError(sql.ErrConnDone)
 
-	_, err := repo.UserRegister("test@example.com", "pass", "employee")
+	_, err := repo.UserRegister(context.Background(), "test@example.com", "pass", "employee")
 	assert.Error(t, err)
 }
 
@@ -45,12 +55,12 @@ func TestUserRepository_UserLogin_Success(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "postgres")
 	repo := NewUserRepository(sqlxDB)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, email, password_hash, role FROM users WHERE email = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(getUserByEmail)).
 		WithArgs("login@example.com").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role"}).
-			AddRow("uid123", "login@example.com", "hashed", "moderator"))
+		WillReturnRows(sqlmock.NewRows(userRowColumns).
+			AddRow("uid123", "login@example.com", "hashed", "moderator", createdAt, nil))
 
-	user, err := repo.UserLogin("login@example.com", "any")
+	user, err := repo.UserLogin(context.Background(), "login@example.com", "any")
 	assert.NoError(t, err)
 	assert.Equal(t, "uid123", user.Id)
 	assert.Equal(t, "moderator", user.Role)
@@ -61,11 +71,11 @@ func TestUserRepository_UserLogin_NotFound(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "postgres")
 	repo := NewUserRepository(sqlxDB)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, email, password_hash, role FROM users WHERE email = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(getUserByEmail)).
 		WithArgs("nouser@example.com").
 		WillReturnError(sql.ErrNoRows)
 
-	_, err := repo.UserLogin("nouser@example.com", "pass")
+	_, err := repo.UserLogin(context.Background(), "nouser@example.com", "pass")
 	assert.Error(t, err)
 }
 
@@ -74,10 +84,115 @@ func TestUserRepository_UserLogin_DBError(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "postgres")
 	repo := NewUserRepository(sqlxDB)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, email, password_hash, role FROM users WHERE email = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(getUserByEmail)).
 		WithArgs("user@example.com").
 		WillReturnError(sql.ErrConnDone)
 
-	_, err := repo.UserLogin("user@example.com", "pass")
+	_, err := repo.UserLogin(context.Background(), "user@example.com", "pass")
 	assert.Error(t, err)
 }
+
+func TestUserRepository_GetUserById(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewUserRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getUserById)).
+		WithArgs("u123").
+		WillReturnRows(sqlmock.NewRows(userRowColumns).
+			AddRow("u123", "test@example.com", "hash", "employee", createdAt, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(getUserById)).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	user, err := repo.GetUserById(context.Background(), "u123")
+	assert.NoError(t, err)
+	assert.Equal(t, "test@example.com", user.Email)
+
+	_, err = repo.GetUserById(context.Background(), "missing")
+	assert.ErrorIs(t, err, dto.ErrUserNotFound)
+}
+
+func TestUserRepository_UpdatePassword(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	repo := NewUserRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET password_hash = $2 WHERE id = $1`)).
+		WithArgs("u123", "newhash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET password_hash = $2 WHERE id = $1`)).
+		WithArgs("missing", "newhash").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.UpdatePassword(context.Background(), "u123", "newhash"))
+	assert.ErrorIs(t, repo.UpdatePassword(context.Background(), "missing", "newhash"), dto.ErrUserNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_ListUsers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewUserRepository(sqlx.NewDb(db, "postgres"))
+
+	disabledAt := createdAt.Add(time.Hour)
+	disabled := true
+
+	mock.ExpectQuery(regexp.QuoteMeta(listUsers)).
+		WithArgs(dto.RoleEmployee, &disabled, 10, 10).
+		WillReturnRows(sqlmock.NewRows(append(userRowColumns, "count")).
+			AddRow("u1", "a@example.com", "hash", dto.RoleEmployee, createdAt, disabledAt, 11))
+
+	users, total, err := repo.ListUsers(context.Background(),
+		models.UserListFilter{Role: dto.RoleEmployee, Disabled: &disabled}, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, 11, total)
+	assert.True(t, users[0].Disabled())
+	assert.Equal(t, disabledAt, *users[0].DisabledAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_SetUserDisabledAt(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewUserRepository(sqlx.NewDb(db, "postgres"))
+
+	disabledAt := createdAt.Add(time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(setUserDisabledAt)).
+		WithArgs("u1", &disabledAt).
+		WillReturnRows(sqlmock.NewRows(userRowColumns).
+			AddRow("u1", "a@example.com", "hash", dto.RoleEmployee, createdAt, disabledAt))
+	mock.ExpectQuery(regexp.QuoteMeta(setUserDisabledAt)).
+		WithArgs("missing", nil).
+		WillReturnError(sql.ErrNoRows)
+
+	user, err := repo.SetUserDisabledAt(context.Background(), "u1", &disabledAt)
+	require.NoError(t, err)
+	assert.True(t, user.Disabled())
+
+	_, err = repo.SetUserDisabledAt(context.Background(), "missing", nil)
+	assert.ErrorIs(t, err, dto.ErrUserNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_SetUserRole(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := NewUserRepository(sqlx.NewDb(db, "postgres"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(setUserRole)).
+		WithArgs("u1", dto.RoleModerator).
+		WillReturnRows(sqlmock.NewRows(userRowColumns).
+			AddRow("u1", "a@example.com", "hash", dto.RoleModerator, createdAt, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(setUserRole)).
+		WithArgs("u1", dto.RoleModerator).
+		WillReturnError(sql.ErrConnDone)
+
+	user, err := repo.SetUserRole(context.Background(), "u1", dto.RoleModerator)
+	require.NoError(t, err)
+	assert.Equal(t, dto.RoleModerator, user.Role)
+	assert.False(t, user.Disabled())
+
+	_, err = repo.SetUserRole(context.Background(), "u1", dto.RoleModerator)
+	assert.ErrorIs(t, err, dto.ErrDBUpdate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
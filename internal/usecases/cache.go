@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/hamillka/avitoTechSpring25/internal/cache"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"go.uber.org/zap"
+)
+
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Generation(ctx context.Context) (cache.Generation, error)
+	Invalidate(ctx context.Context) error
+}
+
+const (
+	pvzListCacheKey  = "list:"
+	pvzCountCacheKey = "count:"
+	pvzAllCacheKey   = "all"
+)
+
+// cacheKey строит ключ из параметров запроса: одинаковые фильтр, страница
+// и детализация всегда дают один и тот же ключ
+func cacheKey(prefix string, params any) string {
+	raw, _ := json.Marshal(params)
+	sum := sha256.Sum256(raw)
+	return prefix + hex.EncodeToString(sum[:])
+}
+
+// readThrough отдает значение из кеша, а при промахе или недоступности кеша
+// загружает его через load и сохраняет в кеш. Поколение читается до загрузки:
+// если кеш сбросят, пока идет загрузка, результат уйдет в старое поколение и
+// не будет прочитан
+func readThrough[T any](ctx context.Context, c Cache, key string, load func() (T, error)) (T, error) {
+	gen, err := c.Generation(ctx)
+	if err != nil {
+		logger.FromContext(ctx, zap.S()).Warnf("failed to read pvz cache generation: %v", err)
+		return load()
+	}
+	key = gen.Key(key)
+
+	raw, ok, err := c.Get(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx, zap.S()).Warnf("failed to read pvz cache: %v", err)
+	}
+	if err == nil && ok {
+		var value T
+		if err = json.Unmarshal(raw, &value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	// сразу после сброса реплика могла еще не получить запись, и прочитанное
+	// с нее попало бы в новое поколение устаревшим
+	if !gen.Cacheable {
+		return value, nil
+	}
+
+	if raw, err = json.Marshal(value); err == nil {
+		if err = c.Set(ctx, key, raw); err != nil {
+			logger.FromContext(ctx, zap.S()).Warnf("failed to write pvz cache: %v", err)
+		}
+	}
+
+	return value, nil
+}
+
+// invalidatePVZCache сбрасывает кеш списков ПВЗ после записи. Ошибка сброса
+// не должна ломать уже выполненную запись: в худшем случае устаревшие данные
+// проживут до истечения TTL, поэтому ошибка только логируется
+func invalidatePVZCache(ctx context.Context, c Cache) {
+	if err := c.Invalidate(context.WithoutCancel(ctx)); err != nil {
+		logger.FromContext(ctx, zap.S()).Warnf("failed to invalidate pvz cache: %v", err)
+	}
+}
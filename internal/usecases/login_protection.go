@@ -0,0 +1,238 @@
+//go:generate mockgen -source=login_protection.go -destination=./mocks/mock_login_protection.go -package=mocks
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// LoginProtectionConfig описывает защиту входа от перебора паролей. Неудачи
+// считаются отдельно по учетной записи и по IP адресу: после каждой следующая
+// попытка разрешена только через задержку, которая удваивается от BaseDelay
+// до MaxDelay, а после MaxAccountFailures (MaxIPFailures) неудач подряд вход
+// блокируется на Lockout. Счетчик сбрасывается после Window без неудач
+type LoginProtectionConfig struct {
+	Enabled            bool          `default:"true" envconfig:"ENABLED"`
+	MaxAccountFailures int           `default:"5"    envconfig:"MAX_ACCOUNT_FAILURES"`
+	MaxIPFailures      int           `default:"20"   envconfig:"MAX_IP_FAILURES"`
+	Window             time.Duration `default:"15m"  envconfig:"WINDOW"`
+	Lockout            time.Duration `default:"15m"  envconfig:"LOCKOUT"`
+	BaseDelay          time.Duration `default:"1s"   envconfig:"BASE_DELAY"`
+	MaxDelay           time.Duration `default:"30s"  envconfig:"MAX_DELAY"`
+}
+
+type LoginFailureRepository interface {
+	GetLoginFailures(ctx context.Context, keys []string) ([]models.LoginFailure, error)
+	RecordLoginFailure(ctx context.Context, key string, now time.Time, window time.Duration) (int, error)
+	BlockLogin(ctx context.Context, key string, until time.Time) error
+	ResetLoginFailures(ctx context.Context, key string) error
+	DeleteStaleLoginFailures(ctx context.Context, before, now time.Time) (int64, error)
+}
+
+const (
+	loginSubjectAccount = "account"
+	loginSubjectIP      = "ip"
+)
+
+// loginSubject — учетная запись или IP адрес, по которому считаются неудачи
+type loginSubject struct {
+	kind        string
+	key         string
+	maxFailures int
+}
+
+// accountLoginKey не зависит от регистра почты, как и уникальность почты в
+// схеме: Admin@mail.ru и admin@mail.ru — одна учетная запись и один счетчик
+func accountLoginKey(email string) string {
+	return loginSubjectAccount + ":" + normalizeEmail(email)
+}
+
+func ipLoginKey(ip string) string {
+	return loginSubjectIP + ":" + ip
+}
+
+func (us *UserService) loginSubjects(email, ip string) []loginSubject {
+	subjects := []loginSubject{{
+		kind:        loginSubjectAccount,
+		key:         accountLoginKey(email),
+		maxFailures: us.loginCfg.MaxAccountFailures,
+	}}
+	if ip != "" {
+		subjects = append(subjects, loginSubject{
+			kind:        loginSubjectIP,
+			key:         ipLoginKey(ip),
+			maxFailures: us.loginCfg.MaxIPFailures,
+		})
+	}
+
+	return subjects
+}
+
+// loginDelay — задержка перед следующей попыткой после failures неудач подряд
+func (us *UserService) loginDelay(failures int) time.Duration {
+	delay := us.loginCfg.BaseDelay
+	if delay <= 0 || failures <= 0 {
+		return 0
+	}
+
+	for i := 1; i < failures && delay < us.loginCfg.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if us.loginCfg.MaxDelay > 0 && delay > us.loginCfg.MaxDelay {
+		return us.loginCfg.MaxDelay
+	}
+	return delay
+}
+
+// checkLoginBlocked отклоняет попытку, если учетная запись или IP адрес еще
+// заблокированы. Пароль при этом не проверяется, поэтому перебор во время
+// блокировки ничего не дает
+func (us *UserService) checkLoginBlocked(ctx context.Context, subjects []loginSubject, email, ip string) error {
+	keys := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		keys = append(keys, subject.key)
+	}
+
+	failures, err := us.failureRepo.GetLoginFailures(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	now := us.now()
+	var blocked *dto.LoginBlockedError
+	for _, failure := range failures {
+		if !failure.BlockedUntil.After(now) {
+			continue
+		}
+
+		err := dto.ErrLoginThrottled
+		for _, subject := range subjects {
+			if subject.key == failure.Key && subject.kind == loginSubjectAccount && failure.Failures >= subject.maxFailures {
+				err = dto.ErrAccountLocked
+			}
+		}
+
+		retryAfter := failure.BlockedUntil.Sub(now)
+		if blocked == nil || retryAfter > blocked.RetryAfter {
+			blocked = &dto.LoginBlockedError{Err: err, RetryAfter: retryAfter}
+		}
+	}
+
+	if blocked == nil {
+		return nil
+	}
+
+	securityLogger(ctx).Warnw("login rejected",
+		"event", "login_blocked",
+		"email", email,
+		"ip", ip,
+		"reason", blocked.Err.Error(),
+		"retry_after", blocked.RetryAfter,
+	)
+	return blocked
+}
+
+// recordLoginFailure увеличивает счетчики неудач и выставляет задержку или
+// блокировку. Ошибки записи только логируются: клиент все равно получает
+// ответ о неверных учетных данных
+func (us *UserService) recordLoginFailure(ctx context.Context, subjects []loginSubject, email, ip string) {
+	now := us.now()
+	fields := []any{"event", "login_failed", "email", email, "ip", ip}
+
+	for _, subject := range subjects {
+		failures, err := us.failureRepo.RecordLoginFailure(ctx, subject.key, now, us.loginCfg.Window)
+		if err != nil {
+			logger.FromContext(ctx, zap.S()).Warnf("failed to record login failure for %s: %v", subject.key, err)
+			continue
+		}
+		fields = append(fields, subject.kind+"_failures", failures)
+
+		block := us.loginDelay(failures)
+		if subject.maxFailures > 0 && failures >= subject.maxFailures {
+			block = us.loginCfg.Lockout
+			securityLogger(ctx).Warnw("login locked",
+				"event", "login_locked",
+				"key", subject.key,
+				"failures", failures,
+				"lockout", block,
+			)
+		}
+
+		if block <= 0 {
+			continue
+		}
+		if err = us.failureRepo.BlockLogin(ctx, subject.key, now.Add(block)); err != nil {
+			logger.FromContext(ctx, zap.S()).Warnf("failed to block login for %s: %v", subject.key, err)
+		}
+	}
+
+	securityLogger(ctx).Warnw("login failed", fields...)
+}
+
+func isCredentialsError(err error) bool {
+	return errors.Is(err, dto.ErrInvalidCredentials) || errors.Is(err, bcrypt.ErrMismatchedHashAndPassword)
+}
+
+// UnlockUser снимает блокировку входа с учетной записи и обнуляет ее счетчик
+// неудач. Блокировки по IP адресу истекают сами
+func (us *UserService) UnlockUser(ctx context.Context, userId string) (models.User, error) {
+	user, err := us.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	err = us.failureRepo.ResetLoginFailures(ctx, accountLoginKey(user.Email))
+	if err != nil {
+		return models.User{}, err
+	}
+
+	securityLogger(ctx).Infow("account unlocked",
+		"event", "account_unlocked",
+		"user_id", user.Id,
+		"email", user.Email,
+	)
+
+	return user, nil
+}
+
+// RunLoginFailuresCleanup раз в Window удаляет истекшие счетчики неудач
+func (us *UserService) RunLoginFailuresCleanup(ctx context.Context) {
+	if !us.loginCfg.Enabled || us.loginCfg.Window <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(us.loginCfg.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tickCtx, span := otel.Tracer(tracerName).Start(ctx, "UserService.CleanupLoginFailures")
+			now := us.now()
+			deleted, err := us.failureRepo.DeleteStaleLoginFailures(tickCtx, now.Add(-us.loginCfg.Window), now)
+			if err != nil {
+				logger.FromContext(tickCtx, zap.S()).Errorf("failed to delete stale login failures: %v", err)
+			} else if deleted > 0 {
+				logger.FromContext(tickCtx, zap.S()).Debugf("deleted %d stale login failures", deleted)
+			}
+			span.End()
+		}
+	}
+}
+
+// securityLogger пишет события безопасности в отдельный именованный логгер,
+// чтобы их можно было отфильтровать по полю logger=security
+func securityLogger(ctx context.Context) *zap.SugaredLogger {
+	return logger.FromContext(ctx, zap.S()).Named("security")
+}
@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/hamillka/avitoTechSpring25/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testLoginCfg = LoginProtectionConfig{
+	Enabled:            true,
+	MaxAccountFailures: 3,
+	MaxIPFailures:      10,
+	Window:             15 * time.Minute,
+	Lockout:            15 * time.Minute,
+	BaseDelay:          time.Second,
+	MaxDelay:           4 * time.Second,
+}
+
+func newProtectedUserService(ctrl *gomock.Controller) (*UserService, *mocks.MockUserRepository, *mocks.MockLoginFailureRepository, time.Time) {
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	failureRepo := mocks.NewMockLoginFailureRepository(ctrl)
+	service := NewUserService(userRepo, failureRepo, nil, testPolicy, nil, testLoginCfg, PasswordResetConfig{}, RegistrationConfig{})
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	return service, userRepo, failureRepo, now
+}
+
+func TestLoginDelay(t *testing.T) {
+	service := NewUserService(nil, nil, nil, testPolicy, nil, testLoginCfg, PasswordResetConfig{}, RegistrationConfig{})
+
+	assert.Equal(t, time.Duration(0), service.loginDelay(0))
+	assert.Equal(t, time.Second, service.loginDelay(1))
+	assert.Equal(t, 2*time.Second, service.loginDelay(2))
+	assert.Equal(t, 4*time.Second, service.loginDelay(3))
+	assert.Equal(t, 4*time.Second, service.loginDelay(30))
+}
+
+func TestUserLogin_WrongPasswordRecordsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, userRepo, failureRepo, now := newProtectedUserService(ctrl)
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("correctpass"), bcrypt.MinCost)
+
+	failureRepo.EXPECT().GetLoginFailures(gomock.Any(), []string{"account:test@example.com", "ip:10.0.0.1"}).
+		Return(nil, nil)
+	userRepo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "wrongpass").
+		Return(models.User{Id: "u1", Password: string(hashed)}, nil)
+
+	failureRepo.EXPECT().RecordLoginFailure(gomock.Any(), "account:test@example.com", now, testLoginCfg.Window).Return(2, nil)
+	failureRepo.EXPECT().BlockLogin(gomock.Any(), "account:test@example.com", now.Add(2*time.Second)).Return(nil)
+	failureRepo.EXPECT().RecordLoginFailure(gomock.Any(), "ip:10.0.0.1", now, testLoginCfg.Window).Return(1, nil)
+	failureRepo.EXPECT().BlockLogin(gomock.Any(), "ip:10.0.0.1", now.Add(time.Second)).Return(nil)
+
+	_, err := service.UserLogin(context.Background(), "Test@example.com", "wrongpass", "10.0.0.1")
+	assert.ErrorIs(t, err, bcrypt.ErrMismatchedHashAndPassword)
+}
+
+func TestUserLogin_LockoutAfterMaxFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, userRepo, failureRepo, now := newProtectedUserService(ctrl)
+
+	failureRepo.EXPECT().GetLoginFailures(gomock.Any(), gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "pass").
+		Return(models.User{}, dto.ErrInvalidCredentials)
+
+	failureRepo.EXPECT().RecordLoginFailure(gomock.Any(), "account:test@example.com", now, testLoginCfg.Window).Return(3, nil)
+	failureRepo.EXPECT().BlockLogin(gomock.Any(), "account:test@example.com", now.Add(testLoginCfg.Lockout)).Return(nil)
+
+	_, err := service.UserLogin(context.Background(), "test@example.com", "pass", "")
+	assert.ErrorIs(t, err, dto.ErrInvalidCredentials)
+}
+
+func TestUserLogin_Blocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, failureRepo, now := newProtectedUserService(ctrl)
+
+	failureRepo.EXPECT().GetLoginFailures(gomock.Any(), gomock.Any()).Return([]models.LoginFailure{
+		{Key: "account:test@example.com", Failures: 3, BlockedUntil: now.Add(10 * time.Minute)},
+		{Key: "ip:10.0.0.1", Failures: 1, BlockedUntil: now.Add(time.Second)},
+	}, nil)
+
+	_, err := service.UserLogin(context.Background(), "test@example.com", "pass", "10.0.0.1")
+
+	var blocked *dto.LoginBlockedError
+	require.ErrorAs(t, err, &blocked)
+	assert.ErrorIs(t, err, dto.ErrAccountLocked)
+	assert.Equal(t, 10*time.Minute, blocked.RetryAfter)
+}
+
+func TestUserLogin_ExpiredDelayAllowsLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, userRepo, failureRepo, now := newProtectedUserService(ctrl)
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+
+	failureRepo.EXPECT().GetLoginFailures(gomock.Any(), gomock.Any()).Return([]models.LoginFailure{
+		{Key: "account:test@example.com", Failures: 2, BlockedUntil: now.Add(-time.Second)},
+	}, nil)
+	userRepo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "password").
+		Return(models.User{Id: "u1", Password: string(hashed)}, nil)
+	failureRepo.EXPECT().ResetLoginFailures(gomock.Any(), "account:test@example.com").Return(nil)
+
+	user, err := service.UserLogin(context.Background(), "test@example.com", "password", "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.Id)
+}
+
+func TestUnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, userRepo, failureRepo, _ := newProtectedUserService(ctrl)
+
+	userRepo.EXPECT().GetUserById(gomock.Any(), "u1").
+		Return(models.User{Id: "u1", Email: "Test@example.com"}, nil)
+	failureRepo.EXPECT().ResetLoginFailures(gomock.Any(), "account:test@example.com").Return(nil)
+
+	user, err := service.UnlockUser(context.Background(), "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.Id)
+
+	userRepo.EXPECT().GetUserById(gomock.Any(), "missing").Return(models.User{}, dto.ErrUserNotFound)
+
+	_, err = service.UnlockUser(context.Background(), "missing")
+	assert.ErrorIs(t, err, dto.ErrUserNotFound)
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.uber.org/zap"
+)
+
+// observeReceptionClosed учитывает закрытие или отмену приемки. Длительность
+// и число товаров пишутся только для закрытых приемок: отмененные не отражают
+// реальную работу ПВЗ
+func observeReceptionClosed(ctx context.Context, prodRepo ProductRepository, reception models.Reception) {
+	metrics.ReceptionsClosed.WithLabelValues(reception.Status).Inc()
+
+	if reception.Status != models.CLOSE {
+		return
+	}
+
+	if start, err := time.Parse(time.RFC3339Nano, reception.DateTime); err == nil {
+		metrics.ReceptionDuration.Observe(time.Since(start).Seconds())
+	}
+
+	counts, err := prodRepo.CountProductsByReceptionIds(ctx, []string{reception.Id}, models.PVZFilter{})
+	if err != nil {
+		logger.FromContext(ctx, zap.S()).Warnf("failed to count products of reception %s: %v", reception.Id, err)
+		return
+	}
+
+	metrics.ProductsPerReception.Observe(float64(counts[reception.Id]))
+}
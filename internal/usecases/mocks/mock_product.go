@@ -5,8 +5,8 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
-	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/hamillka/avitoTechSpring25/internal/models"
@@ -36,60 +36,90 @@ func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
 }
 
 // AddProduct mocks base method.
-func (m *MockProductRepository) AddProduct(productType, receptionId string) (models.Product, error) {
+func (m *MockProductRepository) AddProduct(ctx context.Context, productType, receptionId string) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProduct", productType, receptionId)
+	ret := m.ctrl.Call(m, "AddProduct", ctx, productType, receptionId)
 	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddProduct indicates an expected call of AddProduct.
-func (mr *MockProductRepositoryMockRecorder) AddProduct(productType, receptionId interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) AddProduct(ctx, productType, receptionId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockProductRepository)(nil).AddProduct), productType, receptionId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockProductRepository)(nil).AddProduct), ctx, productType, receptionId)
+}
+
+// CountProductsByReceptionIds mocks base method.
+func (m *MockProductRepository) CountProductsByReceptionIds(ctx context.Context, recIds []string, filter models.PVZFilter) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsByReceptionIds", ctx, recIds, filter)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsByReceptionIds indicates an expected call of CountProductsByReceptionIds.
+func (mr *MockProductRepositoryMockRecorder) CountProductsByReceptionIds(ctx, recIds, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsByReceptionIds", reflect.TypeOf((*MockProductRepository)(nil).CountProductsByReceptionIds), ctx, recIds, filter)
 }
 
 // DeleteProduct mocks base method.
-func (m *MockProductRepository) DeleteProduct(prodId string) error {
+func (m *MockProductRepository) DeleteProduct(ctx context.Context, prodId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", prodId)
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, prodId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockProductRepositoryMockRecorder) DeleteProduct(prodId interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) DeleteProduct(ctx, prodId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductRepository)(nil).DeleteProduct), prodId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductRepository)(nil).DeleteProduct), ctx, prodId)
 }
 
 // GetLastProduct mocks base method.
-func (m *MockProductRepository) GetLastProduct(recId string) (models.Product, error) {
+func (m *MockProductRepository) GetLastProduct(ctx context.Context, recId string) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLastProduct", recId)
+	ret := m.ctrl.Call(m, "GetLastProduct", ctx, recId)
 	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLastProduct indicates an expected call of GetLastProduct.
-func (mr *MockProductRepositoryMockRecorder) GetLastProduct(recId interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) GetLastProduct(ctx, recId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastProduct", reflect.TypeOf((*MockProductRepository)(nil).GetLastProduct), ctx, recId)
+}
+
+// GetProductsByReception mocks base method.
+func (m *MockProductRepository) GetProductsByReception(ctx context.Context, recId string, filter models.ProductListFilter, limit int) ([]models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsByReception", ctx, recId, filter, limit)
+	ret0, _ := ret[0].([]models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsByReception indicates an expected call of GetProductsByReception.
+func (mr *MockProductRepositoryMockRecorder) GetProductsByReception(ctx, recId, filter, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastProduct", reflect.TypeOf((*MockProductRepository)(nil).GetLastProduct), recId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByReception", reflect.TypeOf((*MockProductRepository)(nil).GetProductsByReception), ctx, recId, filter, limit)
 }
 
 // GetProductsByReceptionIds mocks base method.
-func (m *MockProductRepository) GetProductsByReceptionIds(recIds []string, startDate, endDate *time.Time) ([]models.Product, error) {
+func (m *MockProductRepository) GetProductsByReceptionIds(ctx context.Context, recIds []string, filter models.PVZFilter) ([]models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsByReceptionIds", recIds, startDate, endDate)
+	ret := m.ctrl.Call(m, "GetProductsByReceptionIds", ctx, recIds, filter)
 	ret0, _ := ret[0].([]models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProductsByReceptionIds indicates an expected call of GetProductsByReceptionIds.
-func (mr *MockProductRepositoryMockRecorder) GetProductsByReceptionIds(recIds, startDate, endDate interface{}) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) GetProductsByReceptionIds(ctx, recIds, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByReceptionIds", reflect.TypeOf((*MockProductRepository)(nil).GetProductsByReceptionIds), recIds, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByReceptionIds", reflect.TypeOf((*MockProductRepository)(nil).GetProductsByReceptionIds), ctx, recIds, filter)
 }
@@ -35,19 +35,34 @@ func (m *MockPVZRepository) EXPECT() *MockPVZRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountPVZs mocks base method.
+func (m *MockPVZRepository) CountPVZs(ctx context.Context, filter models.PVZFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPVZs", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPVZs indicates an expected call of CountPVZs.
+func (mr *MockPVZRepositoryMockRecorder) CountPVZs(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPVZs", reflect.TypeOf((*MockPVZRepository)(nil).CountPVZs), ctx, filter)
+}
+
 // CreatePVZ mocks base method.
-func (m *MockPVZRepository) CreatePVZ(city string) (models.PVZ, error) {
+func (m *MockPVZRepository) CreatePVZ(ctx context.Context, city string) (models.PVZ, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePVZ", city)
+	ret := m.ctrl.Call(m, "CreatePVZ", ctx, city)
 	ret0, _ := ret[0].(models.PVZ)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePVZ indicates an expected call of CreatePVZ.
-func (mr *MockPVZRepositoryMockRecorder) CreatePVZ(city interface{}) *gomock.Call {
+func (mr *MockPVZRepositoryMockRecorder) CreatePVZ(ctx, city interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePVZ", reflect.TypeOf((*MockPVZRepository)(nil).CreatePVZ), city)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePVZ", reflect.TypeOf((*MockPVZRepository)(nil).CreatePVZ), ctx, city)
 }
 
 // GetAllPVZs mocks base method.
@@ -65,32 +80,77 @@ func (mr *MockPVZRepositoryMockRecorder) GetAllPVZs(ctx interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPVZs", reflect.TypeOf((*MockPVZRepository)(nil).GetAllPVZs), ctx)
 }
 
+// GetNearbyPVZs mocks base method.
+func (m *MockPVZRepository) GetNearbyPVZs(ctx context.Context, lat, lon, radius float64, limit int) ([]models.PVZWithDistance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyPVZs", ctx, lat, lon, radius, limit)
+	ret0, _ := ret[0].([]models.PVZWithDistance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyPVZs indicates an expected call of GetNearbyPVZs.
+func (mr *MockPVZRepositoryMockRecorder) GetNearbyPVZs(ctx, lat, lon, radius, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyPVZs", reflect.TypeOf((*MockPVZRepository)(nil).GetNearbyPVZs), ctx, lat, lon, radius, limit)
+}
+
 // GetPVZById mocks base method.
-func (m *MockPVZRepository) GetPVZById(pvzId string) (models.PVZ, error) {
+func (m *MockPVZRepository) GetPVZById(ctx context.Context, pvzId string) (models.PVZ, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPVZById", pvzId)
+	ret := m.ctrl.Call(m, "GetPVZById", ctx, pvzId)
 	ret0, _ := ret[0].(models.PVZ)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPVZById indicates an expected call of GetPVZById.
-func (mr *MockPVZRepositoryMockRecorder) GetPVZById(pvzId interface{}) *gomock.Call {
+func (mr *MockPVZRepositoryMockRecorder) GetPVZById(ctx, pvzId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZById", reflect.TypeOf((*MockPVZRepository)(nil).GetPVZById), pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZById", reflect.TypeOf((*MockPVZRepository)(nil).GetPVZById), ctx, pvzId)
 }
 
 // GetPVZsWithPagination mocks base method.
-func (m *MockPVZRepository) GetPVZsWithPagination(offset, limit int) ([]models.PVZ, error) {
+func (m *MockPVZRepository) GetPVZsWithPagination(ctx context.Context, filter models.PVZFilter, offset, limit int) ([]models.PVZ, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPVZsWithPagination", offset, limit)
+	ret := m.ctrl.Call(m, "GetPVZsWithPagination", ctx, filter, offset, limit)
 	ret0, _ := ret[0].([]models.PVZ)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPVZsWithPagination indicates an expected call of GetPVZsWithPagination.
-func (mr *MockPVZRepositoryMockRecorder) GetPVZsWithPagination(offset, limit interface{}) *gomock.Call {
+func (mr *MockPVZRepositoryMockRecorder) GetPVZsWithPagination(ctx, filter, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZsWithPagination", reflect.TypeOf((*MockPVZRepository)(nil).GetPVZsWithPagination), ctx, filter, offset, limit)
+}
+
+// UpdatePVZ mocks base method.
+func (m *MockPVZRepository) UpdatePVZ(ctx context.Context, pvzId string, upd models.PVZUpdate) (models.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePVZ", ctx, pvzId, upd)
+	ret0, _ := ret[0].(models.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePVZ indicates an expected call of UpdatePVZ.
+func (mr *MockPVZRepositoryMockRecorder) UpdatePVZ(ctx, pvzId, upd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePVZ", reflect.TypeOf((*MockPVZRepository)(nil).UpdatePVZ), ctx, pvzId, upd)
+}
+
+// UpdatePVZStatus mocks base method.
+func (m *MockPVZRepository) UpdatePVZStatus(ctx context.Context, pvzId, status string) (models.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePVZStatus", ctx, pvzId, status)
+	ret0, _ := ret[0].(models.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePVZStatus indicates an expected call of UpdatePVZStatus.
+func (mr *MockPVZRepositoryMockRecorder) UpdatePVZStatus(ctx, pvzId, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZsWithPagination", reflect.TypeOf((*MockPVZRepository)(nil).GetPVZsWithPagination), offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePVZStatus", reflect.TypeOf((*MockPVZRepository)(nil).UpdatePVZStatus), ctx, pvzId, status)
 }
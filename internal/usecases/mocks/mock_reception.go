@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -35,62 +36,166 @@ func (m *MockReceptionRepository) EXPECT() *MockReceptionRepositoryMockRecorder
 	return m.recorder
 }
 
+// ChangeReceptionStatus mocks base method.
+func (m *MockReceptionRepository) ChangeReceptionStatus(ctx context.Context, recId, fromStatus, toStatus, role, reason string) (models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReceptionStatus", ctx, recId, fromStatus, toStatus, role, reason)
+	ret0, _ := ret[0].(models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeReceptionStatus indicates an expected call of ChangeReceptionStatus.
+func (mr *MockReceptionRepositoryMockRecorder) ChangeReceptionStatus(ctx, recId, fromStatus, toStatus, role, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReceptionStatus", reflect.TypeOf((*MockReceptionRepository)(nil).ChangeReceptionStatus), ctx, recId, fromStatus, toStatus, role, reason)
+}
+
+// CloseStaleReceptions mocks base method.
+func (m *MockReceptionRepository) CloseStaleReceptions(ctx context.Context, maxAge, maxIdle time.Duration) ([]models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseStaleReceptions", ctx, maxAge, maxIdle)
+	ret0, _ := ret[0].([]models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseStaleReceptions indicates an expected call of CloseStaleReceptions.
+func (mr *MockReceptionRepositoryMockRecorder) CloseStaleReceptions(ctx, maxAge, maxIdle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseStaleReceptions", reflect.TypeOf((*MockReceptionRepository)(nil).CloseStaleReceptions), ctx, maxAge, maxIdle)
+}
+
 // CreateReception mocks base method.
-func (m *MockReceptionRepository) CreateReception(pvzId string) (models.Reception, error) {
+func (m *MockReceptionRepository) CreateReception(ctx context.Context, pvzId, role string) (models.Reception, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateReception", pvzId)
+	ret := m.ctrl.Call(m, "CreateReception", ctx, pvzId, role)
 	ret0, _ := ret[0].(models.Reception)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateReception indicates an expected call of CreateReception.
-func (mr *MockReceptionRepositoryMockRecorder) CreateReception(pvzId interface{}) *gomock.Call {
+func (mr *MockReceptionRepositoryMockRecorder) CreateReception(ctx, pvzId, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockReceptionRepository)(nil).CreateReception), pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockReceptionRepository)(nil).CreateReception), ctx, pvzId, role)
 }
 
 // GetLastReception mocks base method.
-func (m *MockReceptionRepository) GetLastReception(pvzId string) (models.Reception, error) {
+func (m *MockReceptionRepository) GetLastReception(ctx context.Context, pvzId string) (models.Reception, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLastReception", pvzId)
+	ret := m.ctrl.Call(m, "GetLastReception", ctx, pvzId)
 	ret0, _ := ret[0].(models.Reception)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLastReception indicates an expected call of GetLastReception.
-func (mr *MockReceptionRepositoryMockRecorder) GetLastReception(pvzId interface{}) *gomock.Call {
+func (mr *MockReceptionRepositoryMockRecorder) GetLastReception(ctx, pvzId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastReception", reflect.TypeOf((*MockReceptionRepository)(nil).GetLastReception), pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastReception", reflect.TypeOf((*MockReceptionRepository)(nil).GetLastReception), ctx, pvzId)
+}
+
+// GetOpenReceptionStats mocks base method.
+func (m *MockReceptionRepository) GetOpenReceptionStats(ctx context.Context) ([]models.OpenReceptionStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenReceptionStats", ctx)
+	ret0, _ := ret[0].([]models.OpenReceptionStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenReceptionStats indicates an expected call of GetOpenReceptionStats.
+func (mr *MockReceptionRepositoryMockRecorder) GetOpenReceptionStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenReceptionStats", reflect.TypeOf((*MockReceptionRepository)(nil).GetOpenReceptionStats), ctx)
+}
+
+// GetReceptionById mocks base method.
+func (m *MockReceptionRepository) GetReceptionById(ctx context.Context, recId string) (models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionById", ctx, recId)
+	ret0, _ := ret[0].(models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceptionById indicates an expected call of GetReceptionById.
+func (mr *MockReceptionRepositoryMockRecorder) GetReceptionById(ctx, recId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionById", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceptionById), ctx, recId)
+}
+
+// GetReceptionsByPVZ mocks base method.
+func (m *MockReceptionRepository) GetReceptionsByPVZ(ctx context.Context, pvzId string, filter models.ReceptionListFilter, offset, limit int) ([]models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionsByPVZ", ctx, pvzId, filter, offset, limit)
+	ret0, _ := ret[0].([]models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceptionsByPVZ indicates an expected call of GetReceptionsByPVZ.
+func (mr *MockReceptionRepositoryMockRecorder) GetReceptionsByPVZ(ctx, pvzId, filter, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionsByPVZ", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceptionsByPVZ), ctx, pvzId, filter, offset, limit)
 }
 
 // GetReceptionsByPVZIds mocks base method.
-func (m *MockReceptionRepository) GetReceptionsByPVZIds(pvzIds []string, startDate, endDate *time.Time) ([]models.Reception, error) {
+func (m *MockReceptionRepository) GetReceptionsByPVZIds(ctx context.Context, pvzIds []string, filter models.PVZFilter) ([]models.Reception, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReceptionsByPVZIds", pvzIds, startDate, endDate)
+	ret := m.ctrl.Call(m, "GetReceptionsByPVZIds", ctx, pvzIds, filter)
 	ret0, _ := ret[0].([]models.Reception)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetReceptionsByPVZIds indicates an expected call of GetReceptionsByPVZIds.
-func (mr *MockReceptionRepositoryMockRecorder) GetReceptionsByPVZIds(pvzIds, startDate, endDate interface{}) *gomock.Call {
+func (mr *MockReceptionRepositoryMockRecorder) GetReceptionsByPVZIds(ctx, pvzIds, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionsByPVZIds", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceptionsByPVZIds), pvzIds, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionsByPVZIds", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceptionsByPVZIds), ctx, pvzIds, filter)
 }
 
-// UpdateReceptionStatus mocks base method.
-func (m *MockReceptionRepository) UpdateReceptionStatus(recId, status string) (models.Reception, error) {
+// GetStatusHistory mocks base method.
+func (m *MockReceptionRepository) GetStatusHistory(ctx context.Context, recId string) ([]models.ReceptionStatusChange, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateReceptionStatus", recId, status)
-	ret0, _ := ret[0].(models.Reception)
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, recId)
+	ret0, _ := ret[0].([]models.ReceptionStatusChange)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateReceptionStatus indicates an expected call of UpdateReceptionStatus.
-func (mr *MockReceptionRepositoryMockRecorder) UpdateReceptionStatus(recId, status interface{}) *gomock.Call {
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockReceptionRepositoryMockRecorder) GetStatusHistory(ctx, recId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockReceptionRepository)(nil).GetStatusHistory), ctx, recId)
+}
+
+// HasOpenReception mocks base method.
+func (m *MockReceptionRepository) HasOpenReception(ctx context.Context, pvzId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOpenReception", ctx, pvzId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOpenReception indicates an expected call of HasOpenReception.
+func (mr *MockReceptionRepositoryMockRecorder) HasOpenReception(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOpenReception", reflect.TypeOf((*MockReceptionRepository)(nil).HasOpenReception), ctx, pvzId)
+}
+
+// StreamReceptionsForExport mocks base method.
+func (m *MockReceptionRepository) StreamReceptionsForExport(ctx context.Context, filter models.PVZFilter, fn func(models.ReceptionExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamReceptionsForExport", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamReceptionsForExport indicates an expected call of StreamReceptionsForExport.
+func (mr *MockReceptionRepositoryMockRecorder) StreamReceptionsForExport(ctx, filter, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReceptionStatus", reflect.TypeOf((*MockReceptionRepository)(nil).UpdateReceptionStatus), recId, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamReceptionsForExport", reflect.TypeOf((*MockReceptionRepository)(nil).StreamReceptionsForExport), ctx, filter, fn)
 }
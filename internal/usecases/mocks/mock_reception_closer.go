@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reception_closer.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLeaderElector is a mock of LeaderElector interface.
+type MockLeaderElector struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaderElectorMockRecorder
+}
+
+// MockLeaderElectorMockRecorder is the mock recorder for MockLeaderElector.
+type MockLeaderElectorMockRecorder struct {
+	mock *MockLeaderElector
+}
+
+// NewMockLeaderElector creates a new mock instance.
+func NewMockLeaderElector(ctrl *gomock.Controller) *MockLeaderElector {
+	mock := &MockLeaderElector{ctrl: ctrl}
+	mock.recorder = &MockLeaderElectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeaderElector) EXPECT() *MockLeaderElectorMockRecorder {
+	return m.recorder
+}
+
+// IsLeader mocks base method.
+func (m *MockLeaderElector) IsLeader(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLeader", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsLeader indicates an expected call of IsLeader.
+func (mr *MockLeaderElectorMockRecorder) IsLeader(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLeader", reflect.TypeOf((*MockLeaderElector)(nil).IsLeader), ctx)
+}
+
+// Resign mocks base method.
+func (m *MockLeaderElector) Resign(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resign", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resign indicates an expected call of Resign.
+func (mr *MockLeaderElectorMockRecorder) Resign(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resign", reflect.TypeOf((*MockLeaderElector)(nil).Resign), ctx)
+}
@@ -0,0 +1,200 @@
+//go:generate mockgen -source=password.go -destination=./mocks/mock_password.go -package=mocks
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordResetConfig описывает сброс пароля по токену из письма
+type PasswordResetConfig struct {
+	TokenTTL time.Duration `default:"30m" envconfig:"TOKEN_TTL"`
+}
+
+type PasswordPolicy interface {
+	Validate(password string) error
+}
+
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) (models.User, error)
+}
+
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error
+}
+
+const resetTokenBytes = 32
+
+// newResetToken возвращает токен для письма и его хеш для базы: утечка
+// таблицы токенов не позволяет сбросить чужой пароль
+func newResetToken() (string, string, error) {
+	raw := make([]byte, resetTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashResetToken(token), nil
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashed), nil
+}
+
+// ChangePassword меняет пароль пользователя из токена после проверки
+// текущего пароля. Токены dummyLogin не привязаны к пользователю, поэтому
+// для них возвращается dto.ErrPasswordChangeDenied
+func (us *UserService) ChangePassword(ctx context.Context, userId, oldPassword, newPassword string) error {
+	if userId == "" {
+		return dto.ErrPasswordChangeDenied
+	}
+
+	user, err := us.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword))
+	if err != nil {
+		securityLogger(ctx).Warnw("password change rejected",
+			"event", "password_change_failed",
+			"user_id", user.Id,
+		)
+		return dto.ErrInvalidCredentials
+	}
+
+	if err = us.policy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	hashed, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err = us.userRepo.UpdatePassword(ctx, user.Id, hashed); err != nil {
+		return err
+	}
+
+	securityLogger(ctx).Infow("password changed",
+		"event", "password_changed",
+		"user_id", user.Id,
+	)
+
+	return nil
+}
+
+// RequestPasswordReset создает токен сброса и отправляет его на почту.
+// Для неизвестной почты ошибка не возвращается, иначе по ответу можно было
+// бы перебирать зарегистрированные адреса
+func (us *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+
+	user, err := us.userRepo.UserLogin(ctx, email, "")
+	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCredentials) {
+			securityLogger(ctx).Infow("password reset for unknown email",
+				"event", "password_reset_unknown_email",
+				"email", email,
+			)
+			return nil
+		}
+		return err
+	}
+
+	if user.Disabled() {
+		securityLogger(ctx).Warnw("password reset for disabled user",
+			"event", "password_reset_disabled",
+			"user_id", user.Id,
+			"email", user.Email,
+		)
+		return nil
+	}
+
+	token, tokenHash, err := newResetToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := us.now().Add(us.resetCfg.TokenTTL)
+	if err = us.resetRepo.CreatePasswordResetToken(ctx, user.Id, tokenHash, expiresAt); err != nil {
+		return err
+	}
+
+	// ошибка доставки тоже не должна отличать существующую почту от неизвестной
+	if err = us.notifier.SendPasswordReset(ctx, user.Email, token, expiresAt); err != nil {
+		logger.FromContext(ctx, zap.S()).Errorf("failed to send password reset: %v", err)
+		return nil
+	}
+
+	securityLogger(ctx).Infow("password reset requested",
+		"event", "password_reset_requested",
+		"user_id", user.Id,
+		"email", user.Email,
+	)
+
+	return nil
+}
+
+// ResetPassword задает новый пароль по токену из письма. Токен одноразовый,
+// а после сброса снимается блокировка входа учетной записи
+func (us *UserService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if token == "" {
+		return dto.ErrInvalidResetToken
+	}
+
+	if err := us.policy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	hashed, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	user, err := us.resetRepo.ResetPassword(ctx, hashResetToken(token), hashed, us.now())
+	if err != nil {
+		if errors.Is(err, dto.ErrInvalidResetToken) {
+			securityLogger(ctx).Warnw("password reset rejected",
+				"event", "password_reset_failed",
+			)
+		}
+		return err
+	}
+
+	if us.loginCfg.Enabled {
+		if err = us.failureRepo.ResetLoginFailures(ctx, accountLoginKey(user.Email)); err != nil {
+			logger.FromContext(ctx, zap.S()).Warnf("failed to reset login failures: %v", err)
+		}
+	}
+
+	securityLogger(ctx).Infow("password reset",
+		"event", "password_reset",
+		"user_id", user.Id,
+		"email", user.Email,
+	)
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/hamillka/avitoTechSpring25/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type passwordMocks struct {
+	userRepo    *mocks.MockUserRepository
+	failureRepo *mocks.MockLoginFailureRepository
+	resetRepo   *mocks.MockPasswordResetRepository
+	notifier    *mocks.MockNotifier
+}
+
+func newPasswordUserService(ctrl *gomock.Controller) (*UserService, passwordMocks, time.Time) {
+	m := passwordMocks{
+		userRepo:    mocks.NewMockUserRepository(ctrl),
+		failureRepo: mocks.NewMockLoginFailureRepository(ctrl),
+		resetRepo:   mocks.NewMockPasswordResetRepository(ctrl),
+		notifier:    mocks.NewMockNotifier(ctrl),
+	}
+	service := NewUserService(m.userRepo, m.failureRepo, m.resetRepo, testPolicy, m.notifier,
+		testLoginCfg, PasswordResetConfig{TokenTTL: 30 * time.Minute}, RegistrationConfig{})
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	return service, m, now
+}
+
+func TestChangePassword_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m, _ := newPasswordUserService(ctrl)
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("oldpassword"), bcrypt.MinCost)
+
+	m.userRepo.EXPECT().GetUserById(gomock.Any(), "u1").
+		Return(models.User{Id: "u1", Password: string(hashed)}, nil)
+
+	var newHash string
+	m.userRepo.EXPECT().UpdatePassword(gomock.Any(), "u1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, password string) error {
+			newHash = password
+			return nil
+		})
+
+	err := service.ChangePassword(context.Background(), "u1", "oldpassword", "newpassword")
+	require.NoError(t, err)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(newHash), []byte("newpassword")))
+}
+
+func TestChangePassword_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m, _ := newPasswordUserService(ctrl)
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("oldpassword"), bcrypt.MinCost)
+
+	err := service.ChangePassword(context.Background(), "", "oldpassword", "newpassword")
+	assert.ErrorIs(t, err, dto.ErrPasswordChangeDenied)
+
+	m.userRepo.EXPECT().GetUserById(gomock.Any(), "u1").
+		Return(models.User{Id: "u1", Password: string(hashed)}, nil).Times(2)
+
+	err = service.ChangePassword(context.Background(), "u1", "wrongpassword", "newpassword")
+	assert.ErrorIs(t, err, dto.ErrInvalidCredentials)
+
+	err = service.ChangePassword(context.Background(), "u1", "oldpassword", "short")
+	assert.ErrorIs(t, err, dto.ErrWeakPassword)
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m, now := newPasswordUserService(ctrl)
+	expiresAt := now.Add(30 * time.Minute)
+
+	m.userRepo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "").
+		Return(models.User{Id: "u1", Email: "test@example.com"}, nil)
+
+	var tokenHash string
+	m.resetRepo.EXPECT().CreatePasswordResetToken(gomock.Any(), "u1", gomock.Any(), expiresAt).
+		DoAndReturn(func(_ context.Context, _, hash string, _ time.Time) error {
+			tokenHash = hash
+			return nil
+		})
+
+	var token string
+	m.notifier.EXPECT().SendPasswordReset(gomock.Any(), "test@example.com", gomock.Any(), expiresAt).
+		DoAndReturn(func(_ context.Context, _, t string, _ time.Time) error {
+			token = t
+			return nil
+		})
+
+	require.NoError(t, service.RequestPasswordReset(context.Background(), "test@example.com"))
+	assert.NotEmpty(t, token)
+	assert.NotEqual(t, token, tokenHash)
+	assert.Equal(t, hashResetToken(token), tokenHash)
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m, _ := newPasswordUserService(ctrl)
+
+	m.userRepo.EXPECT().UserLogin(gomock.Any(), "unknown@example.com", "").
+		Return(models.User{}, dto.ErrInvalidCredentials)
+
+	assert.NoError(t, service.RequestPasswordReset(context.Background(), "unknown@example.com"))
+
+	m.userRepo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "").
+		Return(models.User{}, dto.ErrDBRead)
+
+	assert.ErrorIs(t, service.RequestPasswordReset(context.Background(), "test@example.com"), dto.ErrDBRead)
+}
+
+func TestRequestPasswordReset_NotifierError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m, _ := newPasswordUserService(ctrl)
+
+	m.userRepo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "").
+		Return(models.User{Id: "u1", Email: "test@example.com"}, nil)
+	m.resetRepo.EXPECT().CreatePasswordResetToken(gomock.Any(), "u1", gomock.Any(), gomock.Any()).Return(nil)
+	m.notifier.EXPECT().SendPasswordReset(gomock.Any(), "test@example.com", gomock.Any(), gomock.Any()).
+		Return(errors.New("smtp is down"))
+
+	assert.NoError(t, service.RequestPasswordReset(context.Background(), "test@example.com"))
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m, now := newPasswordUserService(ctrl)
+
+	m.resetRepo.EXPECT().ResetPassword(gomock.Any(), hashResetToken("token"), gomock.Any(), now).
+		Return(models.User{Id: "u1", Email: "Test@example.com"}, nil)
+	m.failureRepo.EXPECT().ResetLoginFailures(gomock.Any(), "account:test@example.com").Return(nil)
+
+	require.NoError(t, service.ResetPassword(context.Background(), "token", "newpassword"))
+}
+
+func TestResetPassword_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m, _ := newPasswordUserService(ctrl)
+
+	assert.ErrorIs(t, service.ResetPassword(context.Background(), "", "newpassword"), dto.ErrInvalidResetToken)
+	assert.ErrorIs(t, service.ResetPassword(context.Background(), "token", "password123"), dto.ErrWeakPassword)
+
+	m.resetRepo.EXPECT().ResetPassword(gomock.Any(), hashResetToken("expired"), gomock.Any(), gomock.Any()).
+		Return(models.User{}, dto.ErrInvalidResetToken)
+
+	err := service.ResetPassword(context.Background(), "expired", "newpassword")
+	assert.ErrorIs(t, err, dto.ErrInvalidResetToken)
+}
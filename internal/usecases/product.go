@@ -2,48 +2,91 @@
 package usecases
 
 import (
+	"context"
 	"time"
 
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 )
 
 type ProductRepository interface {
-	AddProduct(productType, receptionId string) (models.Product, error)
-	GetLastProduct(recId string) (models.Product, error)
-	DeleteProduct(prodId string) error
-	GetProductsByReceptionIds(recIds []string, startDate, endDate *time.Time) ([]models.Product, error)
+	AddProduct(ctx context.Context, productType, receptionId string) (models.Product, error)
+	GetLastProduct(ctx context.Context, recId string) (models.Product, error)
+	DeleteProduct(ctx context.Context, prodId string) error
+	GetProductsByReceptionIds(ctx context.Context, recIds []string, filter models.PVZFilter) ([]models.Product, error)
+	CountProductsByReceptionIds(ctx context.Context, recIds []string, filter models.PVZFilter) (map[string]int, error)
+	GetProductsByReception(ctx context.Context, recId string, filter models.ProductListFilter, limit int) ([]models.Product, error)
 }
 
 type ProductService struct {
 	prodRepo ProductRepository
 	recRepo  ReceptionRepository
 	pvzRepo  PVZRepository
+	cache    Cache
 }
 
-func NewProductService(prodRepo ProductRepository, recRepo ReceptionRepository, pvzRepo PVZRepository) *ProductService {
+func NewProductService(prodRepo ProductRepository, recRepo ReceptionRepository, pvzRepo PVZRepository, cache Cache) *ProductService {
 	return &ProductService{
 		prodRepo: prodRepo,
 		recRepo:  recRepo,
 		pvzRepo:  pvzRepo,
+		cache:    cache,
 	}
 }
 
-func (ps *ProductService) AddProductToReception(productType, pvzId string) (models.Product, error) {
-	_, err := ps.pvzRepo.GetPVZById(pvzId)
+func (ps *ProductService) AddProductToReception(ctx context.Context, productType, pvzId string) (models.Product, error) {
+	pvz, err := ps.pvzRepo.GetPVZById(ctx, pvzId)
 	if err != nil {
 		return models.Product{}, err
 	}
 
-	lastReception, err := ps.recRepo.GetLastReception(pvzId)
-	if err != nil || lastReception.Status != models.INPROGRESS {
+	lastReception, err := ps.recRepo.GetLastReception(ctx, pvzId)
+	if err != nil || !isReceptionEditable(lastReception.Status) {
 		return models.Product{}, dto.ErrNoActiveReception
 	}
 
-	product, err := ps.prodRepo.AddProduct(productType, lastReception.Id)
+	product, err := ps.prodRepo.AddProduct(ctx, productType, lastReception.Id)
 	if err != nil {
 		return models.Product{}, err
 	}
 
+	metrics.ProductsAdded.WithLabelValues(pvz.City, productType).Inc()
+
+	invalidatePVZCache(ctx, ps.cache)
+
 	return product, nil
 }
+
+// GetReceptionProducts возвращает страницу товаров приемки и курсор следующей страницы.
+// Курсор равен nil, если страница последняя
+func (ps *ProductService) GetReceptionProducts(
+	ctx context.Context,
+	recId string,
+	filter models.ProductListFilter,
+	limit int,
+) ([]models.Product, *models.ProductCursor, error) {
+	_, err := ps.recRepo.GetReceptionById(ctx, recId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	products, err := ps.prodRepo.GetProductsByReception(ctx, recId, filter, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(products) <= limit {
+		return products, nil, nil
+	}
+
+	products = products[:limit]
+	last := products[limit-1]
+
+	dateTime, err := time.Parse(time.RFC3339Nano, last.DateTime)
+	if err != nil {
+		return nil, nil, dto.ErrDBRead
+	}
+
+	return products, &models.ProductCursor{DateTime: dateTime, Id: last.Id}, nil
+}
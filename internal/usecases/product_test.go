@@ -1,13 +1,18 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/cache"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/hamillka/avitoTechSpring25/internal/usecases/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,16 +25,20 @@ func TestAddProductToReception_Success(t *testing.T) {
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 	pvzRepo := mocks.NewMockPVZRepository(ctrl)
 
-	service := NewProductService(prodRepo, recRepo, pvzRepo)
+	service := NewProductService(prodRepo, recRepo, pvzRepo, cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("pvz123").Return(models.PVZ{Id: "pvz123"}, nil)
-	recRepo.EXPECT().GetLastReception("pvz123").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
-	prodRepo.EXPECT().AddProduct("type1", "rec1").Return(models.Product{Id: "prod1", Type: "type1"}, nil)
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz123").Return(models.PVZ{Id: "pvz123", City: "Казань"}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz123").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
+	prodRepo.EXPECT().AddProduct(gomock.Any(), "type1", "rec1").Return(models.Product{Id: "prod1", Type: "type1"}, nil)
 
-	product, err := service.AddProductToReception("type1", "pvz123")
+	added := metrics.ProductsAdded.WithLabelValues("Казань", "type1")
+	before := testutil.ToFloat64(added)
+
+	product, err := service.AddProductToReception(context.Background(), "type1", "pvz123")
 
 	require.NoError(t, err)
 	assert.Equal(t, "prod1", product.Id)
+	assert.Equal(t, before+1, testutil.ToFloat64(added))
 }
 
 func TestAddProductToReception_PVZNotFound(t *testing.T) {
@@ -40,11 +49,11 @@ func TestAddProductToReception_PVZNotFound(t *testing.T) {
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 	pvzRepo := mocks.NewMockPVZRepository(ctrl)
 
-	service := NewProductService(prodRepo, recRepo, pvzRepo)
+	service := NewProductService(prodRepo, recRepo, pvzRepo, cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("pvz404").Return(models.PVZ{}, errors.New("not found"))
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz404").Return(models.PVZ{}, errors.New("not found"))
 
-	_, err := service.AddProductToReception("type1", "pvz404")
+	_, err := service.AddProductToReception(context.Background(), "type1", "pvz404")
 
 	assert.Error(t, err)
 }
@@ -57,12 +66,78 @@ func TestAddProductToReception_NoActiveReception(t *testing.T) {
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 	pvzRepo := mocks.NewMockPVZRepository(ctrl)
 
-	service := NewProductService(prodRepo, recRepo, pvzRepo)
+	service := NewProductService(prodRepo, recRepo, pvzRepo, cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("pvz123").Return(models.PVZ{Id: "pvz123"}, nil)
-	recRepo.EXPECT().GetLastReception("pvz123").Return(models.Reception{Id: "rec1", Status: "close"}, nil)
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz123").Return(models.PVZ{Id: "pvz123"}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz123").Return(models.Reception{Id: "rec1", Status: "close"}, nil)
 
-	_, err := service.AddProductToReception("type1", "pvz123")
+	_, err := service.AddProductToReception(context.Background(), "type1", "pvz123")
 
 	assert.ErrorIs(t, err, dto.ErrNoActiveReception)
 }
+
+func TestGetReceptionProducts_NextCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+
+	service := NewProductService(prodRepo, recRepo, pvzRepo, cache.NewNoop())
+
+	filter := models.ProductListFilter{SortOrder: models.SortAsc}
+	recRepo.EXPECT().GetReceptionById(gomock.Any(), "rec1").Return(models.Reception{Id: "rec1"}, nil)
+	prodRepo.EXPECT().GetProductsByReception(gomock.Any(), "rec1", filter, 3).Return([]models.Product{
+		{Id: "prod1", DateTime: "2025-04-11T10:00:00.1Z"},
+		{Id: "prod2", DateTime: "2025-04-11T10:00:00.2Z"},
+		{Id: "prod3", DateTime: "2025-04-11T10:00:00.3Z"},
+	}, nil)
+
+	products, next, err := service.GetReceptionProducts(context.Background(), "rec1", filter, 2)
+
+	require.NoError(t, err)
+	assert.Len(t, products, 2)
+	require.NotNil(t, next)
+	assert.Equal(t, "prod2", next.Id)
+	assert.Equal(t, 200*time.Millisecond, next.DateTime.Sub(next.DateTime.Truncate(time.Second)))
+}
+
+func TestGetReceptionProducts_LastPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+
+	service := NewProductService(prodRepo, recRepo, pvzRepo, cache.NewNoop())
+
+	recRepo.EXPECT().GetReceptionById(gomock.Any(), "rec1").Return(models.Reception{Id: "rec1"}, nil)
+	prodRepo.EXPECT().GetProductsByReception(gomock.Any(), "rec1", models.ProductListFilter{}, 11).Return([]models.Product{
+		{Id: "prod1", DateTime: "2025-04-11T10:00:00Z"},
+	}, nil)
+
+	products, next, err := service.GetReceptionProducts(context.Background(), "rec1", models.ProductListFilter{}, 10)
+
+	require.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.Nil(t, next)
+}
+
+func TestGetReceptionProducts_ReceptionNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+
+	service := NewProductService(prodRepo, recRepo, pvzRepo, cache.NewNoop())
+
+	recRepo.EXPECT().GetReceptionById(gomock.Any(), "rec404").Return(models.Reception{}, dto.ErrReceptionNotFound)
+
+	_, _, err := service.GetReceptionProducts(context.Background(), "rec404", models.ProductListFilter{}, 10)
+
+	assert.ErrorIs(t, err, dto.ErrReceptionNotFound)
+}
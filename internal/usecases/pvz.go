@@ -3,46 +3,123 @@ package usecases
 
 import (
 	"context"
-	"time"
+	"slices"
 
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.uber.org/zap"
 )
 
 type PVZRepository interface {
-	CreatePVZ(city string) (models.PVZ, error)
-	GetPVZById(pvzId string) (models.PVZ, error)
-	GetPVZsWithPagination(offset, limit int) ([]models.PVZ, error)
+	CreatePVZ(ctx context.Context, city string) (models.PVZ, error)
+	GetPVZById(ctx context.Context, pvzId string) (models.PVZ, error)
+	GetPVZsWithPagination(ctx context.Context, filter models.PVZFilter, offset, limit int) ([]models.PVZ, error)
+	CountPVZs(ctx context.Context, filter models.PVZFilter) (int, error)
 	GetAllPVZs(ctx context.Context) ([]models.PVZ, error)
+	UpdatePVZ(ctx context.Context, pvzId string, upd models.PVZUpdate) (models.PVZ, error)
+	UpdatePVZStatus(ctx context.Context, pvzId, status string) (models.PVZ, error)
+	GetNearbyPVZs(ctx context.Context, lat, lon, radius float64, limit int) ([]models.PVZWithDistance, error)
+}
+
+var pvzTransitions = map[string][]string{
+	models.PVZActive:   {models.PVZInactive, models.PVZArchived},
+	models.PVZInactive: {models.PVZActive, models.PVZArchived},
 }
 
 type PVZService struct {
 	pvzRepo  PVZRepository
 	recRepo  ReceptionRepository
 	prodRepo ProductRepository
+	cache    Cache
 }
 
-func NewPVZService(pvzRepo PVZRepository, recRepo ReceptionRepository, prodRepo ProductRepository) *PVZService {
+func NewPVZService(pvzRepo PVZRepository, recRepo ReceptionRepository, prodRepo ProductRepository, cache Cache) *PVZService {
 	return &PVZService{
 		pvzRepo:  pvzRepo,
 		recRepo:  recRepo,
 		prodRepo: prodRepo,
+		cache:    cache,
 	}
 }
 
-func (pvzs *PVZService) CreatePVZ(city string) (models.PVZ, error) {
-	pvz, err := pvzs.pvzRepo.CreatePVZ(city)
+func (pvzs *PVZService) CreatePVZ(ctx context.Context, city string) (models.PVZ, error) {
+	pvz, err := pvzs.pvzRepo.CreatePVZ(ctx, city)
 	if err != nil {
 		return models.PVZ{}, err
 	}
 
+	metrics.PVZCreated.WithLabelValues(pvz.City).Inc()
+
+	invalidatePVZCache(ctx, pvzs.cache)
+
 	return pvz, nil
 }
 
-func (pvzs *PVZService) GetPVZWithPagination(startDate, endDate *time.Time, page, limit int) ([]models.PVZWithReceptions, error) {
+func (pvzs *PVZService) GetPVZ(ctx context.Context, pvzId string) (models.PVZ, error) {
+	return pvzs.pvzRepo.GetPVZById(ctx, pvzId)
+}
+
+func (pvzs *PVZService) UpdatePVZ(ctx context.Context, pvzId string, upd models.PVZUpdate) (models.PVZ, error) {
+	pvz, err := pvzs.pvzRepo.GetPVZById(ctx, pvzId)
+	if err != nil {
+		return models.PVZ{}, err
+	}
+
+	if pvz.Status == models.PVZArchived {
+		return models.PVZ{}, dto.ErrPVZNotActive
+	}
+
+	updPVZ, err := pvzs.pvzRepo.UpdatePVZ(ctx, pvzId, upd)
+	if err != nil {
+		return models.PVZ{}, err
+	}
+
+	invalidatePVZCache(ctx, pvzs.cache)
+
+	return updPVZ, nil
+}
+
+func (pvzs *PVZService) ChangePVZStatus(ctx context.Context, pvzId, status string) (models.PVZ, error) {
+	pvz, err := pvzs.pvzRepo.GetPVZById(ctx, pvzId)
+	if err != nil {
+		return models.PVZ{}, err
+	}
+
+	if !slices.Contains(pvzTransitions[pvz.Status], status) {
+		return models.PVZ{}, dto.ErrInvalidPVZStatusChange
+	}
+
+	updPVZ, err := pvzs.pvzRepo.UpdatePVZStatus(ctx, pvzId, status)
+	if err != nil {
+		return models.PVZ{}, err
+	}
+
+	logger.FromContext(ctx, zap.S()).Infof("pvz %s status changed from %s to %s", pvzId, pvz.Status, status)
+
+	invalidatePVZCache(ctx, pvzs.cache)
+
+	return updPVZ, nil
+}
+
+func (pvzs *PVZService) GetPVZWithPagination(ctx context.Context, filter models.PVZFilter, expand string, page, limit int) ([]models.PVZWithReceptions, error) {
+	key := cacheKey(pvzListCacheKey, struct {
+		Filter models.PVZFilter
+		Expand string
+		Page   int
+		Limit  int
+	}{filter, expand, page, limit})
+
+	return readThrough(ctx, pvzs.cache, key, func() ([]models.PVZWithReceptions, error) {
+		return pvzs.loadPVZPage(ctx, filter, expand, page, limit)
+	})
+}
+
+func (pvzs *PVZService) loadPVZPage(ctx context.Context, filter models.PVZFilter, expand string, page, limit int) ([]models.PVZWithReceptions, error) {
 	offset := (page - 1) * limit
 
-	allPVZs, err := pvzs.pvzRepo.GetPVZsWithPagination(offset, limit)
+	allPVZs, err := pvzs.pvzRepo.GetPVZsWithPagination(ctx, filter, offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -51,21 +128,24 @@ func (pvzs *PVZService) GetPVZWithPagination(startDate, endDate *time.Time, page
 		return []models.PVZWithReceptions{}, nil
 	}
 
+	if expand == models.ExpandPVZ {
+		result := make([]models.PVZWithReceptions, 0, len(allPVZs))
+		for _, pvz := range allPVZs {
+			result = append(result, models.PVZWithReceptions{PVZ: pvz})
+		}
+		return result, nil
+	}
+
 	pvzIds := make([]string, len(allPVZs))
 	for i, pvz := range allPVZs {
 		pvzIds[i] = pvz.Id
 	}
 
-	allReceptions, err := pvzs.recRepo.GetReceptionsByPVZIds(pvzIds, startDate, endDate)
+	allReceptions, err := pvzs.recRepo.GetReceptionsByPVZIds(ctx, pvzIds, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	dateFiltersApplied := startDate != nil || endDate != nil
-	if dateFiltersApplied && len(allReceptions) == 0 {
-		return []models.PVZWithReceptions{}, nil
-	}
-
 	receptionsByPVZ := make(map[string][]models.Reception)
 	receptionIds := make([]string, 0, len(allReceptions))
 
@@ -74,36 +154,41 @@ func (pvzs *PVZService) GetPVZWithPagination(startDate, endDate *time.Time, page
 		receptionIds = append(receptionIds, reception.Id)
 	}
 
-	var allProducts []models.Product
+	productsByReception := make(map[string][]models.Product)
+	productCounts := make(map[string]int)
+
 	if len(receptionIds) > 0 {
-		allProducts, err = pvzs.prodRepo.GetProductsByReceptionIds(receptionIds, startDate, endDate)
-		if err != nil {
-			return nil, err
+		if expand == models.ExpandReceptions {
+			productCounts, err = pvzs.prodRepo.CountProductsByReceptionIds(ctx, receptionIds, filter)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			allProducts, err := pvzs.prodRepo.GetProductsByReceptionIds(ctx, receptionIds, filter)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, product := range allProducts {
+				productsByReception[product.ReceptionId] = append(productsByReception[product.ReceptionId], product)
+				productCounts[product.ReceptionId]++
+			}
 		}
 	}
 
-	productsByReception := make(map[string][]models.Product)
-	for _, product := range allProducts {
-		productsByReception[product.ReceptionId] = append(productsByReception[product.ReceptionId], product)
-	}
-
 	result := make([]models.PVZWithReceptions, 0, len(allPVZs))
 
 	for _, pvz := range allPVZs {
 		receptions := receptionsByPVZ[pvz.Id]
-
-		if dateFiltersApplied && len(receptions) == 0 {
-			continue
-		}
-
 		receptionsWithProducts := make([]models.ReceptionWithProducts, 0, len(receptions))
 
 		for _, reception := range receptions {
 			products := productsByReception[reception.Id]
 
 			receptionsWithProducts = append(receptionsWithProducts, models.ReceptionWithProducts{
-				Reception: reception,
-				Products:  products,
+				Reception:    reception,
+				Products:     products,
+				ProductCount: productCounts[reception.Id],
 			})
 		}
 
@@ -116,49 +201,70 @@ func (pvzs *PVZService) GetPVZWithPagination(startDate, endDate *time.Time, page
 	return result, nil
 }
 
-func (pvzs *PVZService) CloseLastReception(pvzId string) (models.Reception, error) {
-	_, err := pvzs.pvzRepo.GetPVZById(pvzId)
+func (pvzs *PVZService) CountPVZs(ctx context.Context, filter models.PVZFilter) (int, error) {
+	return readThrough(ctx, pvzs.cache, cacheKey(pvzCountCacheKey, filter), func() (int, error) {
+		return pvzs.pvzRepo.CountPVZs(ctx, filter)
+	})
+}
+
+func (pvzs *PVZService) CloseLastReception(ctx context.Context, pvzId string) (models.Reception, error) {
+	_, err := pvzs.pvzRepo.GetPVZById(ctx, pvzId)
 	if err != nil {
 		return models.Reception{}, err
 	}
 
-	lastReception, err := pvzs.recRepo.GetLastReception(pvzId)
-	if err != nil || lastReception.Status == models.CLOSE {
+	lastReception, err := pvzs.recRepo.GetLastReception(ctx, pvzId)
+	if err != nil || validateReceptionTransition(lastReception.Status, models.CLOSE, dto.RoleEmployee) != nil {
 		return models.Reception{}, dto.ErrNoActiveReception
 	}
 
-	updRec, err := pvzs.recRepo.UpdateReceptionStatus(lastReception.Id, "close")
+	updRec, err := pvzs.recRepo.ChangeReceptionStatus(ctx, lastReception.Id, lastReception.Status, models.CLOSE, dto.RoleEmployee, "")
 	if err != nil {
 		return models.Reception{}, err
 	}
 
+	logger.FromContext(ctx, zap.S()).Infof("reception %s of pvz %s closed", lastReception.Id, pvzId)
+	observeReceptionClosed(ctx, pvzs.prodRepo, updRec)
+
+	invalidatePVZCache(ctx, pvzs.cache)
+
 	return updRec, nil
 }
 
-func (pvzs *PVZService) DeleteLastProduct(pvzId string) error {
-	_, err := pvzs.pvzRepo.GetPVZById(pvzId)
+func (pvzs *PVZService) DeleteLastProduct(ctx context.Context, pvzId string) error {
+	pvz, err := pvzs.pvzRepo.GetPVZById(ctx, pvzId)
 	if err != nil {
 		return err
 	}
 
-	lastReception, err := pvzs.recRepo.GetLastReception(pvzId)
-	if err != nil || lastReception.Status == "close" {
+	lastReception, err := pvzs.recRepo.GetLastReception(ctx, pvzId)
+	if err != nil || !isReceptionEditable(lastReception.Status) {
 		return dto.ErrNoActiveReception
 	}
 
-	product, err := pvzs.prodRepo.GetLastProduct(lastReception.Id)
+	product, err := pvzs.prodRepo.GetLastProduct(ctx, lastReception.Id)
 	if err != nil {
 		return dto.ErrNoProductsInReception
 	}
 
-	err = pvzs.prodRepo.DeleteProduct(product.Id)
+	err = pvzs.prodRepo.DeleteProduct(ctx, product.Id)
 	if err != nil {
 		return err
 	}
 
+	metrics.ProductsDeleted.WithLabelValues(pvz.City, product.Type).Inc()
+
+	invalidatePVZCache(ctx, pvzs.cache)
+
 	return nil
 }
 
 func (pvzs *PVZService) GetAllPVZs(ctx context.Context) ([]models.PVZ, error) {
-	return pvzs.pvzRepo.GetAllPVZs(ctx)
+	return readThrough(ctx, pvzs.cache, pvzAllCacheKey, func() ([]models.PVZ, error) {
+		return pvzs.pvzRepo.GetAllPVZs(ctx)
+	})
+}
+
+func (pvzs *PVZService) GetNearbyPVZs(ctx context.Context, lat, lon, radius float64, limit int) ([]models.PVZWithDistance, error) {
+	return pvzs.pvzRepo.GetNearbyPVZs(ctx, lat, lon, radius, limit)
 }
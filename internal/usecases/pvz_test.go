@@ -1,11 +1,13 @@
 package usecases
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/hamillka/avitoTechSpring25/internal/cache"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/hamillka/avitoTechSpring25/internal/usecases/mocks"
@@ -21,11 +23,11 @@ func TestCreatePVZ_Success(t *testing.T) {
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 	prodRepo := mocks.NewMockProductRepository(ctrl)
 
-	service := NewPVZService(pvzRepo, recRepo, prodRepo)
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
 
-	pvzRepo.EXPECT().CreatePVZ("Москва").Return(models.PVZ{Id: "1", City: "Москва"}, nil)
+	pvzRepo.EXPECT().CreatePVZ(gomock.Any(), "Москва").Return(models.PVZ{Id: "1", City: "Москва"}, nil)
 
-	pvz, err := service.CreatePVZ("Москва")
+	pvz, err := service.CreatePVZ(context.Background(), "Москва")
 	require.NoError(t, err)
 	assert.Equal(t, "Москва", pvz.City)
 }
@@ -38,13 +40,14 @@ func TestCloseLastReception_Success(t *testing.T) {
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 	prodRepo := mocks.NewMockProductRepository(ctrl)
 
-	service := NewPVZService(pvzRepo, recRepo, prodRepo)
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
-	recRepo.EXPECT().GetLastReception("pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
-	recRepo.EXPECT().UpdateReceptionStatus("rec1", "close").Return(models.Reception{Id: "rec1", Status: "close"}, nil)
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
+	recRepo.EXPECT().ChangeReceptionStatus(gomock.Any(), "rec1", "in_progress", "close", "employee", "").Return(models.Reception{Id: "rec1", Status: "close"}, nil)
+	prodRepo.EXPECT().CountProductsByReceptionIds(gomock.Any(), []string{"rec1"}, models.PVZFilter{}).Return(map[string]int{"rec1": 2}, nil)
 
-	rec, err := service.CloseLastReception("pvz1")
+	rec, err := service.CloseLastReception(context.Background(), "pvz1")
 	require.NoError(t, err)
 	assert.Equal(t, "close", rec.Status)
 }
@@ -57,14 +60,14 @@ func TestDeleteLastProduct_Success(t *testing.T) {
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 	prodRepo := mocks.NewMockProductRepository(ctrl)
 
-	service := NewPVZService(pvzRepo, recRepo, prodRepo)
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
-	recRepo.EXPECT().GetLastReception("pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
-	prodRepo.EXPECT().GetLastProduct("rec1").Return(models.Product{Id: "prod1"}, nil)
-	prodRepo.EXPECT().DeleteProduct("prod1").Return(nil)
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
+	prodRepo.EXPECT().GetLastProduct(gomock.Any(), "rec1").Return(models.Product{Id: "prod1"}, nil)
+	prodRepo.EXPECT().DeleteProduct(gomock.Any(), "prod1").Return(nil)
 
-	err := service.DeleteLastProduct("pvz1")
+	err := service.DeleteLastProduct(context.Background(), "pvz1")
 	assert.NoError(t, err)
 }
 
@@ -76,13 +79,13 @@ func TestDeleteLastProduct_NoProducts(t *testing.T) {
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 	prodRepo := mocks.NewMockProductRepository(ctrl)
 
-	service := NewPVZService(pvzRepo, recRepo, prodRepo)
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
-	recRepo.EXPECT().GetLastReception("pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
-	prodRepo.EXPECT().GetLastProduct("rec1").Return(models.Product{}, dto.ErrNoProductsInReception)
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
+	prodRepo.EXPECT().GetLastProduct(gomock.Any(), "rec1").Return(models.Product{}, dto.ErrNoProductsInReception)
 
-	err := service.DeleteLastProduct("pvz1")
+	err := service.DeleteLastProduct(context.Background(), "pvz1")
 	assert.ErrorIs(t, err, dto.ErrNoProductsInReception)
 }
 
@@ -120,15 +123,15 @@ func TestGetPVZWithPagination_EmptyDates(t *testing.T) {
 		},
 	}
 
-	mockPVZRepo.EXPECT().GetPVZsWithPagination(0, 10).Return(pvzs, nil)
+	mockPVZRepo.EXPECT().GetPVZsWithPagination(gomock.Any(), models.PVZFilter{}, 0, 10).Return(pvzs, nil)
 
-	mockRecRepo.EXPECT().GetReceptionsByPVZIds([]string{"pvz1"}, nil, nil).Return(receptions, nil)
+	mockRecRepo.EXPECT().GetReceptionsByPVZIds(gomock.Any(), []string{"pvz1"}, models.PVZFilter{}).Return(receptions, nil)
 
-	mockProdRepo.EXPECT().GetProductsByReceptionIds([]string{"rec1"}, nil, nil).Return(products, nil)
+	mockProdRepo.EXPECT().GetProductsByReceptionIds(gomock.Any(), []string{"rec1"}, models.PVZFilter{}).Return(products, nil)
 
-	service := NewPVZService(mockPVZRepo, mockRecRepo, mockProdRepo)
+	service := NewPVZService(mockPVZRepo, mockRecRepo, mockProdRepo, cache.NewNoop())
 
-	result, err := service.GetPVZWithPagination(nil, nil, 1, 10)
+	result, err := service.GetPVZWithPagination(context.Background(), models.PVZFilter{}, models.ExpandProducts, 1, 10)
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(result))
@@ -138,3 +141,222 @@ func TestGetPVZWithPagination_EmptyDates(t *testing.T) {
 	assert.Equal(t, 1, len(result[0].Receptions[0].Products))
 	assert.Equal(t, "prod1", result[0].Receptions[0].Products[0].Id)
 }
+
+func TestChangePVZStatus_Deactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
+
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZActive}, nil)
+	pvzRepo.EXPECT().UpdatePVZStatus(gomock.Any(), "pvz1", models.PVZInactive).Return(models.PVZ{Id: "pvz1", Status: models.PVZInactive}, nil)
+
+	pvz, err := service.ChangePVZStatus(context.Background(), "pvz1", models.PVZInactive)
+	require.NoError(t, err)
+	assert.Equal(t, models.PVZInactive, pvz.Status)
+}
+
+func TestChangePVZStatus_ArchivedIsFinal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
+
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZArchived}, nil)
+
+	_, err := service.ChangePVZStatus(context.Background(), "pvz1", models.PVZActive)
+	assert.ErrorIs(t, err, dto.ErrInvalidPVZStatusChange)
+}
+
+func TestUpdatePVZ_Archived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
+	address := "ул. Баумана, 10"
+
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZArchived}, nil)
+
+	_, err := service.UpdatePVZ(context.Background(), "pvz1", models.PVZUpdate{Address: &address})
+	assert.ErrorIs(t, err, dto.ErrPVZNotActive)
+}
+
+func TestUpdatePVZ_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
+	address := "ул. Баумана, 10"
+	upd := models.PVZUpdate{Address: &address}
+
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZInactive}, nil)
+	pvzRepo.EXPECT().UpdatePVZ(gomock.Any(), "pvz1", upd).Return(models.PVZ{Id: "pvz1", Address: address}, nil)
+
+	pvz, err := service.UpdatePVZ(context.Background(), "pvz1", upd)
+	require.NoError(t, err)
+	assert.Equal(t, address, pvz.Address)
+}
+
+func TestGetNearbyPVZs_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
+
+	pvzRepo.EXPECT().GetNearbyPVZs(gomock.Any(), 55.75, 37.62, 1000.0, 5).
+		Return([]models.PVZWithDistance{{PVZ: models.PVZ{Id: "pvz1"}, Distance: 42}}, nil)
+
+	pvzs, err := service.GetNearbyPVZs(context.Background(), 55.75, 37.62, 1000, 5)
+	require.NoError(t, err)
+	assert.Len(t, pvzs, 1)
+	assert.Equal(t, 42.0, pvzs[0].Distance)
+}
+
+func TestGetPVZWithPagination_ExpandPVZ(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
+
+	pvzRepo.EXPECT().GetPVZsWithPagination(gomock.Any(), models.PVZFilter{}, 0, 10).Return([]models.PVZ{{Id: "pvz1"}}, nil)
+
+	result, err := service.GetPVZWithPagination(context.Background(), models.PVZFilter{}, models.ExpandPVZ, 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Empty(t, result[0].Receptions)
+}
+
+func TestGetPVZWithPagination_ExpandReceptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewNoop())
+
+	pvzRepo.EXPECT().GetPVZsWithPagination(gomock.Any(), models.PVZFilter{}, 0, 10).Return([]models.PVZ{{Id: "pvz1"}}, nil)
+	recRepo.EXPECT().GetReceptionsByPVZIds(gomock.Any(), []string{"pvz1"}, models.PVZFilter{}).
+		Return([]models.Reception{{Id: "rec1", PVZId: "pvz1"}}, nil)
+	prodRepo.EXPECT().CountProductsByReceptionIds(gomock.Any(), []string{"rec1"}, models.PVZFilter{}).
+		Return(map[string]int{"rec1": 1500}, nil)
+
+	result, err := service.GetPVZWithPagination(context.Background(), models.PVZFilter{}, models.ExpandReceptions, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, result[0].Receptions, 1)
+	assert.Equal(t, 1500, result[0].Receptions[0].ProductCount)
+	assert.Nil(t, result[0].Receptions[0].Products)
+}
+
+func TestGetPVZWithPagination_CacheHit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewLRU(10, time.Minute, 0))
+
+	pvzRepo.EXPECT().GetPVZsWithPagination(gomock.Any(), models.PVZFilter{}, 0, 10).Return([]models.PVZ{{Id: "pvz1"}}, nil).Times(1)
+
+	first, err := service.GetPVZWithPagination(context.Background(), models.PVZFilter{}, models.ExpandPVZ, 1, 10)
+	require.NoError(t, err)
+
+	second, err := service.GetPVZWithPagination(context.Background(), models.PVZFilter{}, models.ExpandPVZ, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestGetAllPVZs_InvalidatedDuringLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	pvzCache := cache.NewLRU(10, time.Minute, 0)
+	service := NewPVZService(pvzRepo, nil, nil, pvzCache)
+
+	// запись завершилась и сбросила кеш, пока шло чтение: его результат
+	// не должен остаться в кеше
+	pvzRepo.EXPECT().GetAllPVZs(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]models.PVZ, error) {
+		require.NoError(t, pvzCache.Invalidate(ctx))
+		return []models.PVZ{{Id: "pvz1"}}, nil
+	})
+	pvzRepo.EXPECT().GetAllPVZs(gomock.Any()).Return([]models.PVZ{{Id: "pvz1"}, {Id: "pvz2"}}, nil)
+
+	_, err := service.GetAllPVZs(context.Background())
+	require.NoError(t, err)
+
+	pvzs, err := service.GetAllPVZs(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, pvzs, 2)
+}
+
+func TestGetAllPVZs_NotCachedWhileReplicasLag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	pvzCache := cache.NewLRU(10, time.Minute, time.Minute)
+	service := NewPVZService(pvzRepo, nil, nil, pvzCache)
+
+	require.NoError(t, pvzCache.Invalidate(context.Background()))
+	pvzRepo.EXPECT().GetAllPVZs(gomock.Any()).Return([]models.PVZ{{Id: "pvz1"}}, nil).Times(2)
+
+	_, err := service.GetAllPVZs(context.Background())
+	require.NoError(t, err)
+	_, err = service.GetAllPVZs(context.Background())
+	require.NoError(t, err)
+}
+
+func TestDeleteLastProduct_InvalidatesCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+
+	service := NewPVZService(pvzRepo, recRepo, prodRepo, cache.NewLRU(10, time.Minute, 0))
+
+	pvzRepo.EXPECT().GetAllPVZs(gomock.Any()).Return([]models.PVZ{{Id: "pvz1"}}, nil).Times(2)
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
+	prodRepo.EXPECT().GetLastProduct(gomock.Any(), "rec1").Return(models.Product{Id: "prod1"}, nil)
+	prodRepo.EXPECT().DeleteProduct(gomock.Any(), "prod1").Return(nil)
+
+	_, err := service.GetAllPVZs(context.Background())
+	require.NoError(t, err)
+	_, err = service.GetAllPVZs(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, service.DeleteLastProduct(context.Background(), "pvz1"))
+
+	_, err = service.GetAllPVZs(context.Background())
+	require.NoError(t, err)
+}
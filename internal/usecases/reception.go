@@ -2,46 +2,201 @@
 package usecases
 
 import (
+	"context"
+	"slices"
 	"time"
 
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.uber.org/zap"
 )
 
 type ReceptionRepository interface {
-	GetLastReception(pvzId string) (models.Reception, error)
-	CreateReception(pvzId string) (models.Reception, error)
-	UpdateReceptionStatus(recId, status string) (models.Reception, error)
-	GetReceptionsByPVZIds(pvzIds []string, startDate, endDate *time.Time) ([]models.Reception, error)
+	GetLastReception(ctx context.Context, pvzId string) (models.Reception, error)
+	CreateReception(ctx context.Context, pvzId, role string) (models.Reception, error)
+	HasOpenReception(ctx context.Context, pvzId string) (bool, error)
+	GetReceptionById(ctx context.Context, recId string) (models.Reception, error)
+	ChangeReceptionStatus(ctx context.Context, recId, fromStatus, toStatus, role, reason string) (models.Reception, error)
+	GetStatusHistory(ctx context.Context, recId string) ([]models.ReceptionStatusChange, error)
+	GetReceptionsByPVZIds(ctx context.Context, pvzIds []string, filter models.PVZFilter) ([]models.Reception, error)
+	GetReceptionsByPVZ(ctx context.Context, pvzId string, filter models.ReceptionListFilter, offset, limit int) ([]models.Reception, error)
+	StreamReceptionsForExport(ctx context.Context, filter models.PVZFilter, fn func(row models.ReceptionExportRow) error) error
+	CloseStaleReceptions(ctx context.Context, maxAge, maxIdle time.Duration) ([]models.Reception, error)
+	GetOpenReceptionStats(ctx context.Context) ([]models.OpenReceptionStats, error)
+}
+
+// receptionTransitions описывает допустимые переходы статусов приемки
+// и роли, которым разрешен каждый переход
+var receptionTransitions = map[string]map[string][]string{
+	models.INPROGRESS: {
+		models.PAUSED:    {dto.RoleEmployee},
+		models.CLOSE:     {dto.RoleEmployee},
+		models.CANCELLED: {dto.RoleEmployee, dto.RoleModerator},
+	},
+	models.PAUSED: {
+		models.INPROGRESS: {dto.RoleEmployee},
+		models.CLOSE:      {dto.RoleEmployee},
+		models.CANCELLED:  {dto.RoleEmployee, dto.RoleModerator},
+	},
+	models.CLOSE: {
+		models.REOPENED: {dto.RoleModerator},
+	},
+	models.REOPENED: {
+		models.CLOSE: {dto.RoleEmployee, dto.RoleModerator},
+	},
+}
+
+func validateReceptionTransition(fromStatus, toStatus, role string) error {
+	roles, ok := receptionTransitions[fromStatus][toStatus]
+	if !ok {
+		return dto.ErrInvalidStatusChange
+	}
+
+	if !slices.Contains(roles, role) {
+		return dto.ErrStatusChangeForbidden
+	}
+
+	return nil
+}
+
+func isReceptionOpen(status string) bool {
+	return status != models.CLOSE && status != models.CANCELLED
+}
+
+func isReceptionEditable(status string) bool {
+	return status == models.INPROGRESS || status == models.REOPENED
 }
 
 type ReceptionService struct {
-	pvzRepo PVZRepository
-	recRepo ReceptionRepository
+	pvzRepo  PVZRepository
+	recRepo  ReceptionRepository
+	prodRepo ProductRepository
+	cache    Cache
 }
 
-func NewReceptionService(pvzRepo PVZRepository, recRepo ReceptionRepository) *ReceptionService {
+func NewReceptionService(pvzRepo PVZRepository, recRepo ReceptionRepository, prodRepo ProductRepository, cache Cache) *ReceptionService {
 	return &ReceptionService{
-		pvzRepo: pvzRepo,
-		recRepo: recRepo,
+		pvzRepo:  pvzRepo,
+		recRepo:  recRepo,
+		prodRepo: prodRepo,
+		cache:    cache,
 	}
 }
 
-func (rs *ReceptionService) CreateReception(pvzId string) (models.Reception, error) {
-	_, err := rs.pvzRepo.GetPVZById(pvzId)
+func (rs *ReceptionService) CreateReception(ctx context.Context, pvzId string) (models.Reception, error) {
+	pvz, err := rs.pvzRepo.GetPVZById(ctx, pvzId)
 	if err != nil {
 		return models.Reception{}, err
 	}
 
-	lastReception, err := rs.recRepo.GetLastReception(pvzId)
-	if lastReception.Id != "" && lastReception.Status == "in_progress" {
+	if pvz.Status != models.PVZActive {
+		return models.Reception{}, dto.ErrPVZNotActive
+	}
+
+	lastReception, err := rs.recRepo.GetLastReception(ctx, pvzId)
+	if lastReception.Id != "" && isReceptionOpen(lastReception.Status) {
 		return models.Reception{}, dto.ErrPVZAlreadyHasReception
 	}
 
-	newReception, err := rs.recRepo.CreateReception(pvzId)
+	// создавать приемки может только сотрудник, он же автор первой записи истории
+	newReception, err := rs.recRepo.CreateReception(ctx, pvzId, dto.RoleEmployee)
 	if err != nil {
 		return models.Reception{}, err
 	}
 
+	logger.FromContext(ctx, zap.S()).Infof("reception %s of pvz %s created", newReception.Id, pvzId)
+	metrics.ReceptionsCreated.WithLabelValues(pvz.City).Inc()
+
+	invalidatePVZCache(ctx, rs.cache)
+
 	return newReception, nil
 }
+
+func (rs *ReceptionService) ChangeReceptionStatus(ctx context.Context, recId, status, role, reason string) (models.Reception, error) {
+	reception, err := rs.recRepo.GetReceptionById(ctx, recId)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	err = validateReceptionTransition(reception.Status, status, role)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	if status == models.REOPENED {
+		if err = rs.checkReopen(ctx, reception); err != nil {
+			return models.Reception{}, err
+		}
+	}
+
+	updRec, err := rs.recRepo.ChangeReceptionStatus(ctx, reception.Id, reception.Status, status, role, reason)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	logger.FromContext(ctx, zap.S()).Infof("reception %s status changed from %s to %s by %s", reception.Id, reception.Status, status, role)
+	if !isReceptionOpen(status) {
+		observeReceptionClosed(ctx, rs.prodRepo, updRec)
+	}
+
+	invalidatePVZCache(ctx, rs.cache)
+
+	return updRec, nil
+}
+
+// checkReopen разрешает переоткрыть только последнюю приемку ПВЗ и только
+// когда у ПВЗ нет другой открытой приемки. Товары добавляются и удаляются
+// в последней приемке, поэтому переоткрытая старая приемка осталась бы
+// недоступной для исправления, а у ПВЗ оказалось бы две открытые приемки
+func (rs *ReceptionService) checkReopen(ctx context.Context, reception models.Reception) error {
+	last, err := rs.recRepo.GetLastReception(ctx, reception.PVZId)
+	if err != nil {
+		return err
+	}
+	if last.Id != reception.Id {
+		return dto.ErrReceptionNotLast
+	}
+
+	open, err := rs.recRepo.HasOpenReception(ctx, reception.PVZId)
+	if err != nil {
+		return err
+	}
+	if open {
+		return dto.ErrPVZAlreadyHasReception
+	}
+
+	return nil
+}
+
+func (rs *ReceptionService) GetStatusHistory(ctx context.Context, recId string) ([]models.ReceptionStatusChange, error) {
+	_, err := rs.recRepo.GetReceptionById(ctx, recId)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.recRepo.GetStatusHistory(ctx, recId)
+}
+
+func (rs *ReceptionService) GetPVZReceptions(ctx context.Context, pvzId string, filter models.ReceptionListFilter, page, limit int) ([]models.Reception, error) {
+	_, err := rs.pvzRepo.GetPVZById(ctx, pvzId)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.recRepo.GetReceptionsByPVZ(ctx, pvzId, filter, (page-1)*limit, limit)
+}
+
+func (rs *ReceptionService) ExportReceptions(
+	ctx context.Context,
+	filter models.PVZFilter,
+	fn func(row models.ReceptionExportRow) error,
+) error {
+	return rs.recRepo.StreamReceptionsForExport(ctx, filter, fn)
+}
+
+// OpenReceptionStats возвращает открытые приемки по городам для метрик
+func (rs *ReceptionService) OpenReceptionStats(ctx context.Context) ([]models.OpenReceptionStats, error) {
+	return rs.recRepo.GetOpenReceptionStats(ctx)
+}
@@ -0,0 +1,102 @@
+//go:generate mockgen -source=reception_closer.go -destination=./mocks/mock_reception_closer.go -package=mocks
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/hamillka/avitoTechSpring25/internal/autoclose"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/metrics"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+const tracerName = "github.com/hamillka/avitoTechSpring25/internal/usecases"
+
+type LeaderElector interface {
+	IsLeader(ctx context.Context) (bool, error)
+	Resign(ctx context.Context) error
+}
+
+type ReceptionCloser struct {
+	recRepo  ReceptionRepository
+	prodRepo ProductRepository
+	elector  LeaderElector
+	cache    Cache
+	cfg      autoclose.Config
+	logger   *zap.SugaredLogger
+}
+
+func NewReceptionCloser(
+	recRepo ReceptionRepository,
+	prodRepo ProductRepository,
+	elector LeaderElector,
+	cache Cache,
+	cfg autoclose.Config,
+	logger *zap.SugaredLogger,
+) *ReceptionCloser {
+	return &ReceptionCloser{
+		recRepo:  recRepo,
+		prodRepo: prodRepo,
+		elector:  elector,
+		cache:    cache,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+func (rc *ReceptionCloser) Run(ctx context.Context) {
+	ticker := time.NewTicker(rc.cfg.Interval)
+	defer ticker.Stop()
+
+	defer func() {
+		if err := rc.elector.Resign(context.Background()); err != nil {
+			rc.logger.Errorf("failed to resign leadership: %v", err)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// у фонового прохода нет входящего запроса, поэтому он начинает свою трассу
+			tickCtx, span := otel.Tracer(tracerName).Start(ctx, "ReceptionCloser.CloseStale")
+			if err := rc.CloseStale(tickCtx); err != nil {
+				logger.FromContext(tickCtx, rc.logger).Errorf("failed to close stale receptions: %v", err)
+			}
+			span.End()
+		}
+	}
+}
+
+func (rc *ReceptionCloser) CloseStale(ctx context.Context) error {
+	if rc.cfg.MaxAge <= 0 && rc.cfg.MaxIdle <= 0 {
+		return nil
+	}
+
+	leader, err := rc.elector.IsLeader(ctx)
+	if err != nil || !leader {
+		return err
+	}
+
+	closed, err := rc.recRepo.CloseStaleReceptions(ctx, rc.cfg.MaxAge, rc.cfg.MaxIdle)
+	if err != nil {
+		return err
+	}
+
+	for _, reception := range closed {
+		logger.FromContext(ctx, rc.logger).Infof("reception %s of pvz %s auto-closed: %s", reception.Id, reception.PVZId, reception.CloseReason)
+		metrics.ReceptionsAutoClosed.WithLabelValues(reception.CloseReason).Inc()
+		observeReceptionClosed(ctx, rc.prodRepo, reception)
+	}
+
+	if len(closed) > 0 {
+		if err = rc.cache.Invalidate(ctx); err != nil {
+			logger.FromContext(ctx, rc.logger).Errorf("failed to invalidate pvz cache: %v", err)
+		}
+	}
+
+	return nil
+}
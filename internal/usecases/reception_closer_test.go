@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/autoclose"
+	"github.com/hamillka/avitoTechSpring25/internal/cache"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/hamillka/avitoTechSpring25/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestReceptionCloser_CloseStale_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+	elector := mocks.NewMockLeaderElector(ctrl)
+	cfg := autoclose.Config{MaxAge: 24 * time.Hour, MaxIdle: time.Hour}
+
+	closer := NewReceptionCloser(recRepo, prodRepo, elector, cache.NewNoop(), cfg, zaptest.NewLogger(t).Sugar())
+
+	elector.EXPECT().IsLeader(gomock.Any()).Return(true, nil)
+	recRepo.EXPECT().CloseStaleReceptions(gomock.Any(), 24*time.Hour, time.Hour).Return([]models.Reception{
+		{Id: "rec1", PVZId: "pvz1", Status: models.CLOSE, CloseReason: models.CloseReasonIdle},
+	}, nil)
+	prodRepo.EXPECT().CountProductsByReceptionIds(gomock.Any(), []string{"rec1"}, models.PVZFilter{}).Return(map[string]int{"rec1": 3}, nil)
+
+	err := closer.CloseStale(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestReceptionCloser_CloseStale_NotLeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+	elector := mocks.NewMockLeaderElector(ctrl)
+	cfg := autoclose.Config{MaxAge: 24 * time.Hour}
+
+	closer := NewReceptionCloser(recRepo, prodRepo, elector, cache.NewNoop(), cfg, zaptest.NewLogger(t).Sugar())
+
+	elector.EXPECT().IsLeader(gomock.Any()).Return(false, nil)
+
+	err := closer.CloseStale(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestReceptionCloser_CloseStale_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+	elector := mocks.NewMockLeaderElector(ctrl)
+
+	closer := NewReceptionCloser(recRepo, prodRepo, elector, cache.NewNoop(), autoclose.Config{}, zaptest.NewLogger(t).Sugar())
+
+	err := closer.CloseStale(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestReceptionCloser_CloseStale_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	prodRepo := mocks.NewMockProductRepository(ctrl)
+	elector := mocks.NewMockLeaderElector(ctrl)
+	cfg := autoclose.Config{MaxIdle: time.Hour}
+
+	closer := NewReceptionCloser(recRepo, prodRepo, elector, cache.NewNoop(), cfg, zaptest.NewLogger(t).Sugar())
+
+	elector.EXPECT().IsLeader(gomock.Any()).Return(true, nil)
+	recRepo.EXPECT().CloseStaleReceptions(gomock.Any(), time.Duration(0), time.Hour).Return(nil, errors.New("db error"))
+
+	err := closer.CloseStale(context.Background())
+
+	assert.Error(t, err)
+}
@@ -1,11 +1,14 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/hamillka/avitoTechSpring25/internal/cache"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
 	"github.com/hamillka/avitoTechSpring25/internal/usecases/mocks"
@@ -20,13 +23,13 @@ func TestCreateReception_Success(t *testing.T) {
 	pvzRepo := mocks.NewMockPVZRepository(ctrl)
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 
-	service := NewReceptionService(pvzRepo, recRepo)
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
-	recRepo.EXPECT().GetLastReception("pvz1").Return(models.Reception{Status: "close"}, nil)
-	recRepo.EXPECT().CreateReception("pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZActive}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(models.Reception{Status: "close"}, nil)
+	recRepo.EXPECT().CreateReception(gomock.Any(), "pvz1", dto.RoleEmployee).Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
 
-	reception, err := service.CreateReception("pvz1")
+	reception, err := service.CreateReception(context.Background(), "pvz1")
 
 	require.NoError(t, err)
 	assert.Equal(t, "rec1", reception.Id)
@@ -40,11 +43,11 @@ func TestCreateReception_PVZNotFound(t *testing.T) {
 	pvzRepo := mocks.NewMockPVZRepository(ctrl)
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 
-	service := NewReceptionService(pvzRepo, recRepo)
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("unknown").Return(models.PVZ{}, errors.New("not found"))
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "unknown").Return(models.PVZ{}, errors.New("not found"))
 
-	_, err := service.CreateReception("unknown")
+	_, err := service.CreateReception(context.Background(), "unknown")
 
 	assert.Error(t, err)
 }
@@ -56,12 +59,227 @@ func TestCreateReception_AlreadyExists(t *testing.T) {
 	pvzRepo := mocks.NewMockPVZRepository(ctrl)
 	recRepo := mocks.NewMockReceptionRepository(ctrl)
 
-	service := NewReceptionService(pvzRepo, recRepo)
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
 
-	pvzRepo.EXPECT().GetPVZById("pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
-	recRepo.EXPECT().GetLastReception("pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZActive}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(models.Reception{Id: "rec1", Status: "in_progress"}, nil)
 
-	_, err := service.CreateReception("pvz1")
+	_, err := service.CreateReception(context.Background(), "pvz1")
 
 	assert.ErrorIs(t, err, dto.ErrPVZAlreadyHasReception)
 }
+
+func TestExportReceptions_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	filter := models.PVZFilter{Cities: []string{"Москва"}}
+	recRepo.EXPECT().StreamReceptionsForExport(gomock.Any(), filter, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.PVZFilter, fn func(models.ReceptionExportRow) error) error {
+			return fn(models.ReceptionExportRow{ReceptionId: "rec1"})
+		})
+
+	var rows []models.ReceptionExportRow
+	err := service.ExportReceptions(context.Background(), filter, func(row models.ReceptionExportRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "rec1", rows[0].ReceptionId)
+}
+
+func TestChangeReceptionStatus_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	recRepo.EXPECT().GetReceptionById(gomock.Any(), "rec1").Return(models.Reception{Id: "rec1", Status: models.INPROGRESS}, nil)
+	recRepo.EXPECT().ChangeReceptionStatus(gomock.Any(), "rec1", models.INPROGRESS, models.PAUSED, dto.RoleEmployee, "lunch").
+		Return(models.Reception{Id: "rec1", Status: models.PAUSED}, nil)
+
+	reception, err := service.ChangeReceptionStatus(context.Background(), "rec1", models.PAUSED, dto.RoleEmployee, "lunch")
+
+	require.NoError(t, err)
+	assert.Equal(t, models.PAUSED, reception.Status)
+}
+
+func TestChangeReceptionStatus_InvalidTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	recRepo.EXPECT().GetReceptionById(gomock.Any(), "rec1").Return(models.Reception{Id: "rec1", Status: models.CANCELLED}, nil)
+
+	_, err := service.ChangeReceptionStatus(context.Background(), "rec1", models.INPROGRESS, dto.RoleEmployee, "")
+
+	assert.ErrorIs(t, err, dto.ErrInvalidStatusChange)
+}
+
+func TestChangeReceptionStatus_ReopenForbiddenForEmployee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	recRepo.EXPECT().GetReceptionById(gomock.Any(), "rec1").Return(models.Reception{Id: "rec1", Status: models.CLOSE}, nil)
+
+	_, err := service.ChangeReceptionStatus(context.Background(), "rec1", models.REOPENED, dto.RoleEmployee, "")
+
+	assert.ErrorIs(t, err, dto.ErrStatusChangeForbidden)
+}
+
+func TestChangeReceptionStatus_Reopen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+	service := NewReceptionService(mocks.NewMockPVZRepository(ctrl), recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	closed := models.Reception{Id: "rec1", PVZId: "pvz1", Status: models.CLOSE}
+	recRepo.EXPECT().GetReceptionById(gomock.Any(), "rec1").Return(closed, nil).Times(3)
+
+	// новее есть другая приемка: старую переоткрыть нельзя
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").
+		Return(models.Reception{Id: "rec2", PVZId: "pvz1", Status: models.INPROGRESS}, nil)
+	_, err := service.ChangeReceptionStatus(context.Background(), "rec1", models.REOPENED, dto.RoleModerator, "typo")
+	assert.ErrorIs(t, err, dto.ErrReceptionNotLast)
+
+	// последняя, но у ПВЗ уже есть открытая приемка
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(closed, nil)
+	recRepo.EXPECT().HasOpenReception(gomock.Any(), "pvz1").Return(true, nil)
+	_, err = service.ChangeReceptionStatus(context.Background(), "rec1", models.REOPENED, dto.RoleModerator, "typo")
+	assert.ErrorIs(t, err, dto.ErrPVZAlreadyHasReception)
+
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(closed, nil)
+	recRepo.EXPECT().HasOpenReception(gomock.Any(), "pvz1").Return(false, nil)
+	recRepo.EXPECT().ChangeReceptionStatus(gomock.Any(), "rec1", models.CLOSE, models.REOPENED, dto.RoleModerator, "typo").
+		Return(models.Reception{Id: "rec1", PVZId: "pvz1", Status: models.REOPENED}, nil)
+	reception, err := service.ChangeReceptionStatus(context.Background(), "rec1", models.REOPENED, dto.RoleModerator, "typo")
+	require.NoError(t, err)
+	assert.Equal(t, models.REOPENED, reception.Status)
+}
+
+func TestCreateReception_PausedBlocksNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZActive}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(models.Reception{Id: "rec1", Status: models.PAUSED}, nil)
+
+	_, err := service.CreateReception(context.Background(), "pvz1")
+
+	assert.ErrorIs(t, err, dto.ErrPVZAlreadyHasReception)
+}
+
+func TestGetStatusHistory_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	recRepo.EXPECT().GetReceptionById(gomock.Any(), "rec404").Return(models.Reception{}, dto.ErrReceptionNotFound)
+
+	_, err := service.GetStatusHistory(context.Background(), "rec404")
+
+	assert.ErrorIs(t, err, dto.ErrReceptionNotFound)
+}
+
+func TestCreateReception_PVZNotActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZInactive}, nil)
+
+	_, err := service.CreateReception(context.Background(), "pvz1")
+
+	assert.ErrorIs(t, err, dto.ErrPVZNotActive)
+}
+
+func TestGetPVZReceptions_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	filter := models.ReceptionListFilter{Statuses: []string{models.CLOSE}}
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1"}, nil)
+	recRepo.EXPECT().GetReceptionsByPVZ(gomock.Any(), "pvz1", filter, 20, 10).Return([]models.Reception{{Id: "rec1"}}, nil)
+
+	receptions, err := service.GetPVZReceptions(context.Background(), "pvz1", filter, 3, 10)
+
+	require.NoError(t, err)
+	assert.Len(t, receptions, 1)
+}
+
+func TestGetPVZReceptions_PVZNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), cache.NewNoop())
+
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz404").Return(models.PVZ{}, dto.ErrPVZNotFound)
+
+	_, err := service.GetPVZReceptions(context.Background(), "pvz404", models.ReceptionListFilter{}, 1, 10)
+
+	assert.ErrorIs(t, err, dto.ErrPVZNotFound)
+}
+
+func TestCreateReception_InvalidatesCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pvzRepo := mocks.NewMockPVZRepository(ctrl)
+	recRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	pvzCache := cache.NewLRU(10, time.Minute, 0)
+	require.NoError(t, pvzCache.Set(context.Background(), "key", []byte("value")))
+
+	service := NewReceptionService(pvzRepo, recRepo, mocks.NewMockProductRepository(ctrl), pvzCache)
+
+	pvzRepo.EXPECT().GetPVZById(gomock.Any(), "pvz1").Return(models.PVZ{Id: "pvz1", Status: models.PVZActive}, nil)
+	recRepo.EXPECT().GetLastReception(gomock.Any(), "pvz1").Return(models.Reception{}, dto.ErrReceptionNotFound)
+	recRepo.EXPECT().CreateReception(gomock.Any(), "pvz1", dto.RoleEmployee).Return(models.Reception{Id: "rec1"}, nil)
+
+	_, err := service.CreateReception(context.Background(), "pvz1")
+	require.NoError(t, err)
+
+	_, ok, _ := pvzCache.Get(context.Background(), "key")
+	assert.False(t, ok)
+}
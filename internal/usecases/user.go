@@ -2,38 +2,100 @@
 package usecases
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/logger"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserRepository interface {
-	UserRegister(email, password, role string) (models.User, error)
-	UserLogin(email, password string) (models.User, error)
+	UserRegister(ctx context.Context, email, password, role string) (models.User, error)
+	UserLogin(ctx context.Context, email, password string) (models.User, error)
+	GetUserById(ctx context.Context, userId string) (models.User, error)
+	UpdatePassword(ctx context.Context, userId, password string) error
+	ListUsers(ctx context.Context, filter models.UserListFilter, page, limit int) ([]models.User, int, error)
+	SetUserDisabledAt(ctx context.Context, userId string, disabledAt *time.Time) (models.User, error)
+	SetUserRole(ctx context.Context, userId, role string) (models.User, error)
 }
 
 type UserService struct {
-	userRepo UserRepository
+	userRepo    UserRepository
+	failureRepo LoginFailureRepository
+	resetRepo   PasswordResetRepository
+	policy      PasswordPolicy
+	notifier    Notifier
+	loginCfg    LoginProtectionConfig
+	resetCfg    PasswordResetConfig
+	regCfg      RegistrationConfig
+	now         func() time.Time
 }
 
-func NewUserService(userRepo UserRepository) *UserService {
+func NewUserService(
+	userRepo UserRepository,
+	failureRepo LoginFailureRepository,
+	resetRepo PasswordResetRepository,
+	policy PasswordPolicy,
+	notifier Notifier,
+	loginCfg LoginProtectionConfig,
+	resetCfg PasswordResetConfig,
+	regCfg RegistrationConfig,
+) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		failureRepo: failureRepo,
+		resetRepo:   resetRepo,
+		policy:      policy,
+		notifier:    notifier,
+		loginCfg:    loginCfg,
+		resetCfg:    resetCfg,
+		regCfg:      regCfg,
+		now:         time.Now,
+	}
+}
+
+// UserRegister регистрирует пользователя по его собственному запросу. Так можно
+// получить только роль employee, а в режиме invite регистрация закрыта:
+// пользователей заводит модератор через CreateUser
+func (us *UserService) UserRegister(ctx context.Context, email, password, role string) (models.User, error) {
+	if us.regCfg.Mode == RegistrationInvite {
+		return models.User{}, dto.ErrRegistrationClosed
 	}
+
+	if role != dto.RoleEmployee {
+		securityLogger(ctx).Warnw("self-registration rejected",
+			"event", "registration_role_rejected",
+			"email", email,
+			"role", role,
+		)
+		return models.User{}, dto.ErrRoleNotAllowed
+	}
+
+	return us.createUser(ctx, email, password, role)
 }
 
-func (us *UserService) UserRegister(email, password, role string) (models.User, error) {
-	existingUser, err := us.userRepo.UserLogin(email, password)
+func (us *UserService) createUser(ctx context.Context, email, password, role string) (models.User, error) {
+	email = normalizeEmail(email)
+
+	if err := us.policy.Validate(password); err != nil {
+		return models.User{}, err
+	}
+
+	existingUser, err := us.userRepo.UserLogin(ctx, email, password)
 	if err == nil && existingUser.Id != "" {
 		return models.User{}, dto.ErrUserAlreadyExists
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(password)
 	if err != nil {
 		return models.User{}, err
 	}
 
-	user, err := us.userRepo.UserRegister(email, string(hashedPassword), role)
+	user, err := us.userRepo.UserRegister(ctx, email, hashedPassword, role)
 	if err != nil {
 		return models.User{}, err
 	}
@@ -41,8 +103,53 @@ func (us *UserService) UserRegister(email, password, role string) (models.User,
 	return user, nil
 }
 
-func (us *UserService) UserLogin(email, password string) (models.User, error) {
-	user, err := us.userRepo.UserLogin(email, password)
+// UserLogin проверяет учетные данные. ip — адрес клиента для счетчика неудач
+// по IP, пустой адрес учитывается только по учетной записи
+func (us *UserService) UserLogin(ctx context.Context, email, password, ip string) (models.User, error) {
+	email = normalizeEmail(email)
+
+	if !us.loginCfg.Enabled {
+		return us.checkCredentials(ctx, email, password)
+	}
+
+	subjects := us.loginSubjects(email, ip)
+	if err := us.checkLoginBlocked(ctx, subjects, email, ip); err != nil {
+		return models.User{}, err
+	}
+
+	user, err := us.checkCredentials(ctx, email, password)
+	if err != nil {
+		if isCredentialsError(err) {
+			us.recordLoginFailure(ctx, subjects, email, ip)
+		}
+		return models.User{}, err
+	}
+
+	// счетчик по IP не сбрасывается: иначе перебор чужих паролей можно было бы
+	// разбавлять входом в свою учетную запись
+	if err = us.failureRepo.ResetLoginFailures(ctx, accountLoginKey(email)); err != nil {
+		logger.FromContext(ctx, zap.S()).Warnf("failed to reset login failures: %v", err)
+	}
+
+	securityLogger(ctx).Infow("login succeeded",
+		"event", "login_succeeded",
+		"user_id", user.Id,
+		"email", email,
+		"ip", ip,
+	)
+
+	return user, nil
+}
+
+// normalizeEmail приводит почту к виду, в котором она хранится: в схеме
+// почта уникальна без учета регистра, и все входы в сервис должны
+// сравнивать ее одинаково
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (us *UserService) checkCredentials(ctx context.Context, email, password string) (models.User, error) {
+	user, err := us.userRepo.UserLogin(ctx, email, password)
 	if err != nil {
 		return models.User{}, err
 	}
@@ -52,5 +159,16 @@ func (us *UserService) UserLogin(email, password string) (models.User, error) {
 		return models.User{}, err
 	}
 
+	// пароль уже проверен, поэтому ответ об отключенной учетной записи не
+	// помогает перебирать почты
+	if user.Disabled() {
+		securityLogger(ctx).Warnw("login rejected",
+			"event", "login_disabled",
+			"user_id", user.Id,
+			"email", email,
+		)
+		return models.User{}, dto.ErrUserDisabled
+	}
+
 	return user, nil
 }
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+)
+
+// RegistrationMode — режим самостоятельной регистрации через /register
+type RegistrationMode string
+
+const (
+	RegistrationOpen   RegistrationMode = "open"
+	RegistrationInvite RegistrationMode = "invite"
+)
+
+func (m *RegistrationMode) Decode(value string) error {
+	switch mode := RegistrationMode(value); mode {
+	case RegistrationOpen, RegistrationInvite:
+		*m = mode
+		return nil
+	default:
+		return fmt.Errorf("unknown registration mode %q", value)
+	}
+}
+
+// RegistrationConfig описывает самостоятельную регистрацию. В режиме open
+// любой может зарегистрироваться сотрудником, в режиме invite пользователей
+// заводит только модератор
+type RegistrationConfig struct {
+	Mode RegistrationMode `default:"open" envconfig:"MODE"`
+}
+
+// CreateUser заводит пользователя с любой ролью от имени модератора, в том
+// числе в режиме invite
+func (us *UserService) CreateUser(ctx context.Context, actorId, email, password, role string) (models.User, error) {
+	user, err := us.createUser(ctx, email, password, role)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	securityLogger(ctx).Infow("user created",
+		"event", "user_created",
+		"actor_id", actorId,
+		"user_id", user.Id,
+		"email", user.Email,
+		"role", user.Role,
+	)
+
+	return user, nil
+}
+
+func (us *UserService) ListUsers(
+	ctx context.Context,
+	filter models.UserListFilter,
+	page, limit int,
+) ([]models.User, int, error) {
+	return us.userRepo.ListUsers(ctx, filter, page, limit)
+}
+
+func (us *UserService) GetUser(ctx context.Context, userId string) (models.User, error) {
+	return us.userRepo.GetUserById(ctx, userId)
+}
+
+// DisableUser отключает учетную запись: вход и уже выданные токены
+// пользователя перестают работать. Отключить себя модератор не может,
+// иначе можно остаться без единого модератора
+func (us *UserService) DisableUser(ctx context.Context, actorId, userId string) (models.User, error) {
+	if actorId == userId {
+		return models.User{}, dto.ErrSelfModification
+	}
+
+	existing, err := us.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		return models.User{}, err
+	}
+	// повторное отключение не сдвигает момент отключения
+	if existing.Disabled() {
+		return existing, nil
+	}
+
+	now := us.now()
+	user, err := us.userRepo.SetUserDisabledAt(ctx, userId, &now)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	securityLogger(ctx).Infow("user disabled",
+		"event", "user_disabled",
+		"actor_id", actorId,
+		"user_id", user.Id,
+		"email", user.Email,
+	)
+
+	return user, nil
+}
+
+func (us *UserService) EnableUser(ctx context.Context, actorId, userId string) (models.User, error) {
+	user, err := us.userRepo.SetUserDisabledAt(ctx, userId, nil)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	securityLogger(ctx).Infow("user enabled",
+		"event", "user_enabled",
+		"actor_id", actorId,
+		"user_id", user.Id,
+		"email", user.Email,
+	)
+
+	return user, nil
+}
+
+// ChangeUserRole меняет роль пользователя. Новая роль действует сразу и для
+// выданных ранее токенов: ее подставляет проверка токена через ActiveUserRole
+func (us *UserService) ChangeUserRole(ctx context.Context, actorId, userId, role string) (models.User, error) {
+	if actorId == userId {
+		return models.User{}, dto.ErrSelfModification
+	}
+
+	user, err := us.userRepo.SetUserRole(ctx, userId, role)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	securityLogger(ctx).Infow("user role changed",
+		"event", "user_role_changed",
+		"actor_id", actorId,
+		"user_id", user.Id,
+		"email", user.Email,
+		"role", user.Role,
+	)
+
+	return user, nil
+}
+
+// ActiveUserRole возвращает текущую роль пользователя из токена или
+// dto.ErrUserDisabled, если учетная запись отключена
+func (us *UserService) ActiveUserRole(ctx context.Context, userId string) (string, error) {
+	user, err := us.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+
+	if user.Disabled() {
+		return "", dto.ErrUserDisabled
+	}
+
+	return user.Role, nil
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/hamillka/avitoTechSpring25/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAdminUserService(ctrl *gomock.Controller, mode RegistrationMode) (*UserService, *mocks.MockUserRepository, time.Time) {
+	repo := mocks.NewMockUserRepository(ctrl)
+	service := NewUserService(repo, nil, nil, testPolicy, nil,
+		LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{Mode: mode})
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	return service, repo, now
+}
+
+func TestCreateUser_InviteMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, repo, _ := newAdminUserService(ctrl, RegistrationInvite)
+
+	repo.EXPECT().UserLogin(gomock.Any(), "mod@example.com", "password").Return(models.User{}, dto.ErrInvalidCredentials)
+	repo.EXPECT().UserRegister(gomock.Any(), "mod@example.com", gomock.Any(), dto.RoleModerator).
+		Return(models.User{Id: "u2", Email: "mod@example.com", Role: dto.RoleModerator}, nil)
+
+	user, err := service.CreateUser(context.Background(), "u1", "mod@example.com", "password", dto.RoleModerator)
+	require.NoError(t, err)
+	assert.Equal(t, dto.RoleModerator, user.Role)
+}
+
+func TestDisableUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, repo, now := newAdminUserService(ctrl, RegistrationOpen)
+
+	_, err := service.DisableUser(context.Background(), "u1", "u1")
+	assert.ErrorIs(t, err, dto.ErrSelfModification)
+
+	repo.EXPECT().GetUserById(gomock.Any(), "u2").Return(models.User{Id: "u2"}, nil)
+	repo.EXPECT().SetUserDisabledAt(gomock.Any(), "u2", &now).
+		Return(models.User{Id: "u2", DisabledAt: &now}, nil)
+
+	user, err := service.DisableUser(context.Background(), "u1", "u2")
+	require.NoError(t, err)
+	assert.True(t, user.Disabled())
+
+	earlier := now.Add(-time.Hour)
+	repo.EXPECT().GetUserById(gomock.Any(), "u3").Return(models.User{Id: "u3", DisabledAt: &earlier}, nil)
+
+	user, err = service.DisableUser(context.Background(), "u1", "u3")
+	require.NoError(t, err)
+	assert.Equal(t, earlier, *user.DisabledAt)
+
+	repo.EXPECT().GetUserById(gomock.Any(), "missing").Return(models.User{}, dto.ErrUserNotFound)
+
+	_, err = service.DisableUser(context.Background(), "u1", "missing")
+	assert.ErrorIs(t, err, dto.ErrUserNotFound)
+}
+
+func TestChangeUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, repo, _ := newAdminUserService(ctrl, RegistrationOpen)
+
+	_, err := service.ChangeUserRole(context.Background(), "u1", "u1", dto.RoleEmployee)
+	assert.ErrorIs(t, err, dto.ErrSelfModification)
+
+	repo.EXPECT().SetUserRole(gomock.Any(), "u2", dto.RoleModerator).
+		Return(models.User{Id: "u2", Role: dto.RoleModerator}, nil)
+
+	user, err := service.ChangeUserRole(context.Background(), "u1", "u2", dto.RoleModerator)
+	require.NoError(t, err)
+	assert.Equal(t, dto.RoleModerator, user.Role)
+}
+
+func TestActiveUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, repo, now := newAdminUserService(ctrl, RegistrationOpen)
+
+	repo.EXPECT().GetUserById(gomock.Any(), "u1").Return(models.User{Id: "u1", Role: dto.RoleModerator}, nil)
+	repo.EXPECT().GetUserById(gomock.Any(), "u2").Return(models.User{Id: "u2", DisabledAt: &now}, nil)
+
+	role, err := service.ActiveUserRole(context.Background(), "u1")
+	require.NoError(t, err)
+	assert.Equal(t, dto.RoleModerator, role)
+
+	_, err = service.ActiveUserRole(context.Background(), "u2")
+	assert.ErrorIs(t, err, dto.ErrUserDisabled)
+}
+
+func TestRegistrationMode_Decode(t *testing.T) {
+	var mode RegistrationMode
+	require.NoError(t, mode.Decode("invite"))
+	assert.Equal(t, RegistrationInvite, mode)
+
+	assert.Error(t, mode.Decode("closed"))
+}
@@ -1,32 +1,37 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
 	"github.com/hamillka/avitoTechSpring25/internal/models"
+	"github.com/hamillka/avitoTechSpring25/internal/password"
 	"github.com/hamillka/avitoTechSpring25/internal/usecases/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var testPolicy = password.NewPolicy(password.Config{MinLength: 8, MaxLength: 72}, []string{"password123"})
+
 func TestUserRegister_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockUserRepository(ctrl)
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{})
 
-	repo.EXPECT().UserLogin("test@example.com", "password").Return(models.User{}, errors.New("not found"))
+	repo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "password").Return(models.User{}, errors.New("not found"))
 
-	repo.EXPECT().UserRegister("test@example.com", gomock.Any(), "user").
-		Return(models.User{Id: "u1", Email: "test@example.com", Role: "user"}, nil)
+	repo.EXPECT().UserRegister(gomock.Any(), "test@example.com", gomock.Any(), dto.RoleEmployee).
+		Return(models.User{Id: "u1", Email: "test@example.com", Role: dto.RoleEmployee}, nil)
 
-	user, err := service.UserRegister("test@example.com", "password", "user")
+	user, err := service.UserRegister(context.Background(), "test@example.com", "password", dto.RoleEmployee)
 
 	require.NoError(t, err)
 	assert.Equal(t, "u1", user.Id)
@@ -38,31 +43,60 @@ func TestUserRegister_AlreadyExists(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockUserRepository(ctrl)
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{})
 
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
 
-	repo.EXPECT().UserLogin("test@example.com", "password").
+	repo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "password").
 		Return(models.User{Id: "u1", Email: "test@example.com", Password: string(hashed)}, nil)
 
-	_, err := service.UserRegister("test@example.com", "password", "user")
+	_, err := service.UserRegister(context.Background(), "test@example.com", "password", dto.RoleEmployee)
 
 	assert.ErrorIs(t, err, dto.ErrUserAlreadyExists)
 }
 
+func TestUserRegister_NormalizesEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockUserRepository(ctrl)
+	service := NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{})
+
+	repo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "password").Return(models.User{}, dto.ErrInvalidCredentials)
+	repo.EXPECT().UserRegister(gomock.Any(), "test@example.com", gomock.Any(), dto.RoleEmployee).
+		Return(models.User{Id: "u1", Email: "test@example.com", Role: dto.RoleEmployee}, nil)
+
+	_, err := service.UserRegister(context.Background(), " Test@Example.com ", "password", dto.RoleEmployee)
+
+	require.NoError(t, err)
+}
+
+func TestUserRegister_WeakPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockUserRepository(ctrl)
+	service := NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{})
+
+	for _, pw := range []string{"", "short", "Password123"} {
+		_, err := service.UserRegister(context.Background(), "test@example.com", pw, dto.RoleEmployee)
+		assert.ErrorIs(t, err, dto.ErrWeakPassword, pw)
+	}
+}
+
 func TestUserLogin_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockUserRepository(ctrl)
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{})
 
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
 
-	repo.EXPECT().UserLogin("test@example.com", "password").
+	repo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "password").
 		Return(models.User{Id: "u1", Email: "test@example.com", Password: string(hashed)}, nil)
 
-	user, err := service.UserLogin("test@example.com", "password")
+	user, err := service.UserLogin(context.Background(), "test@example.com", "password", "")
 
 	require.NoError(t, err)
 	assert.Equal(t, "u1", user.Id)
@@ -73,14 +107,46 @@ func TestUserLogin_WrongPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mocks.NewMockUserRepository(ctrl)
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{})
 
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("correctpass"), bcrypt.DefaultCost)
 
-	repo.EXPECT().UserLogin("test@example.com", "wrongpass").
+	repo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "wrongpass").
 		Return(models.User{Id: "u1", Email: "test@example.com", Password: string(hashed)}, nil)
 
-	_, err := service.UserLogin("test@example.com", "wrongpass")
+	_, err := service.UserLogin(context.Background(), "test@example.com", "wrongpass", "")
 
 	assert.Error(t, err)
 }
+
+func TestUserRegister_Restricted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockUserRepository(ctrl)
+	service := NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{Mode: RegistrationOpen})
+
+	_, err := service.UserRegister(context.Background(), "test@example.com", "password", dto.RoleModerator)
+	assert.ErrorIs(t, err, dto.ErrRoleNotAllowed)
+
+	service = NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{Mode: RegistrationInvite})
+
+	_, err = service.UserRegister(context.Background(), "test@example.com", "password", dto.RoleEmployee)
+	assert.ErrorIs(t, err, dto.ErrRegistrationClosed)
+}
+
+func TestUserLogin_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockUserRepository(ctrl)
+	service := NewUserService(repo, nil, nil, testPolicy, nil, LoginProtectionConfig{}, PasswordResetConfig{}, RegistrationConfig{})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	disabledAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	repo.EXPECT().UserLogin(gomock.Any(), "test@example.com", "password").
+		Return(models.User{Id: "u1", Password: string(hashed), DisabledAt: &disabledAt}, nil)
+
+	_, err := service.UserLogin(context.Background(), "test@example.com", "password", "")
+	assert.ErrorIs(t, err, dto.ErrUserDisabled)
+}
@@ -2,29 +2,89 @@ CREATE DATABASE pvz_service;
 
 \connect "pvz_service";
 
+CREATE TABLE schema_version (
+    version INT NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO schema_version (version) VALUES (5);
+
 CREATE TABLE users (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    email TEXT NOT NULL UNIQUE,
+    email TEXT NOT NULL,
     password_hash TEXT NOT NULL,
-    role TEXT NOT NULL CHECK (role IN ('employee', 'moderator'))
+    role TEXT NOT NULL CHECK (role IN ('employee', 'moderator')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Отключенный модератором пользователь не может войти, а его токены отклоняются
+    disabled_at TIMESTAMPTZ
 );
 
+-- Почта не зависит от регистра: A@mail.ru и a@mail.ru — одна учетная запись
+CREATE UNIQUE INDEX users_email_lower_idx ON users (lower(email));
+
+-- Неудачные попытки входа по учетной записи (account:<email>) и по IP адресу (ip:<addr>)
+CREATE TABLE login_failures (
+    key TEXT PRIMARY KEY,
+    failures INT NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    blocked_until TIMESTAMPTZ NOT NULL
+);
+
+-- Токены сброса пароля. Хранится только sha256 от токена, сам токен знает
+-- лишь получатель письма
+CREATE TABLE password_reset_tokens (
+    token_hash TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
+
 CREATE TABLE pvzs (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     registration_date TIMESTAMPTZ DEFAULT NOW(),
-    city TEXT NOT NULL CHECK (city IN ('Москва', 'Санкт-Петербург', 'Казань'))
+    city TEXT NOT NULL CHECK (city IN ('Москва', 'Санкт-Петербург', 'Казань')),
+    name TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    working_hours TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive', 'archived')),
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    timezone TEXT NOT NULL DEFAULT 'Europe/Moscow'
 );
 
+CREATE INDEX pvzs_coordinates_idx ON pvzs (latitude, longitude) WHERE status <> 'archived';
+
 CREATE TABLE receptions (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     date_time TIMESTAMPTZ DEFAULT NOW(),
     pvz_id UUID NOT NULL REFERENCES pvzs(id) ON DELETE CASCADE,
-    status TEXT NOT NULL DEFAULT 'in_progress' CHECK (status IN ('in_progress', 'close'))
+    status TEXT NOT NULL DEFAULT 'in_progress' CHECK (
+        status IN ('in_progress', 'paused', 'close', 'cancelled', 'reopened_for_correction')
+    ),
+    close_reason TEXT
+);
+
+CREATE INDEX receptions_pvz_id_date_time_idx ON receptions (pvz_id, date_time);
+
+CREATE TABLE reception_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reception_id UUID NOT NULL REFERENCES receptions(id) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    changed_at TIMESTAMPTZ DEFAULT NOW(),
+    changed_by_role TEXT NOT NULL,
+    reason TEXT
 );
 
+CREATE INDEX reception_status_history_reception_id_idx ON reception_status_history (reception_id, changed_at);
+
 CREATE TABLE products (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     date_time TIMESTAMPTZ DEFAULT NOW(),
     product_type TEXT NOT NULL CHECK (product_type IN ('электроника', 'одежда', 'обувь')),
     reception_id UUID NOT NULL REFERENCES receptions(id) ON DELETE CASCADE
-);
\ No newline at end of file
+);
+
+CREATE INDEX products_reception_id_date_time_idx ON products (reception_id, date_time, id);
\ No newline at end of file
@@ -11,10 +11,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hamillka/avitoTechSpring25/internal/cache"
 	"github.com/hamillka/avitoTechSpring25/internal/db"
+	"github.com/hamillka/avitoTechSpring25/internal/gateway"
+	mygrpc "github.com/hamillka/avitoTechSpring25/internal/grpc"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers"
 	"github.com/hamillka/avitoTechSpring25/internal/handlers/dto"
+	"github.com/hamillka/avitoTechSpring25/internal/health"
 	"github.com/hamillka/avitoTechSpring25/internal/logger"
+	"github.com/hamillka/avitoTechSpring25/internal/notifier"
+	"github.com/hamillka/avitoTechSpring25/internal/password"
 	"github.com/hamillka/avitoTechSpring25/internal/repositories"
 	"github.com/hamillka/avitoTechSpring25/internal/usecases"
 	"github.com/jmoiron/sqlx"
@@ -38,19 +44,42 @@ func setupTestEnvironment(t *testing.T) (http.Handler, func()) {
 	logConfig := logger.LogConfig{
 		Level: zapcore.DebugLevel,
 	}
-	testLogger := logger.CreateLogger(logConfig)
+	testLogger, err := logger.CreateLogger(logConfig)
+	require.NoError(t, err)
 
-	pr := repositories.NewProductRepository(testDB)
-	pvzr := repositories.NewPVZRepository(testDB)
-	rr := repositories.NewReceptionRepository(testDB)
+	cluster := db.NewCluster(testDB)
+	pr := repositories.NewProductRepository(cluster)
+	pvzr := repositories.NewPVZRepository(cluster)
+	rr := repositories.NewReceptionRepository(cluster)
 	ur := repositories.NewUserRepository(testDB)
 
-	ps := usecases.NewProductService(pr, rr, pvzr)
-	pvzs := usecases.NewPVZService(pvzr, rr, pr)
-	rs := usecases.NewReceptionService(pvzr, rr)
-	us := usecases.NewUserService(ur)
+	ps := usecases.NewProductService(pr, rr, pvzr, cache.NewNoop())
+	pvzs := usecases.NewPVZService(pvzr, rr, pr, cache.NewNoop())
+	rs := usecases.NewReceptionService(pvzr, rr, pr, cache.NewNoop())
+	us := usecases.NewUserService(
+		ur,
+		repositories.NewLoginFailureRepository(testDB),
+		repositories.NewPasswordResetRepository(testDB),
+		password.NewPolicy(password.Config{}, nil),
+		notifier.NewLog(testLogger.SugaredLogger),
+		usecases.LoginProtectionConfig{},
+		usecases.PasswordResetConfig{},
+		usecases.RegistrationConfig{},
+	)
+
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", cluster.Ping)
+	checker.Add("schema", cluster.CheckSchema)
+
+	gw, err := gateway.New(
+		mygrpc.NewPVZServer(pvzs, testLogger.SugaredLogger),
+		mygrpc.NewReceptionServer(rs, testLogger.SugaredLogger),
+		mygrpc.NewProductServer(ps, testLogger.SugaredLogger),
+		mygrpc.NewUserServer(us, testLogger.SugaredLogger),
+	)
+	require.NoError(t, err)
 
-	router := handlers.Router(ps, pvzs, rs, us, testLogger)
+	router := handlers.Router(pvzs, rs, gw, gateway.Routes(), checker, us, nil, testLogger.SugaredLogger)
 
 	cleanup := func() {
 		err := testDB.Close()
@@ -58,9 +87,10 @@ func setupTestEnvironment(t *testing.T) (http.Handler, func()) {
 			testLogger.Errorf("Error closing test database: %v", err)
 		}
 
-		err = testLogger.Sync()
+		// логгер нужен тестам до конца, поэтому закрывается последним
+		err = testLogger.Close()
 		if err != nil {
-			testLogger.Errorf("Error while syncing logger: %v", err)
+			t.Logf("Error closing logger: %v", err)
 		}
 	}
 